@@ -0,0 +1,60 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the time. Containers that depend on time take a Clock so that tests can
+// control time deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// System returns a clock that tells the real time.
+func System() Clock {
+	return systemClock{}
+}
+
+// Manual is a clock that only moves when told to. It is safe for concurrent use.
+type Manual struct {
+	now   time.Time
+	mutex sync.RWMutex
+}
+
+// NewManual returns a manual clock set to the given time.
+func NewManual(now time.Time) *Manual {
+	return &Manual{
+		now:   now,
+		mutex: sync.RWMutex{},
+	}
+}
+
+func (c *Manual) Now() time.Time {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.now
+}
+
+// Advance moves the clock forward by the duration.
+func (c *Manual) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// Set sets the clock to the given time.
+func (c *Manual) Set(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = now
+}
@@ -0,0 +1,41 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaschnit/go-ds/pkg/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that the clocks implement Clock.
+var (
+	_ clock.Clock = clock.System()
+	_ clock.Clock = &clock.Manual{}
+)
+
+func TestSystem(t *testing.T) {
+	t.Parallel()
+
+	before := time.Now()
+	now := clock.System().Now()
+	after := time.Now()
+
+	assert.False(t, now.Before(before))
+	assert.False(t, now.After(after))
+}
+
+func TestManual(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	c := clock.NewManual(start)
+	assert.Equal(t, start, c.Now())
+	assert.Equal(t, start, c.Now())
+
+	c.Advance(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), c.Now())
+
+	c.Set(start)
+	assert.Equal(t, start, c.Now())
+}
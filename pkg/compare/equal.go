@@ -0,0 +1,17 @@
+package compare
+
+import "bytes"
+
+// Equal reports whether two values are considered equal. It is used along with a Hasher
+// by hash tables that work on values directly rather than converting them to a HashKey.
+type Equal[T any] func(left T, right T) bool
+
+// DefaultEqual compares two comparable values with ==.
+func DefaultEqual[T comparable](left T, right T) bool {
+	return left == right
+}
+
+// BytesEqual reports whether two byte slices have the same contents.
+func BytesEqual(left []byte, right []byte) bool {
+	return bytes.Equal(left, right)
+}
@@ -0,0 +1,32 @@
+package compare_test
+
+import (
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that the equality functions implement Equal.
+var (
+	_ compare.Equal[int]    = compare.DefaultEqual[int]
+	_ compare.Equal[[]byte] = compare.BytesEqual
+)
+
+func TestDefaultEqual(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, compare.DefaultEqual(1, 1))
+	assert.False(t, compare.DefaultEqual(1, 2))
+	assert.True(t, compare.DefaultEqual("foo", "foo"))
+	assert.True(t, compare.DefaultEqual(hasherTestStruct{"a", 1}, hasherTestStruct{"a", 1}))
+	assert.False(t, compare.DefaultEqual(hasherTestStruct{"a", 1}, hasherTestStruct{"a", 2}))
+}
+
+func TestBytesEqual(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, compare.BytesEqual([]byte("foo"), []byte("foo")))
+	assert.True(t, compare.BytesEqual(nil, []byte{}))
+	assert.False(t, compare.BytesEqual([]byte("foo"), []byte("fo")))
+}
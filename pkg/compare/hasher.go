@@ -0,0 +1,105 @@
+package compare
+
+import (
+	"hash/fnv"
+	"hash/maphash"
+	"math"
+)
+
+// Hasher is used to hash a value to a 64-bit integer. Values that are considered equal
+// must produce the same hash. This is useful for probabilistic data structures and hash tables
+// that need to derive several positions from a single value.
+type Hasher[T any] func(value T) uint64
+
+// Mix64 spreads the bits of x over the whole result with the finalizer from splitmix64.
+// It improves a hash whose bits are poorly mixed, such as FNV-1a's high bits.
+//
+//nolint:gomnd
+func Mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
+
+// comparableSeed is the seed for hashing values that are not of a primitive type.
+var comparableSeed = maphash.MakeSeed() //nolint:gochecknoglobals
+
+// DefaultHasher hashes any comparable value, hashing values that are equal with == the same.
+// Common primitive types are hashed the same way in every process. Other types, such as
+// structs and pointers, are hashed with hash/maphash using a seed chosen when the process
+// starts, so their hashes differ between processes. Use an explicit Hasher for those types
+// when hashes are saved or shared, such as by serializing a Bloom filter.
+func DefaultHasher[T comparable](value T) uint64 {
+	switch v := any(value).(type) {
+	case string:
+		return hashString(v)
+	case int:
+		return Mix64(uint64(v))
+	case int8:
+		return Mix64(uint64(v))
+	case int16:
+		return Mix64(uint64(v))
+	case int32:
+		return Mix64(uint64(v))
+	case int64:
+		return Mix64(uint64(v))
+	case uint:
+		return Mix64(uint64(v))
+	case uint8:
+		return Mix64(uint64(v))
+	case uint16:
+		return Mix64(uint64(v))
+	case uint32:
+		return Mix64(uint64(v))
+	case uint64:
+		return Mix64(v)
+	case uintptr:
+		return Mix64(uint64(v))
+	case float32:
+		return hashFloat(float64(v))
+	case float64:
+		return hashFloat(v)
+	case bool:
+		if v {
+			return Mix64(1)
+		}
+
+		return Mix64(0)
+	default:
+		return maphash.Comparable(comparableSeed, value)
+	}
+}
+
+// StringHasher hashes a string with 64-bit FNV-1a.
+func StringHasher(value string) uint64 {
+	return hashString(value)
+}
+
+// BytesHasher hashes a byte slice with 64-bit FNV-1a, producing the same hash as
+// StringHasher for the equivalent string.
+func BytesHasher(value []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(value)
+
+	return h.Sum64()
+}
+
+func hashString(value string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(value))
+
+	return h.Sum64()
+}
+
+func hashFloat(value float64) uint64 {
+	// Positive and negative zero are equal, so they must hash the same.
+	if value == 0 {
+		return Mix64(0)
+	}
+
+	return Mix64(math.Float64bits(value))
+}
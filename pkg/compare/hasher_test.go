@@ -0,0 +1,98 @@
+package compare_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that the hashers implement Hasher.
+var (
+	_ compare.Hasher[int]    = compare.DefaultHasher[int]
+	_ compare.Hasher[string] = compare.StringHasher
+	_ compare.Hasher[[]byte] = compare.BytesHasher
+)
+
+type hasherTestStruct struct {
+	name  string
+	count int
+}
+
+type hasherTestFloatStruct struct {
+	value float64
+}
+
+func TestDefaultHasher(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, compare.DefaultHasher(12345), compare.DefaultHasher(12345))
+	assert.NotEqual(t, compare.DefaultHasher(12345), compare.DefaultHasher(12346))
+
+	assert.Equal(t, compare.DefaultHasher("foo"), compare.DefaultHasher("foo"))
+	assert.NotEqual(t, compare.DefaultHasher("foo"), compare.DefaultHasher("bar"))
+	assert.Equal(t, compare.StringHasher("foo"), compare.DefaultHasher("foo"))
+	assert.Equal(t, compare.StringHasher("foo"), compare.BytesHasher([]byte("foo")))
+
+	assert.Equal(t, compare.DefaultHasher(0.0), compare.DefaultHasher(math.Copysign(0, -1)))
+	assert.NotEqual(t, compare.DefaultHasher(1.5), compare.DefaultHasher(2.5))
+	assert.NotEqual(t, compare.DefaultHasher(true), compare.DefaultHasher(false))
+
+	assert.Equal(
+		t,
+		compare.DefaultHasher(hasherTestStruct{name: "a", count: 1}),
+		compare.DefaultHasher(hasherTestStruct{name: "a", count: 1}),
+	)
+	assert.NotEqual(
+		t,
+		compare.DefaultHasher(hasherTestStruct{name: "a", count: 1}),
+		compare.DefaultHasher(hasherTestStruct{name: "a", count: 2}),
+	)
+
+	// Structs holding positive and negative zero are equal, so they hash the same.
+	assert.Equal(
+		t,
+		compare.DefaultHasher(hasherTestFloatStruct{value: 0}),
+		compare.DefaultHasher(hasherTestFloatStruct{value: math.Copysign(0, -1)}),
+	)
+
+	a, b := 1, 1
+	assert.Equal(t, compare.DefaultHasher(&a), compare.DefaultHasher(&a))
+	assert.NotEqual(t, compare.DefaultHasher(&a), compare.DefaultHasher(&b))
+}
+
+func TestDefaultHasherAllocations(t *testing.T) {
+	value := hasherTestStruct{name: "a", count: 1}
+
+	allocations := testing.AllocsPerRun(100, func() {
+		compare.DefaultHasher(value)
+	})
+	assert.Zero(t, allocations)
+}
+
+func TestDefaultHasherSpread(t *testing.T) {
+	t.Parallel()
+
+	// Sequential integers should land in different buckets of a small table.
+	buckets := make(map[uint64]int)
+	for i := 0; i < 1024; i++ {
+		buckets[compare.DefaultHasher(i)%64]++
+	}
+
+	assert.Len(t, buckets, 64)
+
+	for _, count := range buckets {
+		assert.Less(t, count, 40)
+	}
+}
+
+func TestMix64(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, uint64(0), compare.Mix64(0))
+	assert.Equal(t, compare.Mix64(12345), compare.DefaultHasher(12345))
+
+	// Values that differ only in their low bits differ in their high bits once mixed.
+	assert.NotEqual(t, compare.Mix64(1)>>56, compare.Mix64(2)>>56)
+}
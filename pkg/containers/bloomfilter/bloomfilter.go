@@ -0,0 +1,211 @@
+package bloomfilter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+)
+
+const (
+	// DefaultExpectedCount is the number of values a filter is sized for by default.
+	DefaultExpectedCount = 1000
+
+	// DefaultFalsePositiveRate is the false positive rate a filter is sized for by default.
+	DefaultFalsePositiveRate = 0.01
+
+	wordSize = 64
+)
+
+type Builder[T any] struct {
+	hasher            compare.Hasher[T]
+	expectedCount     int
+	falsePositiveRate float64
+}
+
+func NewBuilder[T any](hasher compare.Hasher[T]) *Builder[T] {
+	return &Builder[T]{
+		hasher:            hasher,
+		expectedCount:     DefaultExpectedCount,
+		falsePositiveRate: DefaultFalsePositiveRate,
+	}
+}
+
+// ExpectedCount sets the number of distinct values the filter is expected to hold.
+// Adding more than this makes false positives more likely than the false positive rate.
+func (b *Builder[T]) ExpectedCount(expectedCount int) *Builder[T] {
+	b.expectedCount = expectedCount
+
+	return b
+}
+
+// FalsePositiveRate sets the chance, once the expected number of values have been added,
+// that a value that was never added is reported as possibly added.
+func (b *Builder[T]) FalsePositiveRate(falsePositiveRate float64) *Builder[T] {
+	b.falsePositiveRate = falsePositiveRate
+
+	return b
+}
+
+// Build returns a Bloom filter of the configured size. Returns false if the expected count
+// is not positive or the false positive rate is not between 0 and 1.
+func (b *Builder[T]) Build() (*BloomFilter[T], bool) {
+	p, ok := newParams(b.expectedCount, b.falsePositiveRate)
+	if !ok {
+		return nil, false
+	}
+
+	return &BloomFilter[T]{
+		hasher: b.hasher,
+		params: p,
+		words:  make([]uint64, (p.positions+wordSize-1)/wordSize),
+	}, true
+}
+
+// BuildCounting returns a counting Bloom filter of the configured size. Returns false if the
+// expected count is not positive or the false positive rate is not between 0 and 1.
+func (b *Builder[T]) BuildCounting() (*CountingBloomFilter[T], bool) {
+	p, ok := newParams(b.expectedCount, b.falsePositiveRate)
+	if !ok {
+		return nil, false
+	}
+
+	return &CountingBloomFilter[T]{
+		hasher:   b.hasher,
+		params:   p,
+		counters: make([]uint8, p.positions),
+	}, true
+}
+
+// BloomFilter is a compact probabilistic set that can tell that a value was definitely never
+// added to it, but only that a value was possibly added. Each value sets a few bits chosen by
+// its hash, so memory use depends only on the expected count and false positive rate rather
+// than on the size of the values. Values cannot be removed; see CountingBloomFilter.
+type BloomFilter[T any] struct {
+	hasher compare.Hasher[T]
+	params params
+	words  []uint64
+}
+
+func New[T comparable](expectedCount int, falsePositiveRate float64) (*BloomFilter[T], bool) {
+	return NewBuilder(compare.DefaultHasher[T]).
+		ExpectedCount(expectedCount).
+		FalsePositiveRate(falsePositiveRate).
+		Build()
+}
+
+// Empty returns whether nothing has been added to the filter.
+func (f *BloomFilter[T]) Empty() bool {
+	return f.setBits() == 0
+}
+
+func (f *BloomFilter[T]) Clear() {
+	for i := range f.words {
+		f.words[i] = 0
+	}
+}
+
+func (f *BloomFilter[T]) String() string {
+	return fmt.Sprintf("BloomFilter\nbits=%d, hashes=%d, set=%d", f.params.positions, f.params.hashes, f.setBits())
+}
+
+func (f *BloomFilter[T]) Add(value T) {
+	f.params.forEachIndex(f.hasher(value), func(index uint64) bool {
+		f.words[index/wordSize] |= 1 << (index % wordSize)
+
+		return true
+	})
+}
+
+func (f *BloomFilter[T]) AddAll(values ...T) {
+	for _, value := range values {
+		f.Add(value)
+	}
+}
+
+// MightContain returns false if the value was definitely never added, and true if it
+// possibly was.
+func (f *BloomFilter[T]) MightContain(value T) bool {
+	return f.params.forEachIndex(f.hasher(value), func(index uint64) bool {
+		return f.words[index/wordSize]&(1<<(index%wordSize)) != 0
+	})
+}
+
+// Union adds everything that was added to the other filter to this filter. Both filters must
+// have been built with the same size and hasher. Returns false if their sizes differ.
+func (f *BloomFilter[T]) Union(other *BloomFilter[T]) bool {
+	if f.params != other.params {
+		return false
+	}
+
+	for i, word := range other.words {
+		f.words[i] |= word
+	}
+
+	return true
+}
+
+// EstimatedCount estimates the number of distinct values that have been added from the
+// number of bits that are set.
+func (f *BloomFilter[T]) EstimatedCount() int {
+	return f.params.estimateCount(f.setBits())
+}
+
+// EstimatedFalsePositiveRate estimates the current chance that a value that was never added is
+// reported as possibly added.
+func (f *BloomFilter[T]) EstimatedFalsePositiveRate() float64 {
+	return f.params.falsePositiveRate(f.setBits())
+}
+
+// MarshalBinary encodes the filter, which can be restored with UnmarshalBinary on a filter
+// with the same hasher, including in another process. The hasher must hash values the same way
+// in both processes, which compare.DefaultHasher only does for primitive types.
+func (f *BloomFilter[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, headerSize+len(f.words)*wordSize/8)
+	data = f.params.appendHeader(data, bloomFilterFormat)
+
+	for _, word := range f.words {
+		data = binary.LittleEndian.AppendUint64(data, word)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents and size of the filter with the encoded filter,
+// keeping its hasher.
+func (f *BloomFilter[T]) UnmarshalBinary(data []byte) error {
+	p, data, err := readHeader(data, bloomFilterFormat)
+	if err != nil {
+		return err
+	}
+
+	// Check the length before allocating, since the header may claim any number of positions.
+	wordCount := p.positions / wordSize
+	if p.positions%wordSize != 0 {
+		wordCount++
+	}
+
+	if uint64(len(data))%(wordSize/8) != 0 || uint64(len(data))/(wordSize/8) != wordCount {
+		return ErrInvalidEncoding
+	}
+
+	words := make([]uint64, wordCount)
+
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[i*wordSize/8:])
+	}
+
+	f.params, f.words = p, words
+
+	return nil
+}
+
+func (f *BloomFilter[T]) setBits() uint64 {
+	set := 0
+	for _, word := range f.words {
+		set += bits.OnesCount64(word)
+	}
+
+	return uint64(set)
+}
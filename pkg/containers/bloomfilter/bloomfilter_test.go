@@ -0,0 +1,189 @@
+package bloomfilter_test
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/bloomfilter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBloomFilterBuild(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		expectedCount     int
+		falsePositiveRate float64
+		ok                bool
+	}{
+		{name: "valid", expectedCount: 100, falsePositiveRate: 0.01, ok: true},
+		{name: "zero count", expectedCount: 0, falsePositiveRate: 0.01, ok: false},
+		{name: "negative count", expectedCount: -1, falsePositiveRate: 0.01, ok: false},
+		{name: "zero rate", expectedCount: 100, falsePositiveRate: 0, ok: false},
+		{name: "rate of one", expectedCount: 100, falsePositiveRate: 1, ok: false},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, ok := bloomfilter.New[int](testCase.expectedCount, testCase.falsePositiveRate)
+			assert.Equal(t, testCase.ok, ok)
+			assert.Equal(t, testCase.ok, f != nil)
+
+			c, ok := bloomfilter.NewCounting[int](testCase.expectedCount, testCase.falsePositiveRate)
+			assert.Equal(t, testCase.ok, ok)
+			assert.Equal(t, testCase.ok, c != nil)
+		})
+	}
+}
+
+func TestBloomFilterMightContain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		expectedCount     int
+		falsePositiveRate float64
+	}{
+		{name: "1% of 1000", expectedCount: 1000, falsePositiveRate: 0.01},
+		{name: "0.1% of 5000", expectedCount: 5000, falsePositiveRate: 0.001},
+		{name: "10% of 200", expectedCount: 200, falsePositiveRate: 0.1},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, ok := bloomfilter.New[int](testCase.expectedCount, testCase.falsePositiveRate)
+			assert.True(t, ok)
+			assert.True(t, f.Empty())
+
+			for i := 0; i < testCase.expectedCount; i++ {
+				f.Add(i)
+			}
+
+			assert.False(t, f.Empty())
+			for i := 0; i < testCase.expectedCount; i++ {
+				assert.True(t, f.MightContain(i))
+			}
+
+			trials := 100000
+			falsePositives := 0
+			for i := 0; i < trials; i++ {
+				if f.MightContain(testCase.expectedCount + i) {
+					falsePositives++
+				}
+			}
+
+			rate := float64(falsePositives) / float64(trials)
+			assert.Less(t, rate, 2*testCase.falsePositiveRate)
+			assert.InDelta(t, testCase.falsePositiveRate, f.EstimatedFalsePositiveRate(), testCase.falsePositiveRate)
+			assert.InEpsilon(t, testCase.expectedCount, f.EstimatedCount(), 0.1)
+		})
+	}
+}
+
+func TestBloomFilterHasher(t *testing.T) {
+	t.Parallel()
+
+	hasher := func(value []string) uint64 {
+		return compare.StringHasher(strings.Join(value, "/"))
+	}
+	f, ok := bloomfilter.NewBuilder(hasher).ExpectedCount(10).FalsePositiveRate(0.001).Build()
+	assert.True(t, ok)
+
+	f.AddAll([]string{"usr", "bin"}, []string{"etc"})
+	assert.True(t, f.MightContain([]string{"usr", "bin"}))
+	assert.True(t, f.MightContain([]string{"etc"}))
+	assert.False(t, f.MightContain([]string{"usr", "lib"}))
+
+	f.Clear()
+	assert.True(t, f.Empty())
+	assert.False(t, f.MightContain([]string{"etc"}))
+}
+
+func TestBloomFilterUnion(t *testing.T) {
+	t.Parallel()
+
+	a, _ := bloomfilter.New[string](100, 0.01)
+	b, _ := bloomfilter.New[string](100, 0.01)
+	a.AddAll("a", "b", "c")
+	b.AddAll("c", "d")
+
+	assert.True(t, a.Union(b))
+	for _, value := range []string{"a", "b", "c", "d"} {
+		assert.True(t, a.MightContain(value))
+	}
+	assert.Equal(t, 4, a.EstimatedCount())
+	assert.False(t, b.MightContain("a"))
+
+	other, _ := bloomfilter.New[string](1000, 0.01)
+	assert.False(t, a.Union(other))
+}
+
+func TestBloomFilterMarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	f, _ := bloomfilter.New[int](500, 0.01)
+	for i := 0; i < 500; i += 2 {
+		f.Add(i)
+	}
+
+	data, err := f.MarshalBinary()
+	assert.NoError(t, err)
+
+	// The size of the filter comes from the data rather than the filter being unmarshaled into.
+	restored, _ := bloomfilter.New[int](1, 0.5)
+	assert.NoError(t, restored.UnmarshalBinary(data))
+	assert.Equal(t, f.String(), restored.String())
+	for i := 0; i < 500; i += 2 {
+		assert.True(t, restored.MightContain(i))
+	}
+
+	other, _ := bloomfilter.New[int](500, 0.01)
+	other.Add(1)
+	assert.True(t, restored.Union(other))
+	assert.True(t, restored.MightContain(1))
+
+	assert.ErrorIs(t, restored.UnmarshalBinary(nil), bloomfilter.ErrInvalidEncoding)
+	assert.ErrorIs(t, restored.UnmarshalBinary(data[:len(data)-1]), bloomfilter.ErrInvalidEncoding)
+
+	counting, _ := bloomfilter.NewCounting[int](500, 0.01)
+	assert.ErrorIs(t, counting.UnmarshalBinary(data), bloomfilter.ErrInvalidEncoding)
+}
+
+func TestBloomFilterUnmarshalBinaryCorruptHeader(t *testing.T) {
+	t.Parallel()
+
+	f, _ := bloomfilter.New[int](500, 0.01)
+	data, err := f.MarshalBinary()
+	assert.NoError(t, err)
+
+	// The header is the format, the number of hashes and then the number of positions.
+	for _, positions := range []uint64{0, 1 << 60, math.MaxUint64, 1<<6 + 1} {
+		header := binary.LittleEndian.AppendUint64(append([]byte{}, data[:5]...), positions)
+
+		// A header claiming more positions than the data holds is rejected without allocating them.
+		restored, _ := bloomfilter.New[int](1, 0.5)
+		assert.ErrorIs(t, restored.UnmarshalBinary(header), bloomfilter.ErrInvalidEncoding)
+		assert.ErrorIs(t, restored.UnmarshalBinary(append(header, data[13:]...)), bloomfilter.ErrInvalidEncoding)
+		assert.Equal(t, "bits=2, hashes=1, set=0", strings.Split(restored.String(), "\n")[1])
+	}
+}
+
+func TestBloomFilterString(t *testing.T) {
+	t.Parallel()
+
+	f, _ := bloomfilter.New[int](1, 0.5)
+	f.Add(1)
+
+	resultLines := strings.Split(f.String(), "\n")
+	assert.Len(t, resultLines, 2)
+	assert.Equal(t, "BloomFilter", resultLines[0])
+	assert.Equal(t, "bits=2, hashes=1, set=1", resultLines[1])
+}
@@ -0,0 +1,157 @@
+package bloomfilter
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+)
+
+// CountingBloomFilter is a Bloom filter that keeps a small counter in place of each bit,
+// which lets values be removed at the cost of using eight times the memory.
+// A counter that reaches its maximum stays there, since it is no longer known how many values
+// share it. Removing a value that was never added can cause false negatives for other values.
+type CountingBloomFilter[T any] struct {
+	hasher   compare.Hasher[T]
+	params   params
+	counters []uint8
+}
+
+func NewCounting[T comparable](expectedCount int, falsePositiveRate float64) (*CountingBloomFilter[T], bool) {
+	return NewBuilder(compare.DefaultHasher[T]).
+		ExpectedCount(expectedCount).
+		FalsePositiveRate(falsePositiveRate).
+		BuildCounting()
+}
+
+// Empty returns whether nothing remains in the filter.
+func (f *CountingBloomFilter[T]) Empty() bool {
+	return f.setCounters() == 0
+}
+
+func (f *CountingBloomFilter[T]) Clear() {
+	for i := range f.counters {
+		f.counters[i] = 0
+	}
+}
+
+func (f *CountingBloomFilter[T]) String() string {
+	return fmt.Sprintf(
+		"CountingBloomFilter\ncounters=%d, hashes=%d, set=%d",
+		f.params.positions,
+		f.params.hashes,
+		f.setCounters(),
+	)
+}
+
+func (f *CountingBloomFilter[T]) Add(value T) {
+	f.params.forEachIndex(f.hasher(value), func(index uint64) bool {
+		if f.counters[index] < math.MaxUint8 {
+			f.counters[index]++
+		}
+
+		return true
+	})
+}
+
+func (f *CountingBloomFilter[T]) AddAll(values ...T) {
+	for _, value := range values {
+		f.Add(value)
+	}
+}
+
+// Remove removes one occurrence of a value that was added. Returns false without removing
+// anything if the value was definitely never added.
+func (f *CountingBloomFilter[T]) Remove(value T) bool {
+	hash := f.hasher(value)
+	if !f.mightContain(hash) {
+		return false
+	}
+
+	f.params.forEachIndex(hash, func(index uint64) bool {
+		if f.counters[index] < math.MaxUint8 {
+			f.counters[index]--
+		}
+
+		return true
+	})
+
+	return true
+}
+
+// MightContain returns false if the value was definitely never added or has been removed,
+// and true if it possibly was added.
+func (f *CountingBloomFilter[T]) MightContain(value T) bool {
+	return f.mightContain(f.hasher(value))
+}
+
+// Union adds everything that was added to the other filter to this filter. Both filters must
+// have been built with the same size and hasher. Returns false if their sizes differ.
+func (f *CountingBloomFilter[T]) Union(other *CountingBloomFilter[T]) bool {
+	if f.params != other.params {
+		return false
+	}
+
+	for i, counter := range other.counters {
+		f.counters[i] = uint8(math.Min(float64(f.counters[i])+float64(counter), math.MaxUint8))
+	}
+
+	return true
+}
+
+// EstimatedCount estimates the number of distinct values in the filter from the number of
+// counters that are not zero.
+func (f *CountingBloomFilter[T]) EstimatedCount() int {
+	return f.params.estimateCount(f.setCounters())
+}
+
+// EstimatedFalsePositiveRate estimates the current chance that a value that is not in the
+// filter is reported as possibly added.
+func (f *CountingBloomFilter[T]) EstimatedFalsePositiveRate() float64 {
+	return f.params.falsePositiveRate(f.setCounters())
+}
+
+// MarshalBinary encodes the filter, which can be restored with UnmarshalBinary on a filter
+// with the same hasher, including in another process. The hasher must hash values the same way
+// in both processes, which compare.DefaultHasher only does for primitive types.
+func (f *CountingBloomFilter[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, headerSize+len(f.counters))
+	data = f.params.appendHeader(data, countingFilterFormat)
+
+	return append(data, f.counters...), nil
+}
+
+// UnmarshalBinary replaces the contents and size of the filter with the encoded filter,
+// keeping its hasher.
+func (f *CountingBloomFilter[T]) UnmarshalBinary(data []byte) error {
+	p, data, err := readHeader(data, countingFilterFormat)
+	if err != nil {
+		return err
+	}
+
+	if uint64(len(data)) != p.positions {
+		return ErrInvalidEncoding
+	}
+
+	f.params, f.counters = p, append([]uint8{}, data...)
+
+	return nil
+}
+
+func (f *CountingBloomFilter[T]) mightContain(hash uint64) bool {
+	return f.params.forEachIndex(hash, func(index uint64) bool {
+		return f.counters[index] != 0
+	})
+}
+
+func (f *CountingBloomFilter[T]) setCounters() uint64 {
+	set := uint64(0)
+
+	for _, counter := range f.counters {
+		if counter != 0 {
+			set++
+		}
+	}
+
+	return set
+}
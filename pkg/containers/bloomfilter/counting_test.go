@@ -0,0 +1,122 @@
+package bloomfilter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/bloomfilter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountingBloomFilterRemove(t *testing.T) {
+	t.Parallel()
+
+	f, ok := bloomfilter.NewCounting[int](1000, 0.01)
+	assert.True(t, ok)
+
+	for i := 0; i < 1000; i++ {
+		f.Add(i)
+	}
+	assert.InEpsilon(t, 1000, f.EstimatedCount(), 0.1)
+
+	for i := 0; i < 1000; i += 2 {
+		assert.True(t, f.Remove(i))
+	}
+
+	for i := 1; i < 1000; i += 2 {
+		assert.True(t, f.MightContain(i))
+	}
+	assert.InEpsilon(t, 500, f.EstimatedCount(), 0.1)
+
+	for i := 1; i < 1000; i += 2 {
+		assert.True(t, f.Remove(i))
+	}
+
+	assert.True(t, f.Empty())
+	assert.False(t, f.MightContain(1))
+	assert.False(t, f.Remove(1))
+}
+
+func TestCountingBloomFilterDuplicates(t *testing.T) {
+	t.Parallel()
+
+	f, _ := bloomfilter.NewCounting[string](100, 0.01)
+	f.AddAll("a", "a", "b")
+
+	assert.True(t, f.Remove("a"))
+	assert.True(t, f.MightContain("a"))
+	assert.True(t, f.Remove("a"))
+	assert.False(t, f.MightContain("a"))
+	assert.True(t, f.MightContain("b"))
+
+	f.Clear()
+	assert.True(t, f.Empty())
+}
+
+func TestCountingBloomFilterSaturation(t *testing.T) {
+	t.Parallel()
+
+	f, _ := bloomfilter.NewCounting[string](100, 0.01)
+	for i := 0; i < 300; i++ {
+		f.Add("a")
+	}
+
+	// Saturated counters are never decremented, so the value can't be removed completely.
+	for i := 0; i < 300; i++ {
+		f.Remove("a")
+	}
+	assert.True(t, f.MightContain("a"))
+}
+
+func TestCountingBloomFilterUnion(t *testing.T) {
+	t.Parallel()
+
+	a, _ := bloomfilter.NewCounting[string](100, 0.01)
+	b, _ := bloomfilter.NewCounting[string](100, 0.01)
+	a.AddAll("a", "b")
+	b.AddAll("b", "c")
+
+	assert.True(t, a.Union(b))
+	assert.True(t, a.MightContain("c"))
+
+	// b was added to both, so it remains after being removed once.
+	assert.True(t, a.Remove("b"))
+	assert.True(t, a.MightContain("b"))
+
+	other, _ := bloomfilter.NewCounting[string](100, 0.001)
+	assert.False(t, a.Union(other))
+}
+
+func TestCountingBloomFilterMarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	f, _ := bloomfilter.NewCounting[int](200, 0.01)
+	f.AddAll(1, 2, 2, 3)
+
+	data, err := f.MarshalBinary()
+	assert.NoError(t, err)
+
+	restored, _ := bloomfilter.NewCounting[int](1, 0.5)
+	assert.NoError(t, restored.UnmarshalBinary(data))
+	assert.Equal(t, f.String(), restored.String())
+
+	assert.True(t, restored.Remove(2))
+	assert.True(t, restored.MightContain(2))
+	assert.True(t, f.MightContain(1))
+
+	assert.ErrorIs(t, restored.UnmarshalBinary(append(data, 0)), bloomfilter.ErrInvalidEncoding)
+
+	plain, _ := bloomfilter.New[int](200, 0.01)
+	assert.ErrorIs(t, plain.UnmarshalBinary(data), bloomfilter.ErrInvalidEncoding)
+}
+
+func TestCountingBloomFilterString(t *testing.T) {
+	t.Parallel()
+
+	f, _ := bloomfilter.NewCounting[int](1, 0.5)
+
+	resultLines := strings.Split(f.String(), "\n")
+	assert.Len(t, resultLines, 2)
+	assert.Equal(t, "CountingBloomFilter", resultLines[0])
+	assert.Equal(t, "counters=2, hashes=1, set=0", resultLines[1])
+}
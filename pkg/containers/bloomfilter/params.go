@@ -0,0 +1,105 @@
+package bloomfilter
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// ErrInvalidEncoding is returned when unmarshaling data that was not produced by marshaling
+// the same kind of filter.
+var ErrInvalidEncoding = errors.New("bloomfilter: invalid encoding")
+
+const (
+	bloomFilterFormat    byte = 1
+	countingFilterFormat byte = 2
+
+	// headerSize is the size of the format, the number of hashes and the number of positions.
+	headerSize = 1 + 4 + 8
+)
+
+// params are the dimensions of a filter, which must match for filters to be combined.
+type params struct {
+	// positions is the number of bits or counters in the filter.
+	positions uint64
+
+	// hashes is the number of positions that each value sets.
+	hashes int
+}
+
+// newParams returns the dimensions that give the false positive rate once the expected number
+// of values have been added, using as few positions as possible.
+func newParams(expectedCount int, falsePositiveRate float64) (params, bool) {
+	if expectedCount <= 0 || falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		return params{positions: 0, hashes: 0}, false
+	}
+
+	n := float64(expectedCount)
+	positions := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashes := math.Max(1, math.Round(positions/n*math.Ln2))
+
+	return params{
+		positions: uint64(positions),
+		hashes:    int(hashes),
+	}, true
+}
+
+// forEachIndex calls the op with each position of the hash, stopping early if it returns false.
+// The positions are derived from the two halves of the hash using double hashing.
+//
+//nolint:gomnd
+func (p params) forEachIndex(hash uint64, op func(index uint64) bool) bool {
+	h1 := hash
+	h2 := (hash >> 32) | 1
+
+	for i := 0; i < p.hashes; i++ {
+		if !op((h1 + uint64(i)*h2) % p.positions) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// estimateCount estimates the number of distinct values added from the number of positions
+// that are set.
+func (p params) estimateCount(set uint64) int {
+	if set >= p.positions {
+		return math.MaxInt
+	}
+
+	m, k := float64(p.positions), float64(p.hashes)
+
+	return int(math.Round(-m / k * math.Log(1-float64(set)/m)))
+}
+
+// falsePositiveRate estimates the chance that a value that was never added is reported as
+// possibly added, from the number of positions that are set.
+func (p params) falsePositiveRate(set uint64) float64 {
+	return math.Pow(float64(set)/float64(p.positions), float64(p.hashes))
+}
+
+func (p params) appendHeader(data []byte, format byte) []byte {
+	data = append(data, format)
+	data = binary.LittleEndian.AppendUint32(data, uint32(p.hashes))
+
+	return binary.LittleEndian.AppendUint64(data, p.positions)
+}
+
+// readHeader reads the dimensions of a filter of the format, and returns the rest of the data.
+func readHeader(data []byte, format byte) (params, []byte, error) {
+	if len(data) < headerSize || data[0] != format {
+		return params{positions: 0, hashes: 0}, nil, ErrInvalidEncoding
+	}
+
+	p := params{
+		hashes:    int(binary.LittleEndian.Uint32(data[1:])),
+		positions: binary.LittleEndian.Uint64(data[5:]),
+	}
+
+	if p.hashes <= 0 || p.positions == 0 {
+		return params{positions: 0, hashes: 0}, nil, ErrInvalidEncoding
+	}
+
+	return p, data[headerSize:], nil
+}
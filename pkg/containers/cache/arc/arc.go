@@ -0,0 +1,284 @@
+package arc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/cache"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/map/linkedhashmap"
+)
+
+type Builder[K any, HK comparable, V any] struct {
+	capacity int
+	hashkey  compare.HashKey[K, HK]
+	onEvict  cache.EvictionCallback[K, V]
+}
+
+func NewBuilder[K any, HK comparable, V any](capacity int, hashkey compare.HashKey[K, HK]) *Builder[K, HK, V] {
+	return &Builder[K, HK, V]{
+		capacity: capacity,
+		hashkey:  hashkey,
+	}
+}
+
+func (b *Builder[K, HK, V]) OnEvict(onEvict cache.EvictionCallback[K, V]) *Builder[K, HK, V] {
+	b.onEvict = onEvict
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) Build() *ARC[K, HK, V] {
+	return &ARC[K, HK, V]{
+		capacity:       b.capacity,
+		target:         0,
+		recent:         linkedhashmap.NewBuilder[K, HK, V](b.hashkey).Build(),
+		frequent:       linkedhashmap.NewBuilder[K, HK, V](b.hashkey).Build(),
+		recentGhosts:   linkedhashmap.NewBuilder[K, HK, struct{}](b.hashkey).Build(),
+		frequentGhosts: linkedhashmap.NewBuilder[K, HK, struct{}](b.hashkey).Build(),
+		onEvict:        b.onEvict,
+		stats:          cache.Stats{},
+	}
+}
+
+// ARC is an adaptive replacement cache. It splits its capacity between entries that have been
+// used once recently and entries that have been used more than once, and remembers the keys
+// recently evicted from each part. A miss on a remembered key shifts capacity towards the part
+// it was evicted from, so the cache adapts between recency and frequency as the workload changes
+// and a single scan over many keys cannot flush the frequently used entries.
+// A capacity of zero or less means nothing is cached.
+type ARC[K any, HK comparable, V any] struct {
+	capacity int
+
+	// target is the adaptive number of entries that recent aims to hold.
+	target int
+
+	// Entries used once recently (T1) and entries used at least twice (T2),
+	// each ordered from least recently to most recently used.
+	recent   *linkedhashmap.LinkedHashMap[K, HK, V]
+	frequent *linkedhashmap.LinkedHashMap[K, HK, V]
+
+	// Keys recently evicted from recent (B1) and from frequent (B2).
+	recentGhosts   *linkedhashmap.LinkedHashMap[K, HK, struct{}]
+	frequentGhosts *linkedhashmap.LinkedHashMap[K, HK, struct{}]
+
+	onEvict cache.EvictionCallback[K, V]
+	stats   cache.Stats
+}
+
+func New[K comparable, V any](capacity int) *ARC[K, K, V] {
+	return NewBuilder[K, K, V](capacity, compare.IdentityHashKey[K]).Build()
+}
+
+func (c *ARC[K, HK, V]) Empty() bool {
+	return c.Size() == 0
+}
+
+func (c *ARC[K, HK, V]) Size() int {
+	return c.recent.Size() + c.frequent.Size()
+}
+
+func (c *ARC[K, HK, V]) Clear() {
+	c.recent.Clear()
+	c.frequent.Clear()
+	c.recentGhosts.Clear()
+	c.frequentGhosts.Clear()
+	c.target = 0
+}
+
+func (c *ARC[K, HK, V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("ARC[capacity=%d]\n", c.capacity))
+
+	strs := make([]string, 0, c.Size())
+	appendEntry := func(key K, value V) {
+		strs = append(strs, entry.NewRef(key, value).String())
+	}
+
+	c.recent.ForEach(appendEntry)
+	c.frequent.ForEach(appendEntry)
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (c *ARC[K, HK, V]) Get(key K) (V, bool) {
+	if value, ok := c.recent.Get(key); ok {
+		c.stats.Hits++
+		c.recent.RemoveKey(key)
+		c.frequent.Put(key, value)
+
+		return value, true
+	}
+
+	if value, ok := c.frequent.Get(key); ok {
+		c.stats.Hits++
+		c.frequent.MoveToBack(key)
+
+		return value, true
+	}
+
+	c.stats.Misses++
+
+	return *new(V), false
+}
+
+func (c *ARC[K, HK, V]) Peek(key K) (V, bool) {
+	if value, ok := c.recent.Get(key); ok {
+		return value, true
+	}
+
+	return c.frequent.Get(key)
+}
+
+func (c *ARC[K, HK, V]) Put(key K, value V) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	// The key is cached, so this is a repeated use.
+	if c.recent.RemoveKey(key) {
+		c.frequent.Put(key, value)
+
+		return
+	}
+
+	if c.frequent.ContainsKey(key) {
+		c.frequent.Put(key, value)
+		c.frequent.MoveToBack(key)
+
+		return
+	}
+
+	// The key was recently evicted from recent, so recent should have been bigger.
+	if c.recentGhosts.ContainsKey(key) {
+		c.target = min(c.capacity, c.target+max(c.frequentGhosts.Size()/c.recentGhosts.Size(), 1))
+		c.makeRoom(false)
+		c.recentGhosts.RemoveKey(key)
+		c.frequent.Put(key, value)
+
+		return
+	}
+
+	// The key was recently evicted from frequent, so frequent should have been bigger.
+	if c.frequentGhosts.ContainsKey(key) {
+		c.target = max(0, c.target-max(c.recentGhosts.Size()/c.frequentGhosts.Size(), 1))
+		c.makeRoom(true)
+		c.frequentGhosts.RemoveKey(key)
+		c.frequent.Put(key, value)
+
+		return
+	}
+
+	// The key has not been seen recently, make room for it in recent.
+	recentSide := c.recent.Size() + c.recentGhosts.Size()
+	total := recentSide + c.frequent.Size() + c.frequentGhosts.Size()
+
+	if recentSide >= c.capacity {
+		if c.recent.Size() < c.capacity {
+			removeFront(c.recentGhosts)
+			c.makeRoom(false)
+		} else {
+			c.evictFront(c.recent, nil)
+		}
+	} else if total >= c.capacity {
+		if total >= 2*c.capacity {
+			removeFront(c.frequentGhosts)
+		}
+
+		c.makeRoom(false)
+	}
+
+	c.recent.Put(key, value)
+}
+
+func (c *ARC[K, HK, V]) Remove(key K) bool {
+	c.recentGhosts.RemoveKey(key)
+	c.frequentGhosts.RemoveKey(key)
+
+	return c.recent.RemoveKey(key) || c.frequent.RemoveKey(key)
+}
+
+func (c *ARC[K, HK, V]) ContainsKey(key K) bool {
+	return c.recent.ContainsKey(key) || c.frequent.ContainsKey(key)
+}
+
+func (c *ARC[K, HK, V]) Capacity() int {
+	return c.capacity
+}
+
+func (c *ARC[K, HK, V]) Resize(capacity int) int {
+	c.capacity = capacity
+	c.target = max(0, min(c.target, c.capacity))
+	evicted := 0
+
+	for c.Size() > c.capacity && c.Size() > 0 {
+		c.replace(false)
+		evicted++
+	}
+
+	// Keep the ghost lists within the bounds for the new capacity.
+	for c.recent.Size()+c.recentGhosts.Size() > c.capacity && !c.recentGhosts.Empty() {
+		removeFront(c.recentGhosts)
+	}
+
+	for c.Size()+c.recentGhosts.Size()+c.frequentGhosts.Size() > 2*c.capacity && !c.frequentGhosts.Empty() {
+		removeFront(c.frequentGhosts)
+	}
+
+	return evicted
+}
+
+func (c *ARC[K, HK, V]) Stats() cache.Stats {
+	return c.stats
+}
+
+// makeRoom evicts an entry if the cache is full. The cache may have room even when the
+// ghost lists are full if entries were removed.
+func (c *ARC[K, HK, V]) makeRoom(frequentGhostHit bool) {
+	if c.Size() >= c.capacity {
+		c.replace(frequentGhostHit)
+	}
+}
+
+// replace evicts one entry from either recent or frequent, depending on whether recent is
+// over its target size, and remembers the evicted key in the matching ghost list.
+func (c *ARC[K, HK, V]) replace(frequentGhostHit bool) {
+	recentSize := c.recent.Size()
+	if recentSize > 0 && (recentSize > c.target || (frequentGhostHit && recentSize == c.target)) {
+		c.evictFront(c.recent, c.recentGhosts)
+	} else if !c.frequent.Empty() {
+		c.evictFront(c.frequent, c.frequentGhosts)
+	} else {
+		c.evictFront(c.recent, c.recentGhosts)
+	}
+}
+
+func (c *ARC[K, HK, V]) evictFront(
+	entries *linkedhashmap.LinkedHashMap[K, HK, V], ghosts *linkedhashmap.LinkedHashMap[K, HK, struct{}],
+) {
+	key, value, ok := entries.Front()
+	if !ok {
+		return
+	}
+
+	entries.RemoveKey(key)
+
+	if ghosts != nil {
+		ghosts.Put(key, struct{}{})
+	}
+
+	c.stats.Evictions++
+
+	if c.onEvict != nil {
+		c.onEvict(key, value)
+	}
+}
+
+func removeFront[K any, HK comparable, V any](m *linkedhashmap.LinkedHashMap[K, HK, V]) {
+	if key, _, ok := m.Front(); ok {
+		m.RemoveKey(key)
+	}
+}
@@ -0,0 +1,87 @@
+package arc_test
+
+import (
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/cache"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/arc"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that ARC implements Cache.
+var _ cache.Cache[string, int] = &arc.ARC[string, string, int]{}
+
+func TestARCString(t *testing.T) {
+	t.Parallel()
+
+	c := arc.New[int, string](3)
+	assert.Equal(t, "ARC[capacity=3]\n", c.String())
+
+	c.Put(1, "a")
+	c.Put(2, "b")
+	c.Get(1)
+	assert.Equal(t, "ARC[capacity=3]\nEntry{Key:2, Value:b},Entry{Key:1, Value:a}", c.String())
+}
+
+func TestARCScanResistance(t *testing.T) {
+	t.Parallel()
+
+	c := arc.New[int, int](10)
+
+	// Make a few keys frequently used.
+	for round := 0; round < 3; round++ {
+		for key := 0; key < 5; key++ {
+			c.Put(key, key)
+			c.Get(key)
+		}
+	}
+
+	// Scan over many keys that are used once.
+	for key := 100; key < 200; key++ {
+		c.Put(key, key)
+	}
+
+	for key := 0; key < 5; key++ {
+		assert.True(t, c.ContainsKey(key), "frequently used key %d was evicted by a scan", key)
+	}
+
+	assert.Equal(t, 10, c.Size())
+}
+
+func TestARCGhostHit(t *testing.T) {
+	t.Parallel()
+
+	evicted := []int{}
+	c := arc.NewBuilder[int, int, int](2, func(key int) int { return key }).
+		OnEvict(func(key int, _ int) {
+			evicted = append(evicted, key)
+		}).
+		Build()
+
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3)
+	assert.Equal(t, []int{1}, evicted)
+	assert.False(t, c.ContainsKey(1))
+
+	// 1 was evicted recently, so putting it again treats it as frequently used.
+	c.Put(1, 10)
+	assert.True(t, c.ContainsKey(1))
+	assert.Equal(t, 2, c.Size())
+
+	value, ok := c.Peek(1)
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+
+	assert.True(t, c.Remove(1))
+	assert.Equal(t, 1, c.Size())
+
+	// Removing made room, so nothing is evicted.
+	evictedBefore := len(evicted)
+	c.Put(4, 4)
+	assert.Len(t, evicted, evictedBefore)
+	assert.Equal(t, 2, c.Size())
+
+	c.Clear()
+	assert.True(t, c.Empty())
+}
@@ -0,0 +1,52 @@
+package cache
+
+import "github.com/kaschnit/go-ds/pkg/containers/container"
+
+// EvictionCallback is called with an entry that a cache evicted to stay within its capacity.
+type EvictionCallback[K any, V any] func(key K, value V)
+
+// Stats holds counters describing how a cache has been used.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// HitRatio returns the fraction of lookups that were hits, or 0 if there were no lookups.
+func (s Stats) HitRatio() float64 {
+	lookups := s.Hits + s.Misses
+	if lookups == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(lookups)
+}
+
+// Cache is a bounded key-value container that evicts entries according to a policy
+// once it reaches its capacity.
+type Cache[K any, V any] interface {
+	container.Container
+
+	// Get returns the value for the key, counting as a use of the entry for the eviction
+	// policy and as a hit or a miss in the stats.
+	Get(key K) (value V, ok bool)
+
+	// Peek returns the value for the key without affecting the eviction policy or the stats.
+	Peek(key K) (value V, ok bool)
+
+	// Put sets the value for the key, evicting entries if the cache is over capacity.
+	Put(key K, value V)
+
+	// Remove removes the key from the cache. This is not an eviction, so it does not
+	// trigger the eviction callback.
+	Remove(key K) bool
+
+	ContainsKey(key K) bool
+	Capacity() int
+
+	// Resize changes the capacity of the cache, evicting entries if needed,
+	// and returns the number of entries evicted.
+	Resize(capacity int) int
+
+	Stats() Stats
+}
@@ -0,0 +1,143 @@
+package cache_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/cache"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/arc"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/concurrentcache"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/lfu"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/loadingcache"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/lru"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/tinylfu"
+	"github.com/stretchr/testify/assert"
+)
+
+func getCachesForTest[K comparable, V any](capacity int) []cache.Cache[K, V] {
+	return []cache.Cache[K, V]{
+		lru.New[K, V](capacity),
+		lfu.New[K, V](capacity),
+		arc.New[K, V](capacity),
+		tinylfu.New[K, V](capacity),
+		concurrentcache.MakeThreadSafe[K, V](lru.New[K, V](capacity)),
+		loadingcache.New[K, V](capacity),
+	}
+}
+
+func TestStatsHitRatio(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0.0, cache.Stats{}.HitRatio())
+	assert.Equal(t, 0.75, cache.Stats{Hits: 3, Misses: 1}.HitRatio())
+	assert.Equal(t, 0.0, cache.Stats{Misses: 5, Evictions: 2}.HitRatio())
+}
+
+func TestPutGetRemove(t *testing.T) {
+	t.Parallel()
+
+	caches := getCachesForTest[string, int](10)
+	for i := range caches {
+		c := caches[i]
+		t.Run(fmt.Sprintf("%T", c), func(t *testing.T) {
+			t.Parallel()
+
+			assert.True(t, c.Empty())
+			assert.Equal(t, 10, c.Capacity())
+
+			c.Put("a", 1)
+			c.Put("b", 2)
+			c.Put("a", 100)
+			assert.Equal(t, 2, c.Size())
+			assert.True(t, c.ContainsKey("a"))
+
+			value, ok := c.Get("a")
+			assert.True(t, ok)
+			assert.Equal(t, 100, value)
+
+			value, ok = c.Peek("b")
+			assert.True(t, ok)
+			assert.Equal(t, 2, value)
+
+			_, ok = c.Get("missing")
+			assert.False(t, ok)
+
+			assert.True(t, c.Remove("a"))
+			assert.False(t, c.Remove("a"))
+			assert.False(t, c.ContainsKey("a"))
+
+			// Peek does not count towards the stats.
+			assert.Equal(t, cache.Stats{Hits: 1, Misses: 1, Evictions: 0}, c.Stats())
+
+			c.Clear()
+			assert.True(t, c.Empty())
+		})
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	t.Parallel()
+
+	caches := getCachesForTest[int, int](3)
+	for i := range caches {
+		c := caches[i]
+		t.Run(fmt.Sprintf("%T", c), func(t *testing.T) {
+			t.Parallel()
+
+			for i := 0; i < 10; i++ {
+				c.Put(i, i*i)
+				assert.LessOrEqual(t, c.Size(), 3)
+			}
+
+			assert.Equal(t, 3, c.Size())
+			assert.Equal(t, uint64(7), c.Stats().Evictions)
+
+			assert.Equal(t, 2, c.Resize(1))
+			assert.Equal(t, 1, c.Size())
+			assert.Equal(t, 1, c.Capacity())
+
+			assert.Equal(t, 0, c.Resize(5))
+			assert.Equal(t, 1, c.Size())
+		})
+	}
+}
+
+func TestZeroCapacity(t *testing.T) {
+	t.Parallel()
+
+	caches := getCachesForTest[int, int](0)
+	for i := range caches {
+		c := caches[i]
+		t.Run(fmt.Sprintf("%T", c), func(t *testing.T) {
+			t.Parallel()
+
+			c.Put(1, 1)
+			assert.True(t, c.Empty())
+			assert.False(t, c.ContainsKey(1))
+
+			_, ok := c.Get(1)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestEvictionCallbackMatchesStats(t *testing.T) {
+	t.Parallel()
+
+	caches := getCachesForTest[int, int](8)
+	for i := range caches {
+		c := caches[i]
+		t.Run(fmt.Sprintf("%T", c), func(t *testing.T) {
+			t.Parallel()
+
+			for i := 0; i < 200; i++ {
+				c.Put(i%37, i)
+				c.Get(i % 11)
+				assert.LessOrEqual(t, c.Size(), 8)
+			}
+
+			assert.Equal(t, 8, c.Size())
+			assert.Positive(t, c.Stats().Evictions)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package concurrentcache
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/kaschnit/go-ds/pkg/containers/cache"
+)
+
+func MakeThreadSafe[K any, V any](c cache.Cache[K, V]) *ConcurrentCache[K, V] {
+	if cc, ok := c.(*ConcurrentCache[K, V]); ok {
+		return cc
+	}
+
+	return &ConcurrentCache[K, V]{
+		inner:  c,
+		rwlock: sync.RWMutex{},
+	}
+}
+
+// ConcurrentCache makes a cache safe for concurrent use.
+// Get takes the write lock because it updates the eviction policy and the stats.
+// The eviction callback is called while the lock is held, so it must not use the cache.
+type ConcurrentCache[K any, V any] struct {
+	inner  cache.Cache[K, V]
+	rwlock sync.RWMutex
+}
+
+func (c *ConcurrentCache[K, V]) Empty() bool {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+
+	return c.inner.Empty()
+}
+
+func (c *ConcurrentCache[K, V]) Size() int {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+
+	return c.inner.Size()
+}
+
+func (c *ConcurrentCache[K, V]) Clear() {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+
+	c.inner.Clear()
+}
+
+func (c *ConcurrentCache[K, V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("[Concurrent]")
+
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+	sb.WriteString(c.inner.String())
+
+	return sb.String()
+}
+
+func (c *ConcurrentCache[K, V]) Get(key K) (V, bool) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+
+	return c.inner.Get(key)
+}
+
+func (c *ConcurrentCache[K, V]) Peek(key K) (V, bool) {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+
+	return c.inner.Peek(key)
+}
+
+func (c *ConcurrentCache[K, V]) Put(key K, value V) {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+
+	c.inner.Put(key, value)
+}
+
+func (c *ConcurrentCache[K, V]) Remove(key K) bool {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+
+	return c.inner.Remove(key)
+}
+
+func (c *ConcurrentCache[K, V]) ContainsKey(key K) bool {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+
+	return c.inner.ContainsKey(key)
+}
+
+func (c *ConcurrentCache[K, V]) Capacity() int {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+
+	return c.inner.Capacity()
+}
+
+func (c *ConcurrentCache[K, V]) Resize(capacity int) int {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+
+	return c.inner.Resize(capacity)
+}
+
+func (c *ConcurrentCache[K, V]) Stats() cache.Stats {
+	c.rwlock.RLock()
+	defer c.rwlock.RUnlock()
+
+	return c.inner.Stats()
+}
@@ -0,0 +1,65 @@
+package concurrentcache_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/cache"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/concurrentcache"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/lru"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ cache.Cache[int, int] = &concurrentcache.ConcurrentCache[int, int]{}
+
+func TestConcurrentCacheString(t *testing.T) {
+	t.Parallel()
+
+	c := concurrentcache.MakeThreadSafe[int, string](lru.New[int, string](5))
+	c.Put(1, "foo")
+	assert.Equal(t, "[Concurrent]LRU[capacity=5]\nEntry{Key:1, Value:foo}", c.String())
+}
+
+func TestConcurrentCacheConcurrentPutAndGet(t *testing.T) {
+	t.Parallel()
+
+	c := concurrentcache.MakeThreadSafe[string, int](lru.New[string, int](100))
+	waitGroup := sync.WaitGroup{}
+
+	size := 1000
+
+	for i := 0; i < size; i++ {
+		value := i
+
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			key := fmt.Sprintf("%d", value)
+			c.Put(key, value)
+
+			if actualValue, ok := c.Get(key); ok {
+				assert.Equal(t, value, actualValue)
+			}
+		}()
+	}
+
+	waitGroup.Wait()
+
+	stats := c.Stats()
+	assert.Equal(t, 100, c.Size())
+	assert.Equal(t, uint64(size-100), stats.Evictions)
+	assert.Equal(t, uint64(size), stats.Hits+stats.Misses)
+}
+
+func TestMakeThreadSafe_AlreadyThreadSafe(t *testing.T) {
+	t.Parallel()
+
+	c := lru.New[int, string](10)
+	cache1 := concurrentcache.MakeThreadSafe[int, string](c)
+	cache2 := concurrentcache.MakeThreadSafe[int, string](cache1)
+
+	assert.NotEqual(t, c, cache1)
+	assert.Equal(t, cache1, cache2)
+}
@@ -0,0 +1,274 @@
+package lfu
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/cache"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/map/linkedhashmap"
+)
+
+// frequencyBucket holds all entries that have been used the same number of times,
+// ordered from least recently to most recently used.
+type frequencyBucket[K any, HK comparable, V any] struct {
+	frequency uint64
+	entries   *linkedhashmap.LinkedHashMap[K, HK, V]
+	prev      *frequencyBucket[K, HK, V]
+	next      *frequencyBucket[K, HK, V]
+}
+
+type Builder[K any, HK comparable, V any] struct {
+	capacity int
+	hashkey  compare.HashKey[K, HK]
+	onEvict  cache.EvictionCallback[K, V]
+}
+
+func NewBuilder[K any, HK comparable, V any](capacity int, hashkey compare.HashKey[K, HK]) *Builder[K, HK, V] {
+	return &Builder[K, HK, V]{
+		capacity: capacity,
+		hashkey:  hashkey,
+	}
+}
+
+func (b *Builder[K, HK, V]) OnEvict(onEvict cache.EvictionCallback[K, V]) *Builder[K, HK, V] {
+	b.onEvict = onEvict
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) Build() *LFU[K, HK, V] {
+	return &LFU[K, HK, V]{
+		capacity: b.capacity,
+		hashkey:  b.hashkey,
+		buckets:  make(map[HK]*frequencyBucket[K, HK, V]),
+		lowest:   nil,
+		onEvict:  b.onEvict,
+		stats:    cache.Stats{},
+	}
+}
+
+// LFU is a cache that evicts the least frequently used entry when it is full,
+// breaking ties by evicting the least recently used of those entries.
+// Every operation is O(1): entries are grouped into buckets by use count, and the buckets
+// are kept in a list ordered by count, so the entry to evict is always in the first bucket.
+// A capacity of zero or less means nothing is cached.
+type LFU[K any, HK comparable, V any] struct {
+	capacity int
+	hashkey  compare.HashKey[K, HK]
+
+	// buckets maps each key to the bucket holding its entry.
+	buckets map[HK]*frequencyBucket[K, HK, V]
+
+	// lowest is the bucket with the lowest use count, the head of the bucket list.
+	lowest  *frequencyBucket[K, HK, V]
+	onEvict cache.EvictionCallback[K, V]
+	stats   cache.Stats
+}
+
+func New[K comparable, V any](capacity int) *LFU[K, K, V] {
+	return NewBuilder[K, K, V](capacity, compare.IdentityHashKey[K]).Build()
+}
+
+func (c *LFU[K, HK, V]) Empty() bool {
+	return c.Size() == 0
+}
+
+func (c *LFU[K, HK, V]) Size() int {
+	return len(c.buckets)
+}
+
+func (c *LFU[K, HK, V]) Clear() {
+	for k := range c.buckets {
+		delete(c.buckets, k)
+	}
+
+	c.lowest = nil
+}
+
+func (c *LFU[K, HK, V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("LFU[capacity=%d]\n", c.capacity))
+
+	// Entries are listed in eviction order.
+	strs := make([]string, 0, c.Size())
+	for bucket := c.lowest; bucket != nil; bucket = bucket.next {
+		bucket.entries.ForEach(func(key K, value V) {
+			strs = append(strs, entry.NewRef(key, value).String())
+		})
+	}
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (c *LFU[K, HK, V]) Get(key K) (V, bool) {
+	hashedKey := c.hashkey(key)
+
+	bucket, ok := c.buckets[hashedKey]
+	if !ok {
+		c.stats.Misses++
+
+		return *new(V), false
+	}
+
+	c.stats.Hits++
+	value, _ := bucket.entries.Get(key)
+	c.increment(hashedKey, bucket, key, value)
+
+	return value, true
+}
+
+func (c *LFU[K, HK, V]) Peek(key K) (V, bool) {
+	bucket, ok := c.buckets[c.hashkey(key)]
+	if !ok {
+		return *new(V), false
+	}
+
+	return bucket.entries.Get(key)
+}
+
+func (c *LFU[K, HK, V]) Put(key K, value V) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	hashedKey := c.hashkey(key)
+
+	if bucket, ok := c.buckets[hashedKey]; ok {
+		c.increment(hashedKey, bucket, key, value)
+
+		return
+	}
+
+	if c.Size() >= c.capacity {
+		c.evict()
+	}
+
+	// New entries start with a use count of one.
+	if c.lowest == nil || c.lowest.frequency != 1 {
+		c.lowest = c.newBucket(1, nil, c.lowest)
+	}
+
+	c.lowest.entries.Put(key, value)
+	c.buckets[hashedKey] = c.lowest
+}
+
+func (c *LFU[K, HK, V]) Remove(key K) bool {
+	hashedKey := c.hashkey(key)
+
+	bucket, ok := c.buckets[hashedKey]
+	if !ok {
+		return false
+	}
+
+	delete(c.buckets, hashedKey)
+	bucket.entries.RemoveKey(key)
+	c.removeIfEmpty(bucket)
+
+	return true
+}
+
+func (c *LFU[K, HK, V]) ContainsKey(key K) bool {
+	_, ok := c.buckets[c.hashkey(key)]
+
+	return ok
+}
+
+func (c *LFU[K, HK, V]) Capacity() int {
+	return c.capacity
+}
+
+func (c *LFU[K, HK, V]) Resize(capacity int) int {
+	c.capacity = capacity
+	evicted := 0
+
+	for c.Size() > c.capacity && c.Size() > 0 {
+		c.evict()
+		evicted++
+	}
+
+	return evicted
+}
+
+func (c *LFU[K, HK, V]) Stats() cache.Stats {
+	return c.stats
+}
+
+// Frequency returns the number of times the key has been used since it was added to the cache.
+func (c *LFU[K, HK, V]) Frequency(key K) uint64 {
+	bucket, ok := c.buckets[c.hashkey(key)]
+	if !ok {
+		return 0
+	}
+
+	return bucket.frequency
+}
+
+// increment moves an entry from its bucket to the bucket for the next use count.
+func (c *LFU[K, HK, V]) increment(hashedKey HK, bucket *frequencyBucket[K, HK, V], key K, value V) {
+	next := bucket.next
+	if next == nil || next.frequency != bucket.frequency+1 {
+		next = c.newBucket(bucket.frequency+1, bucket, bucket.next)
+	}
+
+	bucket.entries.RemoveKey(key)
+	next.entries.Put(key, value)
+	c.buckets[hashedKey] = next
+	c.removeIfEmpty(bucket)
+}
+
+func (c *LFU[K, HK, V]) evict() {
+	key, value, ok := c.lowest.entries.Front()
+	if !ok {
+		return
+	}
+
+	c.lowest.entries.RemoveKey(key)
+	delete(c.buckets, c.hashkey(key))
+	c.removeIfEmpty(c.lowest)
+	c.stats.Evictions++
+
+	if c.onEvict != nil {
+		c.onEvict(key, value)
+	}
+}
+
+func (c *LFU[K, HK, V]) newBucket(
+	frequency uint64, prev *frequencyBucket[K, HK, V], next *frequencyBucket[K, HK, V],
+) *frequencyBucket[K, HK, V] {
+	bucket := &frequencyBucket[K, HK, V]{
+		frequency: frequency,
+		entries:   linkedhashmap.NewBuilder[K, HK, V](c.hashkey).Build(),
+		prev:      prev,
+		next:      next,
+	}
+
+	if prev != nil {
+		prev.next = bucket
+	}
+
+	if next != nil {
+		next.prev = bucket
+	}
+
+	return bucket
+}
+
+func (c *LFU[K, HK, V]) removeIfEmpty(bucket *frequencyBucket[K, HK, V]) {
+	if !bucket.entries.Empty() {
+		return
+	}
+
+	if bucket.prev == nil {
+		c.lowest = bucket.next
+	} else {
+		bucket.prev.next = bucket.next
+	}
+
+	if bucket.next != nil {
+		bucket.next.prev = bucket.prev
+	}
+}
@@ -0,0 +1,87 @@
+package lfu_test
+
+import (
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/cache"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/lfu"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that LFU implements Cache.
+var _ cache.Cache[string, int] = &lfu.LFU[string, string, int]{}
+
+func TestLFUString(t *testing.T) {
+	t.Parallel()
+
+	c := lfu.New[int, string](3)
+	assert.Equal(t, "LFU[capacity=3]\n", c.String())
+
+	c.Put(1, "a")
+	c.Put(2, "b")
+	c.Put(3, "c")
+	c.Get(1)
+	c.Get(1)
+	c.Get(2)
+
+	// Listed from the next entry to be evicted to the last.
+	assert.Equal(t, "LFU[capacity=3]\nEntry{Key:3, Value:c},Entry{Key:2, Value:b},Entry{Key:1, Value:a}", c.String())
+}
+
+func TestLFUEviction(t *testing.T) {
+	t.Parallel()
+
+	evicted := []string{}
+	c := lfu.NewBuilder[string, string, int](3, compare.IdentityHashKey[string]).
+		OnEvict(func(key string, value int) {
+			evicted = append(evicted, key)
+		}).
+		Build()
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+	c.Get("c")
+	assert.Equal(t, uint64(3), c.Frequency("a"))
+	assert.Equal(t, uint64(2), c.Frequency("b"))
+	assert.Equal(t, uint64(2), c.Frequency("c"))
+	assert.Equal(t, uint64(0), c.Frequency("missing"))
+
+	// "b" and "c" are tied on frequency, "b" was used less recently.
+	c.Put("d", 4)
+	assert.Equal(t, []string{"b"}, evicted)
+
+	// "d" is the least frequently used.
+	c.Put("e", 5)
+	assert.Equal(t, []string{"b", "d"}, evicted)
+
+	// Peek does not count as a use, so "e" is still evicted next.
+	_, ok := c.Peek("e")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), c.Frequency("e"))
+
+	c.Put("f", 6)
+	assert.Equal(t, []string{"b", "d", "e"}, evicted)
+
+	// Updating counts as a use.
+	c.Put("f", 60)
+	assert.Equal(t, uint64(2), c.Frequency("f"))
+
+	value, ok := c.Get("f")
+	assert.True(t, ok)
+	assert.Equal(t, 60, value)
+
+	assert.True(t, c.Remove("c"))
+	assert.Equal(t, 2, c.Size())
+	assert.Equal(t, []string{"b", "d", "e"}, evicted)
+
+	// "a" and "f" are tied on frequency, "a" was used less recently.
+	assert.Equal(t, 1, c.Resize(1))
+	assert.Equal(t, []string{"b", "d", "e", "a"}, evicted)
+	assert.True(t, c.ContainsKey("f"))
+}
@@ -0,0 +1,461 @@
+package loadingcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaschnit/go-ds/pkg/clock"
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/cache"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/policy"
+)
+
+// Loader loads the value for a key that is missing from the cache.
+type Loader[K any, V any] func(ctx context.Context, key K) (V, error)
+
+// PanicError is the error that GetOrLoad returns when the loader panics, since the load runs
+// in its own goroutine where the panic cannot reach the callers. It is never cached.
+type PanicError struct {
+	// Value is the value that the loader panicked with.
+	Value any
+
+	// Stack is the stack trace of the loader's goroutine when it panicked.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("loadingcache: loader panicked: %v\n\n%s", e.Value, e.Stack)
+}
+
+// record is a loaded value or the error from a failed load, along with when it was loaded.
+type record[V any] struct {
+	value    V
+	err      error
+	loadedAt time.Time
+
+	// expiresAt is when the record expires, the zero time means the record never expires.
+	expiresAt time.Time
+
+	// refreshedAt is when a refresh of the record was last started, the zero time means it
+	// has never been refreshed. A failed refresh leaves the record in place, so this keeps the
+	// record from being refreshed again until another refresh period has passed.
+	refreshedAt time.Time
+}
+
+func (r *record[V]) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("error(%v)", r.err)
+	}
+
+	return fmt.Sprintf("%v", r.value)
+}
+
+// call is a load that is in progress, which callers for the same key wait on.
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+
+	// cancel cancels the context of the load, which happens once no caller is waiting for it.
+	// A refresh has no callers waiting for it, and is never cancelled.
+	cancel  context.CancelFunc
+	waiters int
+	refresh bool
+
+	// abandoned is whether the load was cancelled, in which case its result is not stored.
+	abandoned bool
+}
+
+type Builder[K any, HK comparable, V any] struct {
+	capacity     int
+	hashkey      compare.HashKey[K, HK]
+	policy       policy.Policy
+	ttl          time.Duration
+	negativeTTL  time.Duration
+	refreshAfter time.Duration
+	clock        clock.Clock
+}
+
+func NewBuilder[K any, HK comparable, V any](capacity int, hashkey compare.HashKey[K, HK]) *Builder[K, HK, V] {
+	return &Builder[K, HK, V]{
+		capacity: capacity,
+		hashkey:  hashkey,
+		policy:   policy.LRU,
+		clock:    clock.System(),
+	}
+}
+
+// Policy sets the eviction policy of the cache. Defaults to LRU.
+func (b *Builder[K, HK, V]) Policy(p policy.Policy) *Builder[K, HK, V] {
+	b.policy = p
+
+	return b
+}
+
+// TTL sets how long a loaded value is cached. Zero, the default, means values are cached
+// until they are evicted.
+func (b *Builder[K, HK, V]) TTL(ttl time.Duration) *Builder[K, HK, V] {
+	b.ttl = ttl
+
+	return b
+}
+
+// NegativeTTL sets how long the error from a failed load is cached, so that a key that
+// fails to load is not loaded again by every caller. Zero, the default, means errors
+// are not cached.
+func (b *Builder[K, HK, V]) NegativeTTL(ttl time.Duration) *Builder[K, HK, V] {
+	b.negativeTTL = ttl
+
+	return b
+}
+
+// RefreshAfter makes the cache reload a value in the background once it is older than
+// the duration, while continuing to serve the old value. This keeps frequently used values
+// fresh without callers having to wait for a load. A refresh that fails keeps the old value,
+// which is not refreshed again until the duration has passed once more.
+// Zero, the default, disables refreshing.
+func (b *Builder[K, HK, V]) RefreshAfter(refreshAfter time.Duration) *Builder[K, HK, V] {
+	b.refreshAfter = refreshAfter
+
+	return b
+}
+
+// Clock sets the clock used for the TTLs and refreshing. Defaults to the system clock.
+func (b *Builder[K, HK, V]) Clock(c clock.Clock) *Builder[K, HK, V] {
+	b.clock = c
+
+	return b
+}
+
+// Build creates the cache. Returns false if the eviction policy is not supported.
+func (b *Builder[K, HK, V]) Build() (*LoadingCache[K, HK, V], bool) {
+	records, ok := policy.NewBuilder[K, HK, *record[V]](b.policy, b.capacity, b.hashkey).Build()
+	if !ok {
+		return nil, false
+	}
+
+	return &LoadingCache[K, HK, V]{
+		hashkey:      b.hashkey,
+		ttl:          b.ttl,
+		negativeTTL:  b.negativeTTL,
+		refreshAfter: b.refreshAfter,
+		clock:        b.clock,
+		records:      records,
+		calls:        make(map[HK]*call[V]),
+		mutex:        sync.Mutex{},
+	}, true
+}
+
+// LoadingCache is a cache that loads missing values on demand. Concurrent callers that miss
+// on the same key share a single load rather than each calling the loader, so a cold cache
+// does not send a burst of identical requests to the backend.
+// It is safe for concurrent use.
+type LoadingCache[K any, HK comparable, V any] struct {
+	hashkey      compare.HashKey[K, HK]
+	ttl          time.Duration
+	negativeTTL  time.Duration
+	refreshAfter time.Duration
+	clock        clock.Clock
+	records      cache.Cache[K, *record[V]]
+
+	// calls holds the loads in progress by key.
+	calls map[HK]*call[V]
+	mutex sync.Mutex
+}
+
+// New creates a loading cache with an LRU eviction policy.
+func New[K comparable, V any](capacity int) *LoadingCache[K, K, V] {
+	c, _ := NewBuilder[K, K, V](capacity, compare.IdentityHashKey[K]).Build()
+
+	return c
+}
+
+func (c *LoadingCache[K, HK, V]) Empty() bool {
+	return c.Size() == 0
+}
+
+func (c *LoadingCache[K, HK, V]) Size() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.records.Size()
+}
+
+func (c *LoadingCache[K, HK, V]) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.records.Clear()
+}
+
+func (c *LoadingCache[K, HK, V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("[Loading]")
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	sb.WriteString(c.records.String())
+
+	return sb.String()
+}
+
+// GetOrLoad returns the cached value for the key, loading it with the loader if it is missing
+// or expired. If a load for the key is already in progress, GetOrLoad waits for that load
+// instead of starting another. The load runs with a context that has the values of the context
+// of the caller that started it, but is only cancelled once every caller waiting for it has
+// stopped waiting because its own context is done.
+// If the loader panics, the callers get a *PanicError.
+func (c *LoadingCache[K, HK, V]) GetOrLoad(ctx context.Context, key K, loader Loader[K, V]) (V, error) {
+	hashedKey := c.hashkey(key)
+
+	c.mutex.Lock()
+
+	if rec, ok := c.getRecord(key); ok {
+		if rec.err == nil && c.shouldRefresh(rec) {
+			if _, loading := c.calls[hashedKey]; !loading {
+				rec.refreshedAt = c.clock.Now()
+				c.startCall(context.Background(), hashedKey, key, loader, true)
+			}
+		}
+
+		c.mutex.Unlock()
+
+		return rec.value, rec.err
+	}
+
+	pending, loading := c.calls[hashedKey]
+	if !loading {
+		pending = c.startCall(ctx, hashedKey, key, loader, false)
+	}
+
+	pending.waiters++
+	c.mutex.Unlock()
+
+	select {
+	case <-pending.done:
+		return pending.value, pending.err
+	case <-ctx.Done():
+		c.stopWaiting(hashedKey, pending)
+
+		return *new(V), ctx.Err()
+	}
+}
+
+// Get returns the cached value for the key without loading it.
+func (c *LoadingCache[K, HK, V]) Get(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	rec, ok := c.getRecord(key)
+	if !ok || rec.err != nil {
+		return *new(V), false
+	}
+
+	return rec.value, true
+}
+
+func (c *LoadingCache[K, HK, V]) Peek(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	rec, ok := c.records.Peek(key)
+	if !ok || rec.err != nil || c.expired(rec) {
+		return *new(V), false
+	}
+
+	return rec.value, true
+}
+
+// Put caches the value for the key as if it had just been loaded.
+func (c *LoadingCache[K, HK, V]) Put(key K, value V) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.store(key, value, nil)
+}
+
+// Remove removes the key from the cache, so the next GetOrLoad loads it again.
+// A load that is already in progress still stores its result.
+func (c *LoadingCache[K, HK, V]) Remove(key K) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.records.Remove(key)
+}
+
+func (c *LoadingCache[K, HK, V]) ContainsKey(key K) bool {
+	_, ok := c.Peek(key)
+
+	return ok
+}
+
+func (c *LoadingCache[K, HK, V]) Capacity() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.records.Capacity()
+}
+
+func (c *LoadingCache[K, HK, V]) Resize(capacity int) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.records.Resize(capacity)
+}
+
+func (c *LoadingCache[K, HK, V]) Stats() cache.Stats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.records.Stats()
+}
+
+// startCall starts loading the key in a new goroutine. Must be called with the mutex held.
+func (c *LoadingCache[K, HK, V]) startCall(
+	ctx context.Context, hashedKey HK, key K, loader Loader[K, V], refresh bool,
+) *call[V] {
+	loadCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	pending := &call[V]{
+		done:      make(chan struct{}),
+		value:     *new(V),
+		err:       nil,
+		cancel:    cancel,
+		waiters:   0,
+		refresh:   refresh,
+		abandoned: false,
+	}
+	c.calls[hashedKey] = pending
+
+	go func() {
+		defer cancel()
+
+		value, err := callLoader(loadCtx, key, loader)
+
+		c.mutex.Lock()
+		if c.calls[hashedKey] == pending {
+			delete(c.calls, hashedKey)
+		}
+
+		// A failed refresh keeps serving the old value rather than replacing it with the error.
+		// An error from a context is about the callers rather than the key, so it is not cached,
+		// and neither is a panic, which is a bug in the loader rather than a failure to load.
+		if !pending.abandoned && (!refresh || err == nil) && !isContextError(err) && !isPanicError(err) {
+			c.store(key, value, err)
+		}
+		c.mutex.Unlock()
+
+		pending.value = value
+		pending.err = err
+		close(pending.done)
+	}()
+
+	return pending
+}
+
+// callLoader calls the loader, returning a PanicError if it panics.
+func callLoader[K any, V any](ctx context.Context, key K, loader Loader[K, V]) (V, error) {
+	var (
+		value V
+		err   error
+	)
+
+	func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = &PanicError{Value: recovered, Stack: debug.Stack()}
+			}
+		}()
+
+		value, err = loader(ctx, key)
+	}()
+
+	return value, err
+}
+
+// stopWaiting is called when a caller stops waiting for a load because its context is done.
+// The load is cancelled if no other caller is waiting for it, and a later caller starts a new
+// load instead of waiting for the cancelled one.
+func (c *LoadingCache[K, HK, V]) stopWaiting(hashedKey HK, pending *call[V]) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	pending.waiters--
+	if pending.waiters > 0 || pending.refresh || c.calls[hashedKey] != pending {
+		return
+	}
+
+	delete(c.calls, hashedKey)
+	pending.abandoned = true
+	pending.cancel()
+}
+
+// getRecord returns the record for the key if it has not expired. Must be called with
+// the mutex held.
+func (c *LoadingCache[K, HK, V]) getRecord(key K) (*record[V], bool) {
+	rec, ok := c.records.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	if c.expired(rec) {
+		c.records.Remove(key)
+
+		return nil, false
+	}
+
+	return rec, true
+}
+
+// store caches the result of a load. Must be called with the mutex held.
+func (c *LoadingCache[K, HK, V]) store(key K, value V, err error) {
+	ttl := c.ttl
+	if err != nil {
+		if c.negativeTTL <= 0 {
+			return
+		}
+
+		ttl = c.negativeTTL
+	}
+
+	now := c.clock.Now()
+	rec := &record[V]{
+		value:       value,
+		err:         err,
+		loadedAt:    now,
+		expiresAt:   time.Time{},
+		refreshedAt: time.Time{},
+	}
+
+	if ttl > 0 {
+		rec.expiresAt = now.Add(ttl)
+	}
+
+	c.records.Put(key, rec)
+}
+
+func (c *LoadingCache[K, HK, V]) expired(rec *record[V]) bool {
+	return !rec.expiresAt.IsZero() && !c.clock.Now().Before(rec.expiresAt)
+}
+
+func (c *LoadingCache[K, HK, V]) shouldRefresh(rec *record[V]) bool {
+	now := c.clock.Now()
+
+	return c.refreshAfter > 0 && now.Sub(rec.loadedAt) >= c.refreshAfter &&
+		(rec.refreshedAt.IsZero() || now.Sub(rec.refreshedAt) >= c.refreshAfter)
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func isPanicError(err error) bool {
+	var panicErr *PanicError
+
+	return errors.As(err, &panicErr)
+}
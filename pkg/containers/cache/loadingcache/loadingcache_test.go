@@ -0,0 +1,488 @@
+package loadingcache_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kaschnit/go-ds/pkg/clock"
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/cache"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/loadingcache"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/policy"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that LoadingCache implements Cache.
+var _ cache.Cache[string, int] = &loadingcache.LoadingCache[string, string, int]{}
+
+var errLoad = errors.New("load failed")
+
+func buildForTest(t *testing.T, c clock.Clock) *loadingcache.LoadingCache[string, string, int] {
+	t.Helper()
+
+	result, ok := loadingcache.NewBuilder[string, string, int](10, compare.IdentityHashKey[string]).
+		TTL(time.Minute).
+		NegativeTTL(time.Second).
+		RefreshAfter(30 * time.Second).
+		Clock(c).
+		Build()
+	assert.True(t, ok)
+
+	return result
+}
+
+func TestLoadingCacheString(t *testing.T) {
+	t.Parallel()
+
+	c := loadingcache.New[string, int](2)
+	assert.Equal(t, "[Loading]LRU[capacity=2]\n", c.String())
+
+	c.Put("a", 1)
+	assert.Equal(t, "[Loading]LRU[capacity=2]\nEntry{Key:a, Value:1}", c.String())
+}
+
+func TestLoadingCacheUnsupportedPolicy(t *testing.T) {
+	t.Parallel()
+
+	_, ok := loadingcache.NewBuilder[string, string, int](10, compare.IdentityHashKey[string]).
+		Policy(policy.Policy("fifo")).
+		Build()
+	assert.False(t, ok)
+
+	c, ok := loadingcache.NewBuilder[string, string, int](10, compare.IdentityHashKey[string]).
+		Policy(policy.ARC).
+		Build()
+	assert.True(t, ok)
+	assert.Equal(t, 10, c.Capacity())
+}
+
+func TestLoadingCacheGetOrLoad(t *testing.T) {
+	t.Parallel()
+
+	c := loadingcache.New[string, int](10)
+	calls := 0
+	loader := func(ctx context.Context, key string) (int, error) {
+		calls++
+
+		return len(key), nil
+	}
+
+	value, err := c.GetOrLoad(context.Background(), "abc", loader)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, value)
+
+	value, err = c.GetOrLoad(context.Background(), "abc", loader)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, value)
+	assert.Equal(t, 1, calls)
+
+	value, ok := c.Get("abc")
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+
+	assert.True(t, c.Remove("abc"))
+	_, err = c.GetOrLoad(context.Background(), "abc", loader)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestLoadingCacheSingleFlight(t *testing.T) {
+	t.Parallel()
+
+	c := loadingcache.New[string, int](10)
+	release := make(chan struct{})
+	var calls atomic.Int32
+	loader := func(ctx context.Context, key string) (int, error) {
+		calls.Add(1)
+		<-release
+
+		return 42, nil
+	}
+
+	const callers = 20
+	wg := sync.WaitGroup{}
+	results := make([]int, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			value, err := c.GetOrLoad(context.Background(), "key", loader)
+			assert.NoError(t, err)
+			results[i] = value
+		}(i)
+	}
+
+	// Give the callers a chance to pile up on the load before it finishes.
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for _, value := range results {
+		assert.Equal(t, 42, value)
+	}
+}
+
+func TestLoadingCacheWaiterContext(t *testing.T) {
+	t.Parallel()
+
+	c := loadingcache.New[string, int](10)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	loader := func(ctx context.Context, key string) (int, error) {
+		close(started)
+		<-release
+
+		return 7, nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		value, err := c.GetOrLoad(context.Background(), "key", loader)
+		assert.NoError(t, err)
+		assert.Equal(t, 7, value)
+	}()
+	<-started
+
+	// A waiter that gives up does not affect the load in progress.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.GetOrLoad(ctx, "key", loader)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+	<-done
+
+	value, ok := c.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, 7, value)
+}
+
+type contextKey struct{}
+
+func TestLoadingCacheFirstCallerCancels(t *testing.T) {
+	t.Parallel()
+
+	c := buildForTest(t, clock.System())
+	started := make(chan struct{})
+	release := make(chan struct{})
+	loader := func(ctx context.Context, key string) (int, error) {
+		close(started)
+		<-release
+
+		// The load keeps the values of the first caller's context, but not its cancellation.
+		assert.Equal(t, "first", ctx.Value(contextKey{}))
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		return 7, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), contextKey{}, "first"))
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+
+		_, err := c.GetOrLoad(ctx, "key", loader)
+		assert.ErrorIs(t, err, context.Canceled)
+	}()
+	<-started
+
+	secondDone := make(chan struct{})
+	go func() {
+		defer close(secondDone)
+
+		value, err := c.GetOrLoad(context.Background(), "key", loader)
+		assert.NoError(t, err)
+		assert.Equal(t, 7, value)
+	}()
+
+	// Cancel the first caller once the second is waiting on the same load.
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	<-firstDone
+
+	close(release)
+	<-secondDone
+
+	value, ok := c.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, 7, value)
+}
+
+func TestLoadingCacheAllCallersCancel(t *testing.T) {
+	t.Parallel()
+
+	c := buildForTest(t, clock.System())
+	var calls atomic.Int32
+	cancelled := make(chan struct{})
+	loader := func(ctx context.Context, key string) (int, error) {
+		if calls.Add(1) > 1 {
+			return 9, nil
+		}
+
+		// The load is cancelled once nobody is waiting for it.
+		<-ctx.Done()
+		close(cancelled)
+
+		return 0, ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := c.GetOrLoad(ctx, "key", loader)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	<-cancelled
+
+	// The next caller starts a new load rather than getting the error of the cancelled one.
+	value, err := c.GetOrLoad(context.Background(), "key", loader)
+	assert.NoError(t, err)
+	assert.Equal(t, 9, value)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestLoadingCacheContextErrorsNotCached(t *testing.T) {
+	t.Parallel()
+
+	c := buildForTest(t, clock.NewManual(time.Unix(0, 0)))
+	calls := 0
+	loader := func(ctx context.Context, key string) (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, fmt.Errorf("backend: %w", context.DeadlineExceeded)
+		}
+
+		return 3, nil
+	}
+
+	_, err := c.GetOrLoad(context.Background(), "key", loader)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Errors are cached for the negative TTL, but not errors from a context.
+	value, err := c.GetOrLoad(context.Background(), "key", loader)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, value)
+	assert.Equal(t, 2, calls)
+}
+
+func TestLoadingCacheTTL(t *testing.T) {
+	t.Parallel()
+
+	manual := clock.NewManual(time.Unix(0, 0))
+	c, ok := loadingcache.NewBuilder[string, string, int](10, compare.IdentityHashKey[string]).
+		TTL(time.Minute).
+		Clock(manual).
+		Build()
+	assert.True(t, ok)
+
+	calls := 0
+	loader := func(ctx context.Context, key string) (int, error) {
+		calls++
+
+		return calls, nil
+	}
+
+	value, _ := c.GetOrLoad(context.Background(), "key", loader)
+	assert.Equal(t, 1, value)
+
+	manual.Advance(59 * time.Second)
+	value, _ = c.GetOrLoad(context.Background(), "key", loader)
+	assert.Equal(t, 1, value)
+	assert.True(t, c.ContainsKey("key"))
+
+	manual.Advance(time.Second)
+	assert.False(t, c.ContainsKey("key"))
+	_, ok = c.Get("key")
+	assert.False(t, ok)
+
+	value, _ = c.GetOrLoad(context.Background(), "key", loader)
+	assert.Equal(t, 2, value)
+}
+
+func TestLoadingCacheNegativeCaching(t *testing.T) {
+	t.Parallel()
+
+	manual := clock.NewManual(time.Unix(0, 0))
+	c := buildForTest(t, manual)
+
+	calls := 0
+	failing := func(ctx context.Context, key string) (int, error) {
+		calls++
+
+		return 0, errLoad
+	}
+
+	_, err := c.GetOrLoad(context.Background(), "key", failing)
+	assert.ErrorIs(t, err, errLoad)
+	_, err = c.GetOrLoad(context.Background(), "key", failing)
+	assert.ErrorIs(t, err, errLoad)
+	assert.Equal(t, 1, calls)
+
+	// A cached error is not a value.
+	_, ok := c.Get("key")
+	assert.False(t, ok)
+	assert.False(t, c.ContainsKey("key"))
+
+	manual.Advance(time.Second)
+	value, err := c.GetOrLoad(context.Background(), "key", func(ctx context.Context, key string) (int, error) {
+		return 5, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, value)
+}
+
+func TestLoadingCacheErrorsNotCachedByDefault(t *testing.T) {
+	t.Parallel()
+
+	c := loadingcache.New[string, int](10)
+	calls := 0
+	failing := func(ctx context.Context, key string) (int, error) {
+		calls++
+
+		return 0, errLoad
+	}
+
+	_, err := c.GetOrLoad(context.Background(), "key", failing)
+	assert.ErrorIs(t, err, errLoad)
+	_, err = c.GetOrLoad(context.Background(), "key", failing)
+	assert.ErrorIs(t, err, errLoad)
+	assert.Equal(t, 2, calls)
+	assert.True(t, c.Empty())
+}
+
+func TestLoadingCacheRefreshAhead(t *testing.T) {
+	t.Parallel()
+
+	manual := clock.NewManual(time.Unix(0, 0))
+	c := buildForTest(t, manual)
+
+	refreshed := make(chan struct{})
+	var calls atomic.Int32
+	loader := func(ctx context.Context, key string) (int, error) {
+		if calls.Add(1) == 2 {
+			defer close(refreshed)
+		}
+
+		return int(calls.Load()), nil
+	}
+
+	value, _ := c.GetOrLoad(context.Background(), "key", loader)
+	assert.Equal(t, 1, value)
+
+	// Past the refresh point the old value is still served while it is reloaded.
+	manual.Advance(30 * time.Second)
+	value, _ = c.GetOrLoad(context.Background(), "key", loader)
+	assert.Equal(t, 1, value)
+
+	<-refreshed
+	assert.Eventually(t, func() bool {
+		value, _ := c.Get("key")
+
+		return value == 2
+	}, time.Second, time.Millisecond)
+
+	// The refreshed value is fresh again, so no further reload is started.
+	value, _ = c.GetOrLoad(context.Background(), "key", loader)
+	assert.Equal(t, 2, value)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestLoadingCacheFailedRefreshKeepsValue(t *testing.T) {
+	t.Parallel()
+
+	manual := clock.NewManual(time.Unix(0, 0))
+	c := buildForTest(t, manual)
+	c.Put("key", 1)
+
+	manual.Advance(30 * time.Second)
+	refreshed := make(chan struct{})
+	value, err := c.GetOrLoad(context.Background(), "key", func(ctx context.Context, key string) (int, error) {
+		defer close(refreshed)
+
+		return 0, errLoad
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, value)
+
+	<-refreshed
+	assert.Eventually(t, func() bool {
+		value, ok := c.Get("key")
+
+		return ok && value == 1
+	}, time.Second, time.Millisecond)
+}
+
+func TestLoadingCacheFailedRefreshWaits(t *testing.T) {
+	t.Parallel()
+
+	manual := clock.NewManual(time.Unix(0, 0))
+	c, _ := loadingcache.NewBuilder[string, string, int](10, compare.IdentityHashKey[string]).
+		RefreshAfter(30 * time.Second).
+		Clock(manual).
+		Build()
+	c.Put("key", 1)
+
+	refreshes := make(chan struct{}, 10)
+	loader := func(ctx context.Context, key string) (int, error) {
+		refreshes <- struct{}{}
+
+		return 0, errLoad
+	}
+
+	// A failed refresh is not retried by the callers that follow it.
+	manual.Advance(30 * time.Second)
+	for i := 0; i < 5; i++ {
+		value, err := c.GetOrLoad(context.Background(), "key", loader)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, value)
+
+		if i == 0 {
+			<-refreshes
+		}
+	}
+
+	manual.Advance(29 * time.Second)
+	value, _ := c.GetOrLoad(context.Background(), "key", loader)
+	assert.Equal(t, 1, value)
+	assert.Empty(t, refreshes)
+
+	// It is retried once another refresh period has passed.
+	manual.Advance(time.Second)
+	value, _ = c.GetOrLoad(context.Background(), "key", loader)
+	assert.Equal(t, 1, value)
+	<-refreshes
+	assert.Empty(t, refreshes)
+}
+
+func TestLoadingCacheLoaderPanics(t *testing.T) {
+	t.Parallel()
+
+	c := buildForTest(t, clock.NewManual(time.Unix(0, 0)))
+
+	// The panic reaches the caller as an error instead of crashing the loading goroutine.
+	_, err := c.GetOrLoad(context.Background(), "key", func(ctx context.Context, key string) (int, error) {
+		panic("boom")
+	})
+
+	var panicErr *loadingcache.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.NotEmpty(t, panicErr.Stack)
+
+	// The panic is not cached, so the next caller loads the key again.
+	value, err := c.GetOrLoad(context.Background(), "key", func(ctx context.Context, key string) (int, error) {
+		return 7, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 7, value)
+}
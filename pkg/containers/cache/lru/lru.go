@@ -0,0 +1,193 @@
+package lru
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/cache"
+	"github.com/kaschnit/go-ds/pkg/containers/list/linkedlist"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+)
+
+type Builder[K any, HK comparable, V any] struct {
+	capacity int
+	hashkey  compare.HashKey[K, HK]
+	onEvict  cache.EvictionCallback[K, V]
+}
+
+func NewBuilder[K any, HK comparable, V any](capacity int, hashkey compare.HashKey[K, HK]) *Builder[K, HK, V] {
+	return &Builder[K, HK, V]{
+		capacity: capacity,
+		hashkey:  hashkey,
+	}
+}
+
+func (b *Builder[K, HK, V]) OnEvict(onEvict cache.EvictionCallback[K, V]) *Builder[K, HK, V] {
+	b.onEvict = onEvict
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) Build() *LRU[K, HK, V] {
+	return &LRU[K, HK, V]{
+		capacity: b.capacity,
+		hashkey:  b.hashkey,
+		elements: make(map[HK]*linkedlist.Element[*lruEntry[K, V]]),
+		order:    linkedlist.NewDoubleLinked[*lruEntry[K, V]](),
+		onEvict:  b.onEvict,
+		stats:    cache.Stats{},
+	}
+}
+
+type lruEntry[K any, V any] struct {
+	key   K
+	value V
+}
+
+// LRU is a cache that evicts the least recently used entry when it is full.
+// A capacity of zero or less means nothing is cached.
+type LRU[K any, HK comparable, V any] struct {
+	capacity int
+	hashkey  compare.HashKey[K, HK]
+
+	// The elements of the entries in the order, which is from least recently used
+	// to most recently used.
+	elements map[HK]*linkedlist.Element[*lruEntry[K, V]]
+	order    *linkedlist.DoubleLinkedList[*lruEntry[K, V]]
+
+	onEvict cache.EvictionCallback[K, V]
+	stats   cache.Stats
+}
+
+func New[K comparable, V any](capacity int) *LRU[K, K, V] {
+	return NewBuilder[K, K, V](capacity, compare.IdentityHashKey[K]).Build()
+}
+
+func (c *LRU[K, HK, V]) Empty() bool {
+	return c.Size() == 0
+}
+
+func (c *LRU[K, HK, V]) Size() int {
+	return len(c.elements)
+}
+
+func (c *LRU[K, HK, V]) Clear() {
+	c.elements = make(map[HK]*linkedlist.Element[*lruEntry[K, V]])
+	c.order.Clear()
+}
+
+func (c *LRU[K, HK, V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("LRU[capacity=%d]\n", c.capacity))
+
+	strs := make([]string, 0, c.Size())
+	c.order.ForEach(func(_ int, e *lruEntry[K, V]) {
+		strs = append(strs, entry.NewRef(e.key, e.value).String())
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (c *LRU[K, HK, V]) Get(key K) (V, bool) {
+	element, ok := c.elements[c.hashkey(key)]
+	if !ok {
+		c.stats.Misses++
+
+		return *new(V), false
+	}
+
+	c.stats.Hits++
+	c.order.MoveElementToBack(element)
+
+	return element.Value().value, true
+}
+
+func (c *LRU[K, HK, V]) Peek(key K) (V, bool) {
+	element, ok := c.elements[c.hashkey(key)]
+	if !ok {
+		return *new(V), false
+	}
+
+	return element.Value().value, true
+}
+
+func (c *LRU[K, HK, V]) Put(key K, value V) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	hashedKey := c.hashkey(key)
+
+	if element, ok := c.elements[hashedKey]; ok {
+		e := element.Value()
+		e.key, e.value = key, value
+		c.order.MoveElementToBack(element)
+
+		return
+	}
+
+	c.elements[hashedKey] = c.order.AppendElement(&lruEntry[K, V]{key: key, value: value})
+	c.evictOverCapacity()
+}
+
+func (c *LRU[K, HK, V]) Remove(key K) bool {
+	hashedKey := c.hashkey(key)
+
+	element, ok := c.elements[hashedKey]
+	if ok {
+		delete(c.elements, hashedKey)
+		c.order.RemoveElement(element)
+	}
+
+	return ok
+}
+
+func (c *LRU[K, HK, V]) ContainsKey(key K) bool {
+	_, ok := c.elements[c.hashkey(key)]
+
+	return ok
+}
+
+func (c *LRU[K, HK, V]) Capacity() int {
+	return c.capacity
+}
+
+func (c *LRU[K, HK, V]) Resize(capacity int) int {
+	c.capacity = capacity
+
+	return c.evictOverCapacity()
+}
+
+func (c *LRU[K, HK, V]) Stats() cache.Stats {
+	return c.stats
+}
+
+// Oldest returns the least recently used entry, which is the next to be evicted.
+func (c *LRU[K, HK, V]) Oldest() (K, V, bool) {
+	oldest, ok := c.order.GetFront()
+	if !ok {
+		return *new(K), *new(V), false
+	}
+
+	return oldest.key, oldest.value, true
+}
+
+func (c *LRU[K, HK, V]) evictOverCapacity() int {
+	evicted := 0
+
+	for c.Size() > c.capacity && c.Size() > 0 {
+		oldest, _ := c.order.PopFront()
+		delete(c.elements, c.hashkey(oldest.key))
+		c.stats.Evictions++
+		evicted++
+
+		if c.onEvict != nil {
+			c.onEvict(oldest.key, oldest.value)
+		}
+	}
+
+	return evicted
+}
@@ -0,0 +1,87 @@
+package lru_test
+
+import (
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/cache"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/lru"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that LRU implements Cache.
+var _ cache.Cache[string, int] = &lru.LRU[string, string, int]{}
+
+func TestLRUString(t *testing.T) {
+	t.Parallel()
+
+	c := lru.New[int, string](2)
+	assert.Equal(t, "LRU[capacity=2]\n", c.String())
+
+	c.Put(1, "a")
+	c.Put(2, "b")
+	c.Get(1)
+	assert.Equal(t, "LRU[capacity=2]\nEntry{Key:2, Value:b},Entry{Key:1, Value:a}", c.String())
+}
+
+func TestLRUEviction(t *testing.T) {
+	t.Parallel()
+
+	evicted := []string{}
+	c := lru.NewBuilder[string, string, int](3, compare.IdentityHashKey[string]).
+		OnEvict(func(key string, value int) {
+			evicted = append(evicted, key)
+		}).
+		Build()
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+
+	// Using "a" makes "b" the least recently used.
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	c.Put("d", 4)
+	assert.Equal(t, []string{"b"}, evicted)
+	assert.False(t, c.ContainsKey("b"))
+
+	// Peeking at "c" does not protect it from eviction.
+	_, ok = c.Peek("c")
+	assert.True(t, ok)
+
+	c.Put("e", 5)
+	assert.Equal(t, []string{"b", "c"}, evicted)
+
+	// Updating "a" counts as a use.
+	c.Put("a", 10)
+	c.Put("f", 6)
+	assert.Equal(t, []string{"b", "c", "d"}, evicted)
+
+	key, value, ok := c.Oldest()
+	assert.True(t, ok)
+	assert.Equal(t, "e", key)
+	assert.Equal(t, 5, value)
+
+	// Removing is not an eviction.
+	assert.True(t, c.Remove("e"))
+	assert.Equal(t, []string{"b", "c", "d"}, evicted)
+
+	assert.Equal(t, 1, c.Resize(1))
+	assert.Equal(t, []string{"b", "c", "d", "a"}, evicted)
+	assert.Equal(t, cache.Stats{Hits: 1, Misses: 0, Evictions: 4}, c.Stats())
+}
+
+func TestLRUZeroCapacity(t *testing.T) {
+	t.Parallel()
+
+	c := lru.New[int, int](0)
+	c.Put(1, 1)
+	assert.True(t, c.Empty())
+
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+
+	_, _, ok = c.Oldest()
+	assert.False(t, ok)
+}
@@ -0,0 +1,73 @@
+package policy
+
+import (
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/cache"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/arc"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/lfu"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/lru"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/tinylfu"
+)
+
+// Policy names a cache eviction policy, so the policy can be chosen by configuration.
+type Policy string
+
+const (
+	// LRU evicts the least recently used entry, see lru.LRU.
+	LRU Policy = "lru"
+	// LFU evicts the least frequently used entry, see lfu.LFU.
+	LFU Policy = "lfu"
+	// ARC adapts between recency and frequency, see arc.ARC.
+	ARC Policy = "arc"
+	// WTinyLFU admits entries based on their recent frequency, see tinylfu.TinyLFU.
+	WTinyLFU Policy = "wtinylfu"
+)
+
+// Policies returns all supported policies.
+func Policies() []Policy {
+	return []Policy{LRU, LFU, ARC, WTinyLFU}
+}
+
+type Builder[K any, HK comparable, V any] struct {
+	policy   Policy
+	capacity int
+	hashkey  compare.HashKey[K, HK]
+	onEvict  cache.EvictionCallback[K, V]
+}
+
+func NewBuilder[K any, HK comparable, V any](
+	policy Policy, capacity int, hashkey compare.HashKey[K, HK],
+) *Builder[K, HK, V] {
+	return &Builder[K, HK, V]{
+		policy:   policy,
+		capacity: capacity,
+		hashkey:  hashkey,
+	}
+}
+
+func (b *Builder[K, HK, V]) OnEvict(onEvict cache.EvictionCallback[K, V]) *Builder[K, HK, V] {
+	b.onEvict = onEvict
+
+	return b
+}
+
+// Build creates a cache using the builder's policy. Returns false if the policy is not supported.
+func (b *Builder[K, HK, V]) Build() (cache.Cache[K, V], bool) {
+	switch b.policy {
+	case LRU:
+		return lru.NewBuilder[K, HK, V](b.capacity, b.hashkey).OnEvict(b.onEvict).Build(), true
+	case LFU:
+		return lfu.NewBuilder[K, HK, V](b.capacity, b.hashkey).OnEvict(b.onEvict).Build(), true
+	case ARC:
+		return arc.NewBuilder[K, HK, V](b.capacity, b.hashkey).OnEvict(b.onEvict).Build(), true
+	case WTinyLFU:
+		return tinylfu.NewBuilder[K, HK, V](b.capacity, b.hashkey).OnEvict(b.onEvict).Build(), true
+	default:
+		return nil, false
+	}
+}
+
+// New creates a cache using the named policy. Returns false if the policy is not supported.
+func New[K comparable, V any](policy Policy, capacity int) (cache.Cache[K, V], bool) {
+	return NewBuilder[K, K, V](policy, capacity, compare.IdentityHashKey[K]).Build()
+}
@@ -0,0 +1,85 @@
+package policy_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/cache/policy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	for _, p := range policy.Policies() {
+		p := p
+		t.Run(string(p), func(t *testing.T) {
+			t.Parallel()
+
+			c, ok := policy.New[string, int](p, 2)
+			assert.True(t, ok)
+			assert.Equal(t, 2, c.Capacity())
+
+			c.Put("a", 1)
+			value, ok := c.Get("a")
+			assert.True(t, ok)
+			assert.Equal(t, 1, value)
+		})
+	}
+}
+
+func TestNew_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	_, ok := policy.New[string, int]("fifo", 2)
+	assert.False(t, ok)
+}
+
+func TestBuilderOnEvict(t *testing.T) {
+	t.Parallel()
+
+	for _, p := range policy.Policies() {
+		p := p
+		t.Run(string(p), func(t *testing.T) {
+			t.Parallel()
+
+			evictions := 0
+			c, ok := policy.NewBuilder[int, string, int](p, 5, func(key int) string { return fmt.Sprint(key) }).
+				OnEvict(func(_ int, _ int) { evictions++ }).
+				Build()
+			assert.True(t, ok)
+
+			for i := 0; i < 20; i++ {
+				c.Put(i, i)
+			}
+
+			assert.Equal(t, 5, c.Size())
+			assert.Equal(t, 15, evictions)
+			assert.Equal(t, uint64(evictions), c.Stats().Evictions)
+		})
+	}
+}
+
+func TestBuilderHashKey(t *testing.T) {
+	t.Parallel()
+
+	for _, p := range policy.Policies() {
+		p := p
+		t.Run(string(p), func(t *testing.T) {
+			t.Parallel()
+
+			// Keys that differ only in case are the same key.
+			c, ok := policy.NewBuilder[string, string, int](p, 5, strings.ToLower).Build()
+			assert.True(t, ok)
+
+			c.Put("Foo", 1)
+			c.Put("FOO", 2)
+			assert.Equal(t, 1, c.Size())
+
+			value, ok := c.Get("foo")
+			assert.True(t, ok)
+			assert.Equal(t, 2, value)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package tinylfu
+
+import "github.com/kaschnit/go-ds/pkg/compare"
+
+const (
+	sketchDepth = 4
+
+	// Counters saturate at this value, only the relative popularity of keys matters.
+	maxCount = 15
+
+	// The counters are halved after this many increments per cached entry, so that the sketch
+	// reflects recent popularity rather than all-time popularity.
+	sampleFactor = 10
+
+	// Number of counters per row for each cached entry. Wider rows mean fewer collisions,
+	// so keys that are seen once are less likely to be mistaken for popular keys.
+	widthFactor = 8
+)
+
+// frequencySketch is a count-min sketch of small saturating counters that estimates how often
+// each key has been seen recently.
+type frequencySketch[T any] struct {
+	hasher     compare.Hasher[T]
+	rows       [sketchDepth][]uint8
+	mask       uint64
+	additions  int
+	sampleSize int
+}
+
+func newFrequencySketch[T any](capacity int, hasher compare.Hasher[T]) *frequencySketch[T] {
+	width := 1
+	for width < capacity*widthFactor {
+		width <<= 1
+	}
+
+	s := &frequencySketch[T]{
+		hasher:     hasher,
+		mask:       uint64(width - 1),
+		additions:  0,
+		sampleSize: sampleFactor * max(capacity, 1),
+	}
+
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+	}
+
+	return s
+}
+
+// Increment records an occurrence of the value.
+func (s *frequencySketch[T]) Increment(value T) {
+	hash := s.hasher(value)
+	incremented := false
+
+	for i := range s.rows {
+		index := s.index(hash, i)
+		if s.rows[i][index] < maxCount {
+			s.rows[i][index]++
+			incremented = true
+		}
+	}
+
+	if incremented {
+		s.additions++
+		if s.additions >= s.sampleSize {
+			s.age()
+		}
+	}
+}
+
+// Estimate returns the estimated number of recent occurrences of the value.
+// The estimate may be too high because of hash collisions, but is never too low
+// apart from the effect of aging.
+func (s *frequencySketch[T]) Estimate(value T) uint8 {
+	hash := s.hasher(value)
+	estimate := uint8(maxCount)
+
+	for i := range s.rows {
+		if count := s.rows[i][s.index(hash, i)]; count < estimate {
+			estimate = count
+		}
+	}
+
+	return estimate
+}
+
+func (s *frequencySketch[T]) Clear() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] = 0
+		}
+	}
+
+	s.additions = 0
+}
+
+// age halves every counter.
+func (s *frequencySketch[T]) age() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] >>= 1
+		}
+	}
+
+	s.additions /= 2
+}
+
+// index derives the position of a hash in a row using double hashing.
+//
+//nolint:gomnd
+func (s *frequencySketch[T]) index(hash uint64, row int) uint64 {
+	h1 := hash
+	h2 := (hash >> 32) | 1
+
+	return (h1 + uint64(row)*h2) & s.mask
+}
@@ -0,0 +1,313 @@
+package tinylfu
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/cache"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/map/linkedhashmap"
+)
+
+const (
+	// Percentage of the capacity used for the admission window.
+	windowPercent = 1
+
+	// Percentage of the main space used for the protected segment.
+	protectedPercent = 80
+)
+
+type Builder[K any, HK comparable, V any] struct {
+	capacity int
+	hashkey  compare.HashKey[K, HK]
+	hasher   compare.Hasher[HK]
+	onEvict  cache.EvictionCallback[K, V]
+}
+
+func NewBuilder[K any, HK comparable, V any](capacity int, hashkey compare.HashKey[K, HK]) *Builder[K, HK, V] {
+	return &Builder[K, HK, V]{
+		capacity: capacity,
+		hashkey:  hashkey,
+		hasher:   compare.DefaultHasher[HK],
+	}
+}
+
+// Hasher sets the hasher used to estimate key frequencies. Defaults to compare.DefaultHasher.
+func (b *Builder[K, HK, V]) Hasher(hasher compare.Hasher[HK]) *Builder[K, HK, V] {
+	b.hasher = hasher
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) OnEvict(onEvict cache.EvictionCallback[K, V]) *Builder[K, HK, V] {
+	b.onEvict = onEvict
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) Build() *TinyLFU[K, HK, V] {
+	c := &TinyLFU[K, HK, V]{
+		hashkey:   b.hashkey,
+		hasher:    b.hasher,
+		window:    linkedhashmap.NewBuilder[K, HK, V](b.hashkey).Build(),
+		probation: linkedhashmap.NewBuilder[K, HK, V](b.hashkey).Build(),
+		protected: linkedhashmap.NewBuilder[K, HK, V](b.hashkey).Build(),
+		onEvict:   b.onEvict,
+		stats:     cache.Stats{},
+	}
+	c.setCapacity(b.capacity)
+
+	return c
+}
+
+// TinyLFU is a W-TinyLFU cache. New entries go into a small LRU admission window. An entry
+// leaving the window only enters the main space if it has been used more often recently than
+// the entry it would replace, according to a count-min sketch of recent key frequencies.
+// This protects popular entries from being flushed by scans over keys that are used once.
+// The main space is a segmented LRU, where entries used again are moved from a probation
+// segment to a protected segment.
+// A capacity of zero or less means nothing is cached.
+type TinyLFU[K any, HK comparable, V any] struct {
+	capacity          int
+	windowCapacity    int
+	protectedCapacity int
+
+	hashkey compare.HashKey[K, HK]
+	hasher  compare.Hasher[HK]
+	sketch  *frequencySketch[HK]
+
+	// Each segment is ordered from least recently to most recently used.
+	window    *linkedhashmap.LinkedHashMap[K, HK, V]
+	probation *linkedhashmap.LinkedHashMap[K, HK, V]
+	protected *linkedhashmap.LinkedHashMap[K, HK, V]
+
+	onEvict cache.EvictionCallback[K, V]
+	stats   cache.Stats
+}
+
+func New[K comparable, V any](capacity int) *TinyLFU[K, K, V] {
+	return NewBuilder[K, K, V](capacity, compare.IdentityHashKey[K]).Build()
+}
+
+func (c *TinyLFU[K, HK, V]) Empty() bool {
+	return c.Size() == 0
+}
+
+func (c *TinyLFU[K, HK, V]) Size() int {
+	return c.window.Size() + c.probation.Size() + c.protected.Size()
+}
+
+func (c *TinyLFU[K, HK, V]) Clear() {
+	c.window.Clear()
+	c.probation.Clear()
+	c.protected.Clear()
+	c.sketch.Clear()
+}
+
+func (c *TinyLFU[K, HK, V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("TinyLFU[capacity=%d]\n", c.capacity))
+
+	strs := make([]string, 0, c.Size())
+	appendEntry := func(key K, value V) {
+		strs = append(strs, entry.NewRef(key, value).String())
+	}
+
+	c.window.ForEach(appendEntry)
+	c.probation.ForEach(appendEntry)
+	c.protected.ForEach(appendEntry)
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (c *TinyLFU[K, HK, V]) Get(key K) (V, bool) {
+	if c.capacity > 0 {
+		c.sketch.Increment(c.hashkey(key))
+	}
+
+	value, ok := c.Peek(key)
+	if !ok {
+		c.stats.Misses++
+
+		return value, false
+	}
+
+	c.stats.Hits++
+	c.touch(key, value)
+
+	return value, true
+}
+
+func (c *TinyLFU[K, HK, V]) Peek(key K) (V, bool) {
+	if value, ok := c.window.Get(key); ok {
+		return value, true
+	}
+
+	if value, ok := c.probation.Get(key); ok {
+		return value, true
+	}
+
+	return c.protected.Get(key)
+}
+
+func (c *TinyLFU[K, HK, V]) Put(key K, value V) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	c.sketch.Increment(c.hashkey(key))
+
+	if c.ContainsKey(key) {
+		c.touch(key, value)
+
+		return
+	}
+
+	c.window.Put(key, value)
+
+	for c.window.Size() > c.windowCapacity {
+		candidateKey, candidateValue, _ := c.window.Front()
+		c.window.RemoveKey(candidateKey)
+		c.admit(candidateKey, candidateValue)
+	}
+}
+
+func (c *TinyLFU[K, HK, V]) Remove(key K) bool {
+	return c.window.RemoveKey(key) || c.probation.RemoveKey(key) || c.protected.RemoveKey(key)
+}
+
+func (c *TinyLFU[K, HK, V]) ContainsKey(key K) bool {
+	return c.window.ContainsKey(key) || c.probation.ContainsKey(key) || c.protected.ContainsKey(key)
+}
+
+func (c *TinyLFU[K, HK, V]) Capacity() int {
+	return c.capacity
+}
+
+// Resize changes the capacity of the cache. The frequency sketch is sized for the capacity,
+// so resizing starts the frequency history from scratch.
+func (c *TinyLFU[K, HK, V]) Resize(capacity int) int {
+	c.setCapacity(capacity)
+
+	// Move entries out of segments that are now too big, without admission checks
+	// since these entries were already in the cache.
+	for c.window.Size() > c.windowCapacity {
+		key, value, _ := c.window.Front()
+		c.window.RemoveKey(key)
+		c.probation.Put(key, value)
+	}
+
+	c.demoteOverCapacity()
+
+	evicted := 0
+
+	for c.Size() > c.capacity {
+		switch {
+		case !c.probation.Empty():
+			c.evictFront(c.probation)
+		case !c.protected.Empty():
+			c.evictFront(c.protected)
+		default:
+			c.evictFront(c.window)
+		}
+
+		evicted++
+	}
+
+	return evicted
+}
+
+func (c *TinyLFU[K, HK, V]) Stats() cache.Stats {
+	return c.stats
+}
+
+func (c *TinyLFU[K, HK, V]) setCapacity(capacity int) {
+	c.capacity = capacity
+	c.windowCapacity = 0
+	c.protectedCapacity = 0
+
+	if capacity > 0 {
+		c.windowCapacity = max(1, capacity*windowPercent/100)
+		c.protectedCapacity = (capacity - c.windowCapacity) * protectedPercent / 100
+	}
+
+	c.sketch = newFrequencySketch(capacity, c.hasher)
+}
+
+// touch records a use of an entry that is in the cache, updating its value.
+func (c *TinyLFU[K, HK, V]) touch(key K, value V) {
+	switch {
+	case c.window.ContainsKey(key):
+		c.window.Put(key, value)
+		c.window.MoveToBack(key)
+	case c.probation.ContainsKey(key):
+		// Entries used again while on probation are promoted to the protected segment.
+		c.probation.RemoveKey(key)
+		c.protected.Put(key, value)
+		c.demoteOverCapacity()
+	default:
+		c.protected.Put(key, value)
+		c.protected.MoveToBack(key)
+	}
+}
+
+// admit decides whether an entry leaving the window enters the main space.
+func (c *TinyLFU[K, HK, V]) admit(key K, value V) {
+	mainCapacity := c.capacity - c.windowCapacity
+	if c.probation.Size()+c.protected.Size() < mainCapacity {
+		c.probation.Put(key, value)
+
+		return
+	}
+
+	victims := c.probation
+	if victims.Empty() {
+		victims = c.protected
+	}
+
+	victimKey, _, ok := victims.Front()
+	if !ok {
+		// There is no main space at all.
+		c.evict(key, value)
+
+		return
+	}
+
+	if c.sketch.Estimate(c.hashkey(key)) > c.sketch.Estimate(c.hashkey(victimKey)) {
+		c.evictFront(victims)
+		c.probation.Put(key, value)
+	} else {
+		c.evict(key, value)
+	}
+}
+
+// demoteOverCapacity moves the least recently used protected entries back to probation
+// until the protected segment is within its capacity.
+func (c *TinyLFU[K, HK, V]) demoteOverCapacity() {
+	for c.protected.Size() > c.protectedCapacity {
+		key, value, _ := c.protected.Front()
+		c.protected.RemoveKey(key)
+		c.probation.Put(key, value)
+	}
+}
+
+func (c *TinyLFU[K, HK, V]) evictFront(entries *linkedhashmap.LinkedHashMap[K, HK, V]) {
+	key, value, ok := entries.Front()
+	if !ok {
+		return
+	}
+
+	entries.RemoveKey(key)
+	c.evict(key, value)
+}
+
+func (c *TinyLFU[K, HK, V]) evict(key K, value V) {
+	c.stats.Evictions++
+
+	if c.onEvict != nil {
+		c.onEvict(key, value)
+	}
+}
@@ -0,0 +1,85 @@
+package tinylfu_test
+
+import (
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/cache"
+	"github.com/kaschnit/go-ds/pkg/containers/cache/tinylfu"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that TinyLFU implements Cache.
+var _ cache.Cache[string, int] = &tinylfu.TinyLFU[string, string, int]{}
+
+func TestTinyLFUString(t *testing.T) {
+	t.Parallel()
+
+	c := tinylfu.New[int, string](100)
+	assert.Equal(t, "TinyLFU[capacity=100]\n", c.String())
+
+	c.Put(1, "a")
+	assert.Equal(t, "TinyLFU[capacity=100]\nEntry{Key:1, Value:a}", c.String())
+}
+
+func TestTinyLFUScanResistance(t *testing.T) {
+	t.Parallel()
+
+	c := tinylfu.New[int, int](100)
+
+	// Make a set of keys popular.
+	for round := 0; round < 5; round++ {
+		for key := 0; key < 50; key++ {
+			if _, ok := c.Get(key); !ok {
+				c.Put(key, key)
+			}
+		}
+	}
+
+	// Scan over many keys that are used once. The scan is short enough that the sketch
+	// does not age out the popularity of the keys.
+	for key := 1000; key < 1500; key++ {
+		c.Put(key, key)
+	}
+
+	survivors := 0
+
+	for key := 0; key < 50; key++ {
+		if c.ContainsKey(key) {
+			survivors++
+		}
+	}
+
+	assert.Equal(t, 50, survivors)
+	assert.Equal(t, 100, c.Size())
+}
+
+func TestTinyLFUAdmission(t *testing.T) {
+	t.Parallel()
+
+	evicted := []string{}
+	c := tinylfu.NewBuilder[string, string, int](3, compare.IdentityHashKey[string]).
+		Hasher(compare.StringHasher).
+		OnEvict(func(key string, _ int) {
+			evicted = append(evicted, key)
+		}).
+		Build()
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	assert.Equal(t, 3, c.Size())
+
+	// "d" has been seen as often as the victim, so it is not admitted.
+	c.Put("d", 4)
+	assert.Equal(t, []string{"c"}, evicted)
+	assert.True(t, c.ContainsKey("d"))
+
+	// "c" has now been seen more often than the victim, so it replaces it.
+	c.Get("c")
+	c.Put("c", 3)
+	c.Put("e", 5)
+	assert.Contains(t, evicted, "a")
+	assert.True(t, c.ContainsKey("c"))
+	assert.Equal(t, 3, c.Size())
+}
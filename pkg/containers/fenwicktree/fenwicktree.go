@@ -0,0 +1,219 @@
+package fenwicktree
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"golang.org/x/exp/constraints"
+)
+
+// Number is a type that can be summed.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// FenwickTree, also called a binary indexed tree, holds a fixed number of values and sums any
+// prefix or range of them, adding to a value and summing a range each in logarithmic time.
+// It is smaller and faster than a segment tree, but only supports sums.
+// Ranges are half open, so a range [start, end) holds the values from start up to but not
+// including end.
+type FenwickTree[T Number] struct {
+	// tree[i-1] holds the sum of the values in [i-lowbit(i), i), where lowbit(i) is the lowest
+	// set bit of i.
+	tree []T
+}
+
+// New returns a tree of size values that are all zero.
+func New[T Number](size int) *FenwickTree[T] {
+	return &FenwickTree[T]{
+		tree: make([]T, size),
+	}
+}
+
+// NewFromValues returns a tree holding the values, building it in linear time.
+func NewFromValues[T Number](values ...T) *FenwickTree[T] {
+	tree := append(make([]T, 0, len(values)), values...)
+
+	// Add each partial sum into the next node that covers it.
+	for i := 1; i <= len(tree); i++ {
+		if parent := i + lowbit(i); parent <= len(tree) {
+			tree[parent-1] += tree[i-1]
+		}
+	}
+
+	return &FenwickTree[T]{
+		tree: tree,
+	}
+}
+
+func (t *FenwickTree[T]) Empty() bool {
+	return t.Size() == 0
+}
+
+func (t *FenwickTree[T]) Size() int {
+	return len(t.tree)
+}
+
+// Clear sets every value to zero.
+func (t *FenwickTree[T]) Clear() {
+	t.tree = make([]T, len(t.tree))
+}
+
+func (t *FenwickTree[T]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("FenwickTree\n")
+
+	strs := make([]string, 0, t.Size())
+	t.ForEach(func(_ int, value T) {
+		strs = append(strs, fmt.Sprintf("%v", value))
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (t *FenwickTree[T]) ForEach(op enumerable.Op[int, T]) {
+	for i, value := range t.Values() {
+		op(i, value)
+	}
+}
+
+func (t *FenwickTree[T]) Any(predicate enumerable.Predicate[int, T]) bool {
+	_, _, found := t.Find(predicate)
+
+	return found
+}
+
+func (t *FenwickTree[T]) All(predicate enumerable.Predicate[int, T]) bool {
+	for i, value := range t.Values() {
+		if !predicate(i, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *FenwickTree[T]) Find(predicate enumerable.Predicate[int, T]) (int, T, bool) {
+	for i, value := range t.Values() {
+		if predicate(i, value) {
+			return i, value, true
+		}
+	}
+
+	return -1, *new(T), false
+}
+
+// Values returns the values in linear time.
+func (t *FenwickTree[T]) Values() []T {
+	values := append(make([]T, 0, len(t.tree)), t.tree...)
+
+	// Undo NewFromValues, subtracting in reverse so that each node still holds its partial sum.
+	for i := len(values); i >= 1; i-- {
+		if parent := i + lowbit(i); parent <= len(values) {
+			values[parent-1] -= values[i-1]
+		}
+	}
+
+	return values
+}
+
+func (t *FenwickTree[T]) Get(index int) (T, bool) {
+	return t.Sum(index, index+1)
+}
+
+// Set replaces the value at the index. Returns false if the index is out of range.
+func (t *FenwickTree[T]) Set(index int, value T) bool {
+	old, ok := t.Get(index)
+	if !ok {
+		return false
+	}
+
+	return t.Add(index, value-old)
+}
+
+// Add adds the delta to the value at the index. Returns false if the index is out of range.
+func (t *FenwickTree[T]) Add(index int, delta T) bool {
+	if index < 0 || index >= len(t.tree) {
+		return false
+	}
+
+	for i := index + 1; i <= len(t.tree); i += lowbit(i) {
+		t.tree[i-1] += delta
+	}
+
+	return true
+}
+
+// PrefixSum returns the sum of the values in [0, end). Returns false if end is out of range.
+func (t *FenwickTree[T]) PrefixSum(end int) (T, bool) {
+	if end < 0 || end > len(t.tree) {
+		return 0, false
+	}
+
+	sum := T(0)
+	for i := end; i > 0; i -= lowbit(i) {
+		sum += t.tree[i-1]
+	}
+
+	return sum, true
+}
+
+// Sum returns the sum of the values in [start, end). Returns false if the range is out of
+// bounds or start is after end.
+func (t *FenwickTree[T]) Sum(start int, end int) (T, bool) {
+	if start < 0 || start > end {
+		return 0, false
+	}
+
+	endSum, ok := t.PrefixSum(end)
+	if !ok {
+		return 0, false
+	}
+
+	startSum, _ := t.PrefixSum(start)
+
+	return endSum - startSum, true
+}
+
+// Total returns the sum of all of the values.
+func (t *FenwickTree[T]) Total() T {
+	total, _ := t.PrefixSum(len(t.tree))
+
+	return total
+}
+
+// LowerBound returns the smallest end such that the sum of [0, end) is at least the target,
+// in logarithmic time. The values must not be negative, so that the prefix sums never
+// decrease. Returns false if the sum of all of the values is less than the target.
+func (t *FenwickTree[T]) LowerBound(target T) (int, bool) {
+	if target <= 0 {
+		return 0, true
+	}
+
+	step := 1
+	for step*2 <= len(t.tree) {
+		step *= 2
+	}
+
+	// Find the longest prefix whose sum is less than the target, one bit at a time.
+	end := 0
+	for ; step > 0; step /= 2 {
+		if next := end + step; next <= len(t.tree) && t.tree[next-1] < target {
+			end = next
+			target -= t.tree[next-1]
+		}
+	}
+
+	if end == len(t.tree) {
+		return 0, false
+	}
+
+	return end + 1, true
+}
+
+func lowbit(i int) int {
+	return i & -i
+}
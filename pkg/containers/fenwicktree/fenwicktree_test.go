@@ -0,0 +1,171 @@
+package fenwicktree_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/container"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/fenwicktree"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that FenwickTree implements Container and Enumerable.
+var (
+	_ container.Container             = fenwicktree.New[int](0)
+	_ enumerable.Enumerable[int, int] = fenwicktree.New[int](0)
+)
+
+func TestFenwickTreeString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "FenwickTree\n0,0", fenwicktree.New[int](2).String())
+	assert.Equal(t, "FenwickTree\n1.5,2,-3", fenwicktree.NewFromValues(1.5, 2, -3).String())
+	assert.Equal(t, "FenwickTree\n", fenwicktree.NewFromValues[int]().String())
+}
+
+func TestFenwickTreeSums(t *testing.T) {
+	t.Parallel()
+
+	tr := fenwicktree.NewFromValues(3, 1, 4, 1, 5, 9, 2, 6)
+	assert.Equal(t, 8, tr.Size())
+	assert.Equal(t, 31, tr.Total())
+
+	tests := []struct {
+		name     string
+		start    int
+		end      int
+		expected int
+		ok       bool
+	}{
+		{name: "everything", start: 0, end: 8, expected: 31, ok: true},
+		{name: "prefix", start: 0, end: 3, expected: 8, ok: true},
+		{name: "middle", start: 2, end: 6, expected: 19, ok: true},
+		{name: "empty", start: 4, end: 4, expected: 0, ok: true},
+		{name: "start after end", start: 5, end: 4, expected: 0, ok: false},
+		{name: "past the end", start: 0, end: 9, expected: 0, ok: false},
+		{name: "negative start", start: -1, end: 2, expected: 0, ok: false},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, ok := tr.Sum(testCase.start, testCase.end)
+			assert.Equal(t, testCase.ok, ok)
+			assert.Equal(t, testCase.expected, result)
+		})
+	}
+}
+
+func TestFenwickTreeUpdate(t *testing.T) {
+	t.Parallel()
+
+	tr := fenwicktree.New[uint](5)
+	assert.True(t, tr.Add(2, 5))
+	assert.True(t, tr.Add(2, 1))
+	assert.True(t, tr.Set(4, 3))
+	assert.True(t, tr.Set(2, 2))
+	assert.False(t, tr.Add(5, 1))
+	assert.False(t, tr.Set(-1, 1))
+
+	assert.Equal(t, []uint{0, 0, 2, 0, 3}, tr.Values())
+
+	value, ok := tr.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, uint(2), value)
+
+	prefix, ok := tr.PrefixSum(3)
+	assert.True(t, ok)
+	assert.Equal(t, uint(2), prefix)
+
+	tr.Clear()
+	assert.Equal(t, uint(0), tr.Total())
+	assert.Equal(t, 5, tr.Size())
+}
+
+func TestFenwickTreeLowerBound(t *testing.T) {
+	t.Parallel()
+
+	tr := fenwicktree.NewFromValues(2, 0, 3, 1, 0, 4)
+
+	tests := []struct {
+		name     string
+		target   int
+		expected int
+		ok       bool
+	}{
+		{name: "zero target", target: 0, expected: 0, ok: true},
+		{name: "first value", target: 1, expected: 1, ok: true},
+		{name: "exact prefix sum", target: 2, expected: 1, ok: true},
+		{name: "skips zero values", target: 3, expected: 3, ok: true},
+		{name: "total", target: 10, expected: 6, ok: true},
+		{name: "more than the total", target: 11, expected: 0, ok: false},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			end, ok := tr.LowerBound(testCase.target)
+			assert.Equal(t, testCase.ok, ok)
+			assert.Equal(t, testCase.expected, end)
+		})
+	}
+}
+
+func TestFenwickTreeEnumeration(t *testing.T) {
+	t.Parallel()
+
+	tr := fenwicktree.NewFromValues(4, 5, 6)
+
+	index, value, found := tr.Find(func(_ int, value int) bool { return value > 4 })
+	assert.True(t, found)
+	assert.Equal(t, 1, index)
+	assert.Equal(t, 5, value)
+
+	assert.True(t, tr.Any(func(_ int, value int) bool { return value == 6 }))
+	assert.True(t, tr.All(func(_ int, value int) bool { return value >= 4 }))
+	assert.False(t, tr.All(func(_ int, value int) bool { return value > 4 }))
+}
+
+func TestFenwickTreeRandomOperations(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(42))
+	values := make([]int64, 53)
+
+	for i := range values {
+		values[i] = int64(rng.Intn(100))
+	}
+
+	tr := fenwicktree.NewFromValues(values...)
+	assert.Equal(t, values, tr.Values())
+
+	for i := 0; i < 2000; i++ {
+		index := rng.Intn(len(values))
+
+		switch rng.Intn(3) {
+		case 0:
+			delta := int64(rng.Intn(200) - 100)
+			values[index] += delta
+			assert.True(t, tr.Add(index, delta))
+		case 1:
+			values[index] = int64(rng.Intn(100))
+			assert.True(t, tr.Set(index, values[index]))
+		default:
+			end := index + rng.Intn(len(values)-index+1)
+
+			expected := int64(0)
+			for _, value := range values[index:end] {
+				expected += value
+			}
+
+			result, ok := tr.Sum(index, end)
+			assert.True(t, ok)
+			assert.Equal(t, expected, result)
+		}
+	}
+
+	assert.Equal(t, values, tr.Values())
+}
@@ -0,0 +1,28 @@
+package intervaltree
+
+import "fmt"
+
+// Interval is a closed range [lo, hi], which contains both of its endpoints.
+type Interval[T any] struct {
+	lo T
+	hi T
+}
+
+func NewInterval[T any](lo T, hi T) Interval[T] {
+	return Interval[T]{
+		lo: lo,
+		hi: hi,
+	}
+}
+
+func (i Interval[T]) Lo() T {
+	return i.lo
+}
+
+func (i Interval[T]) Hi() T {
+	return i.hi
+}
+
+func (i Interval[T]) String() string {
+	return fmt.Sprintf("[%v, %v]", i.lo, i.hi)
+}
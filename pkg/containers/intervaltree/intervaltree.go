@@ -0,0 +1,333 @@
+package intervaltree
+
+import (
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/iterator"
+	"golang.org/x/exp/constraints"
+)
+
+type Builder[T any, V any] struct {
+	comparator compare.Comparator[T]
+	entries    []entry.Entry[Interval[T], V]
+}
+
+func NewBuilder[T any, V any](comparator compare.Comparator[T]) *Builder[T, V] {
+	return &Builder[T, V]{
+		comparator: comparator,
+		entries:    nil,
+	}
+}
+
+func (b *Builder[T, V]) Insert(lo T, hi T, value V) *Builder[T, V] {
+	b.entries = append(b.entries, entry.New(NewInterval(lo, hi), value))
+
+	return b
+}
+
+func (b *Builder[T, V]) Build() *IntervalTree[T, V] {
+	t := &IntervalTree[T, V]{
+		comparator: b.comparator,
+		root:       nil,
+		size:       0,
+	}
+
+	for _, entry := range b.entries {
+		t.Insert(entry.Key().lo, entry.Key().hi, entry.Value())
+	}
+
+	return t
+}
+
+// IntervalTree maps closed intervals to values and finds the intervals that overlap a range or
+// contain a point in time logarithmic in the number of intervals, plus the number of results.
+// It is a balanced binary tree ordered by the start of the intervals, in which each node also
+// records the largest end below it so that subtrees that end too early can be skipped.
+// Intervals are enumerated ordered by their start, and then by their end.
+type IntervalTree[T any, V any] struct {
+	comparator compare.Comparator[T]
+	root       *node[T, V]
+	size       int
+}
+
+func New[T constraints.Ordered, V any]() *IntervalTree[T, V] {
+	return NewBuilder[T, V](compare.OrderedComparator[T]).Build()
+}
+
+func (t *IntervalTree[T, V]) Empty() bool {
+	return t.Size() == 0
+}
+
+func (t *IntervalTree[T, V]) Size() int {
+	return t.size
+}
+
+func (t *IntervalTree[T, V]) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+func (t *IntervalTree[T, V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("IntervalTree\n")
+
+	strs := make([]string, 0, t.Size())
+	t.ForEach(func(interval Interval[T], value V) {
+		strs = append(strs, entry.NewRef(interval, value).String())
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+// ForEach visits the intervals in order.
+func (t *IntervalTree[T, V]) ForEach(op enumerable.Op[Interval[T], V]) {
+	t.root.walk(func(n *node[T, V]) bool {
+		op(n.interval, n.value)
+
+		return true
+	})
+}
+
+func (t *IntervalTree[T, V]) Any(predicate enumerable.Predicate[Interval[T], V]) bool {
+	_, _, found := t.Find(predicate)
+
+	return found
+}
+
+func (t *IntervalTree[T, V]) All(predicate enumerable.Predicate[Interval[T], V]) bool {
+	return t.root.walk(func(n *node[T, V]) bool {
+		return predicate(n.interval, n.value)
+	})
+}
+
+// Find returns the first interval in order that satisfies the predicate.
+func (t *IntervalTree[T, V]) Find(predicate enumerable.Predicate[Interval[T], V]) (Interval[T], V, bool) {
+	var found *node[T, V]
+
+	t.root.walk(func(n *node[T, V]) bool {
+		if predicate(n.interval, n.value) {
+			found = n
+
+			return false
+		}
+
+		return true
+	})
+
+	if found == nil {
+		return *new(Interval[T]), *new(V), false
+	}
+
+	return found.interval, found.value, true
+}
+
+// Iterator returns an iterator over the intervals in order.
+func (t *IntervalTree[T, V]) Iterator() (iterator.ForwardIterator[Interval[T], V], bool) {
+	if t.Empty() {
+		return nil, false
+	}
+
+	return newIterator(t.root, nil), true
+}
+
+// Insert maps the interval [lo, hi] to the value, replacing the value if the tree already
+// contains the interval. Returns false without inserting anything if lo is after hi.
+func (t *IntervalTree[T, V]) Insert(lo T, hi T, value V) bool {
+	if t.comparator(lo, hi) == compare.PriorityLeftHigher {
+		return false
+	}
+
+	var added bool
+	t.root, added = t.insert(t.root, NewInterval(lo, hi), value)
+
+	if added {
+		t.size++
+	}
+
+	return true
+}
+
+func (t *IntervalTree[T, V]) insert(n *node[T, V], interval Interval[T], value V) (*node[T, V], bool) {
+	if n == nil {
+		return newNode(interval, value), true
+	}
+
+	var added bool
+
+	switch t.compareIntervals(interval, n.interval) {
+	case compare.PriorityRightHigher:
+		n.left, added = t.insert(n.left, interval, value)
+	case compare.PriorityLeftHigher:
+		n.right, added = t.insert(n.right, interval, value)
+	case compare.PriorityEqual:
+		n.value = value
+
+		return n, false
+	}
+
+	return n.balance(t.comparator), added
+}
+
+// Remove removes the interval [lo, hi]. Returns false if the tree does not contain it.
+func (t *IntervalTree[T, V]) Remove(lo T, hi T) bool {
+	var removed bool
+	t.root, removed = t.remove(t.root, NewInterval(lo, hi))
+
+	if removed {
+		t.size--
+	}
+
+	return removed
+}
+
+func (t *IntervalTree[T, V]) remove(n *node[T, V], interval Interval[T]) (*node[T, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var removed bool
+
+	switch t.compareIntervals(interval, n.interval) {
+	case compare.PriorityRightHigher:
+		n.left, removed = t.remove(n.left, interval)
+	case compare.PriorityLeftHigher:
+		n.right, removed = t.remove(n.right, interval)
+	case compare.PriorityEqual:
+		if n.left == nil {
+			return n.right, true
+		}
+
+		if n.right == nil {
+			return n.left, true
+		}
+
+		// Replace the node with the next interval in order.
+		right, successor := n.right.removeMin(t.comparator)
+		successor.left = n.left
+		successor.right = right
+
+		return successor.balance(t.comparator), true
+	}
+
+	return n.balance(t.comparator), removed
+}
+
+// Get returns the value of the interval [lo, hi].
+func (t *IntervalTree[T, V]) Get(lo T, hi T) (V, bool) {
+	interval := NewInterval(lo, hi)
+
+	n := t.root
+	for n != nil {
+		switch t.compareIntervals(interval, n.interval) {
+		case compare.PriorityRightHigher:
+			n = n.left
+		case compare.PriorityLeftHigher:
+			n = n.right
+		case compare.PriorityEqual:
+			return n.value, true
+		}
+	}
+
+	return *new(V), false
+}
+
+func (t *IntervalTree[T, V]) Contains(lo T, hi T) bool {
+	_, ok := t.Get(lo, hi)
+
+	return ok
+}
+
+// Overlapping returns the intervals that share at least one point with [lo, hi] in order.
+func (t *IntervalTree[T, V]) Overlapping(lo T, hi T) []entry.Entry[Interval[T], V] {
+	entries := []entry.Entry[Interval[T], V]{}
+
+	t.root.overlapping(t.comparator, lo, hi, func(n *node[T, V]) bool {
+		entries = append(entries, entry.New(n.interval, n.value))
+
+		return true
+	})
+
+	return entries
+}
+
+// Overlaps returns whether any interval shares at least one point with [lo, hi].
+func (t *IntervalTree[T, V]) Overlaps(lo T, hi T) bool {
+	found := false
+
+	t.root.overlapping(t.comparator, lo, hi, func(*node[T, V]) bool {
+		found = true
+
+		return false
+	})
+
+	return found
+}
+
+// Containing returns the intervals that contain the point in order.
+func (t *IntervalTree[T, V]) Containing(point T) []entry.Entry[Interval[T], V] {
+	return t.Overlapping(point, point)
+}
+
+func (t *IntervalTree[T, V]) compareIntervals(left Interval[T], right Interval[T]) compare.Priority {
+	if cmp := t.comparator(left.lo, right.lo); cmp != compare.PriorityEqual {
+		return cmp
+	}
+
+	return t.comparator(left.hi, right.hi)
+}
+
+// pending is an immutable stack of the nodes an iterator has yet to visit, whose left
+// subtrees have already been pushed.
+type pending[T any, V any] struct {
+	n    *node[T, V]
+	next *pending[T, V]
+}
+
+type intervalTreeIterator[T any, V any] struct {
+	current *node[T, V]
+	pending *pending[T, V]
+}
+
+// newIterator returns an iterator at the first node below n, followed by the pending nodes.
+func newIterator[T any, V any](n *node[T, V], stack *pending[T, V]) *intervalTreeIterator[T, V] {
+	for ; n != nil; n = n.left {
+		stack = &pending[T, V]{n: n, next: stack}
+	}
+
+	return &intervalTreeIterator[T, V]{
+		current: stack.n,
+		pending: stack.next,
+	}
+}
+
+func (it *intervalTreeIterator[T, V]) Key() (Interval[T], bool) {
+	return it.current.interval, true
+}
+
+func (it *intervalTreeIterator[T, V]) Value() (V, bool) {
+	return it.current.value, true
+}
+
+func (it *intervalTreeIterator[T, V]) Next() (iterator.ForwardIterator[Interval[T], V], bool) {
+	if !it.HasNext() {
+		return nil, false
+	}
+
+	if it.current.right != nil {
+		return newIterator(it.current.right, it.pending), true
+	}
+
+	return &intervalTreeIterator[T, V]{
+		current: it.pending.n,
+		pending: it.pending.next,
+	}, true
+}
+
+func (it *intervalTreeIterator[T, V]) HasNext() bool {
+	return it.current.right != nil || it.pending != nil
+}
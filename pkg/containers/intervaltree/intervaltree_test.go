@@ -0,0 +1,218 @@
+package intervaltree_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/container"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/intervaltree"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that IntervalTree implements Container and Enumerable.
+var (
+	_ container.Container                                       = intervaltree.New[int, string]()
+	_ enumerable.Enumerable[intervaltree.Interval[int], string] = intervaltree.New[int, string]()
+)
+
+func intervalsOf[V any](entries []entry.Entry[intervaltree.Interval[int], V]) [][2]int {
+	intervals := make([][2]int, 0, len(entries))
+	for _, entry := range entries {
+		intervals = append(intervals, [2]int{entry.Key().Lo(), entry.Key().Hi()})
+	}
+
+	return intervals
+}
+
+func TestIntervalTreeString(t *testing.T) {
+	t.Parallel()
+
+	tr := intervaltree.New[int, string]()
+	assert.Equal(t, "IntervalTree\n", tr.String())
+
+	tr.Insert(5, 8, "b")
+	tr.Insert(1, 3, "a")
+	assert.Equal(t, "IntervalTree\nEntry{Key:[1, 3], Value:a},Entry{Key:[5, 8], Value:b}", tr.String())
+}
+
+func TestIntervalTreeInsertRemove(t *testing.T) {
+	t.Parallel()
+
+	tr := intervaltree.NewBuilder[int, string](compare.OrderedComparator[int]).
+		Insert(1, 5, "a").
+		Insert(1, 3, "b").
+		Build()
+	assert.Equal(t, 2, tr.Size())
+
+	// An interval must not end before it starts, but it may be a single point.
+	assert.False(t, tr.Insert(4, 3, "c"))
+	assert.True(t, tr.Insert(4, 4, "d"))
+	assert.Equal(t, 3, tr.Size())
+
+	// Inserting an interval again replaces its value.
+	assert.True(t, tr.Insert(1, 5, "e"))
+	assert.Equal(t, 3, tr.Size())
+
+	value, ok := tr.Get(1, 5)
+	assert.True(t, ok)
+	assert.Equal(t, "e", value)
+	assert.False(t, tr.Contains(1, 4))
+
+	assert.False(t, tr.Remove(1, 4))
+	assert.True(t, tr.Remove(1, 5))
+	assert.False(t, tr.Remove(1, 5))
+	assert.Equal(t, [][2]int{{1, 3}, {4, 4}}, intervalsOf(tr.Overlapping(0, 10)))
+
+	tr.Clear()
+	assert.True(t, tr.Empty())
+	assert.False(t, tr.Overlaps(0, 10))
+}
+
+func TestIntervalTreeOverlapping(t *testing.T) {
+	t.Parallel()
+
+	tr := intervaltree.New[int, int]()
+	for i, interval := range [][2]int{{15, 20}, {10, 30}, {17, 19}, {5, 20}, {12, 15}, {30, 40}} {
+		tr.Insert(interval[0], interval[1], i)
+	}
+
+	tests := []struct {
+		name     string
+		lo       int
+		hi       int
+		expected [][2]int
+	}{
+		{name: "before all intervals", lo: 0, hi: 4, expected: [][2]int{}},
+		{name: "after all intervals", lo: 41, hi: 50, expected: [][2]int{}},
+		{name: "touching an end", lo: 0, hi: 5, expected: [][2]int{{5, 20}}},
+		{name: "touching a start", lo: 40, hi: 45, expected: [][2]int{{30, 40}}},
+		{name: "inside several intervals", lo: 16, hi: 16, expected: [][2]int{{5, 20}, {10, 30}, {15, 20}}},
+		{name: "spanning everything", lo: 0, hi: 100, expected: [][2]int{{5, 20}, {10, 30}, {12, 15}, {15, 20}, {17, 19}, {30, 40}}},
+		{name: "between intervals", lo: 21, hi: 29, expected: [][2]int{{10, 30}}},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expected, intervalsOf(tr.Overlapping(testCase.lo, testCase.hi)))
+			assert.Equal(t, len(testCase.expected) > 0, tr.Overlaps(testCase.lo, testCase.hi))
+		})
+	}
+}
+
+func TestIntervalTreeContaining(t *testing.T) {
+	t.Parallel()
+
+	// Reservations of a room, checked against the times of new bookings.
+	at := func(hour int) time.Time {
+		return time.Date(2024, time.January, 1, hour, 0, 0, 0, time.UTC)
+	}
+	byTime := func(left time.Time, right time.Time) compare.Priority {
+		return compare.OrderedComparator(left.UnixNano(), right.UnixNano())
+	}
+
+	tr := intervaltree.NewBuilder[time.Time, string](byTime).
+		Insert(at(9), at(10), "standup").
+		Insert(at(13), at(15), "review").
+		Insert(at(14), at(16), "planning").
+		Build()
+
+	containing := tr.Containing(at(14))
+	assert.Len(t, containing, 2)
+	assert.Equal(t, "review", containing[0].Value())
+	assert.Equal(t, "planning", containing[1].Value())
+
+	assert.Empty(t, tr.Containing(at(12)))
+	assert.False(t, tr.Overlaps(at(11), at(12)))
+	assert.True(t, tr.Overlaps(at(10), at(11)))
+}
+
+func TestIntervalTreeEnumeration(t *testing.T) {
+	t.Parallel()
+
+	tr := intervaltree.New[int, int]()
+	tr.Insert(3, 4, 1)
+	tr.Insert(1, 9, 2)
+	tr.Insert(1, 2, 3)
+
+	visited := [][2]int{}
+	tr.ForEach(func(interval intervaltree.Interval[int], _ int) {
+		visited = append(visited, [2]int{interval.Lo(), interval.Hi()})
+	})
+	assert.Equal(t, [][2]int{{1, 2}, {1, 9}, {3, 4}}, visited)
+
+	interval, value, found := tr.Find(func(_ intervaltree.Interval[int], value int) bool { return value < 3 })
+	assert.True(t, found)
+	assert.Equal(t, intervaltree.NewInterval(1, 9), interval)
+	assert.Equal(t, 2, value)
+
+	assert.True(t, tr.Any(func(interval intervaltree.Interval[int], _ int) bool { return interval.Lo() == 3 }))
+	assert.False(t, tr.All(func(interval intervaltree.Interval[int], _ int) bool { return interval.Hi() < 9 }))
+
+	it, ok := tr.Iterator()
+	iterated := [][2]int{}
+
+	for ok {
+		interval, _ := it.Key()
+		iterated = append(iterated, [2]int{interval.Lo(), interval.Hi()})
+		it, ok = it.Next()
+	}
+	assert.Equal(t, visited, iterated)
+}
+
+func TestIntervalTreeRandomOperations(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(42))
+	expected := map[[2]int]int{}
+	tr := intervaltree.New[int, int]()
+
+	for i := 0; i < 3000; i++ {
+		lo := rng.Intn(200)
+		interval := [2]int{lo, lo + rng.Intn(20)}
+
+		if rng.Intn(3) == 0 {
+			_, existed := expected[interval]
+			delete(expected, interval)
+			assert.Equal(t, existed, tr.Remove(interval[0], interval[1]))
+		} else {
+			expected[interval] = i
+			assert.True(t, tr.Insert(interval[0], interval[1], i))
+		}
+	}
+
+	assert.Equal(t, len(expected), tr.Size())
+
+	// Compare every query against a scan of all of the intervals.
+	for lo := -5; lo < 230; lo += 3 {
+		hi := lo + rng.Intn(10)
+
+		overlapping := [][2]int{}
+		for interval := range expected {
+			if interval[0] <= hi && lo <= interval[1] {
+				overlapping = append(overlapping, interval)
+			}
+		}
+
+		sort.Slice(overlapping, func(i, j int) bool {
+			if overlapping[i][0] != overlapping[j][0] {
+				return overlapping[i][0] < overlapping[j][0]
+			}
+
+			return overlapping[i][1] < overlapping[j][1]
+		})
+
+		actual := tr.Overlapping(lo, hi)
+		assert.Equal(t, overlapping, intervalsOf(actual))
+
+		for _, entry := range actual {
+			assert.Equal(t, expected[[2]int{entry.Key().Lo(), entry.Key().Hi()}], entry.Value())
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package intervaltree
+
+import (
+	"github.com/kaschnit/go-ds/pkg/compare"
+)
+
+// node is a node of an AVL tree ordered by the intervals, first by their lo and then by their hi.
+type node[T any, V any] struct {
+	interval Interval[T]
+	value    V
+	left     *node[T, V]
+	right    *node[T, V]
+	height   int
+
+	// maxHi is the largest hi of the intervals at or below the node.
+	maxHi T
+}
+
+func newNode[T any, V any](interval Interval[T], value V) *node[T, V] {
+	return &node[T, V]{
+		interval: interval,
+		value:    value,
+		left:     nil,
+		right:    nil,
+		height:   1,
+		maxHi:    interval.hi,
+	}
+}
+
+func height[T any, V any](n *node[T, V]) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.height
+}
+
+// update recomputes the height and maxHi of the node from its children.
+func (n *node[T, V]) update(cmp compare.Comparator[T]) {
+	n.height = max(height(n.left), height(n.right)) + 1
+	n.maxHi = n.interval.hi
+
+	if n.left != nil && cmp(n.left.maxHi, n.maxHi) == compare.PriorityLeftHigher {
+		n.maxHi = n.left.maxHi
+	}
+
+	if n.right != nil && cmp(n.right.maxHi, n.maxHi) == compare.PriorityLeftHigher {
+		n.maxHi = n.right.maxHi
+	}
+}
+
+func (n *node[T, V]) rotateLeft(cmp compare.Comparator[T]) *node[T, V] {
+	pivot := n.right
+	n.right = pivot.left
+	pivot.left = n
+
+	n.update(cmp)
+	pivot.update(cmp)
+
+	return pivot
+}
+
+func (n *node[T, V]) rotateRight(cmp compare.Comparator[T]) *node[T, V] {
+	pivot := n.left
+	n.left = pivot.right
+	pivot.right = n
+
+	n.update(cmp)
+	pivot.update(cmp)
+
+	return pivot
+}
+
+// balance updates the node and rotates it if its subtrees differ in height by more than one,
+// returning the root of the balanced subtree.
+func (n *node[T, V]) balance(cmp compare.Comparator[T]) *node[T, V] {
+	n.update(cmp)
+
+	switch skew := height(n.left) - height(n.right); {
+	case skew > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = n.left.rotateLeft(cmp)
+		}
+
+		return n.rotateRight(cmp)
+	case skew < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = n.right.rotateRight(cmp)
+		}
+
+		return n.rotateLeft(cmp)
+	default:
+		return n
+	}
+}
+
+// removeMin removes the leftmost node below the node, returning the new root of the subtree
+// and the removed node.
+func (n *node[T, V]) removeMin(cmp compare.Comparator[T]) (*node[T, V], *node[T, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+
+	var removed *node[T, V]
+	n.left, removed = n.left.removeMin(cmp)
+
+	return n.balance(cmp), removed
+}
+
+// walk visits the nodes below the node in order, stopping early if the op returns false.
+func (n *node[T, V]) walk(op func(n *node[T, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	return n.left.walk(op) && op(n) && n.right.walk(op)
+}
+
+// overlapping visits the nodes below the node whose intervals overlap [lo, hi] in order,
+// stopping early if the op returns false.
+func (n *node[T, V]) overlapping(cmp compare.Comparator[T], lo T, hi T, op func(n *node[T, V]) bool) bool {
+	// Nothing below the node reaches lo.
+	if n == nil || cmp(n.maxHi, lo) == compare.PriorityRightHigher {
+		return true
+	}
+
+	if !n.left.overlapping(cmp, lo, hi, op) {
+		return false
+	}
+
+	// The node and everything to its right start after hi.
+	if cmp(n.interval.lo, hi) == compare.PriorityLeftHigher {
+		return true
+	}
+
+	if cmp(n.interval.hi, lo) != compare.PriorityRightHigher && !op(n) {
+		return false
+	}
+
+	return n.right.overlapping(cmp, lo, hi, op)
+}
@@ -46,6 +46,9 @@ type doubleLinkedNode[T any] struct {
 	value T
 	prev  *doubleLinkedNode[T]
 	next  *doubleLinkedNode[T]
+
+	// list is the list that the node is in, or nil once the node has been removed from it.
+	list *DoubleLinkedList[T]
 }
 
 type DoubleLinkedList[T any] struct {
@@ -158,19 +161,12 @@ func (l *DoubleLinkedList[T]) IteratorReverse() (iterator.ForwardIterator[int, T
 }
 
 func (l *DoubleLinkedList[T]) Append(value T) {
-	newTail := &doubleLinkedNode[T]{
+	l.linkBack(&doubleLinkedNode[T]{
 		value: value,
-		prev:  l.tail,
+		prev:  nil,
 		next:  nil,
-	}
-	if l.head == nil {
-		l.head = newTail
-	} else {
-		l.tail.next = newTail
-	}
-
-	l.tail = newTail
-	l.size++
+		list:  l,
+	})
 }
 
 func (l *DoubleLinkedList[T]) AppendAll(values ...T) {
@@ -184,6 +180,7 @@ func (l *DoubleLinkedList[T]) Prepend(value T) {
 		value: value,
 		prev:  nil,
 		next:  l.head,
+		list:  l,
 	}
 	if l.tail == nil {
 		l.tail = newHead
@@ -223,6 +220,7 @@ func (l *DoubleLinkedList[T]) Insert(index int, value T) bool {
 		value: value,
 		prev:  prevNode,
 		next:  nextNode,
+		list:  l,
 	}
 
 	// Insert the new node at the insertion point.
@@ -260,6 +258,9 @@ func (l *DoubleLinkedList[T]) InsertAll(index int, values ...T) bool {
 
 	// Create a sub list
 	subList := NewDoubleLinked(values...)
+	for node := subList.head; node != nil; node = node.next {
+		node.list = l
+	}
 
 	// Insert the sub list at the insertion point.
 	// prevNode's index was validated, so it will not be nil.
@@ -281,6 +282,7 @@ func (l *DoubleLinkedList[T]) InsertAll(index int, values ...T) bool {
 func (l *DoubleLinkedList[T]) PopBack() (T, bool) {
 	back, ok := l.GetBack()
 	if ok {
+		l.tail.list = nil
 		l.tail = l.tail.prev
 		if l.tail == nil {
 			l.head = nil
@@ -297,6 +299,7 @@ func (l *DoubleLinkedList[T]) PopBack() (T, bool) {
 func (l *DoubleLinkedList[T]) PopFront() (T, bool) {
 	front, ok := l.GetFront()
 	if ok {
+		l.head.list = nil
 		l.head = l.head.next
 		if l.head == nil {
 			l.tail = nil
@@ -358,3 +361,101 @@ func (l *DoubleLinkedList[T]) getNodeFromBack(index int) *doubleLinkedNode[T] {
 
 	return node
 }
+
+// Element is a handle to a value in a DoubleLinkedList, which lets the value be read, moved
+// or removed in constant time instead of by index. Whether the value is still in the list is
+// kept by the list itself, so every handle to the same value agrees, and a handle is no longer
+// in the list once its value has been removed by any means other than Clear. The handles of
+// a cleared list must no longer be used.
+type Element[T any] struct {
+	node *doubleLinkedNode[T]
+}
+
+func (e *Element[T]) Value() T {
+	return e.node.value
+}
+
+func (e *Element[T]) SetValue(value T) {
+	e.node.value = value
+}
+
+// AppendElement adds the value to the end of the list, and returns its element.
+func (l *DoubleLinkedList[T]) AppendElement(value T) *Element[T] {
+	l.Append(value)
+
+	return &Element[T]{
+		node: l.tail,
+	}
+}
+
+// FrontElement returns the element of the first value. Returns false if the list is empty.
+func (l *DoubleLinkedList[T]) FrontElement() (*Element[T], bool) {
+	if l.Empty() {
+		return nil, false
+	}
+
+	return &Element[T]{
+		node: l.head,
+	}, true
+}
+
+// RemoveElement removes the value of the element from the list.
+// Returns false if the element is not in the list.
+func (l *DoubleLinkedList[T]) RemoveElement(e *Element[T]) bool {
+	if e.node.list != l {
+		return false
+	}
+
+	l.unlink(e.node)
+
+	return true
+}
+
+// MoveElementToBack moves the value of the element to the end of the list.
+// Returns false if the element is not in the list.
+func (l *DoubleLinkedList[T]) MoveElementToBack(e *Element[T]) bool {
+	if e.node.list != l {
+		return false
+	}
+
+	if e.node != l.tail {
+		l.unlink(e.node)
+		l.linkBack(e.node)
+	}
+
+	return true
+}
+
+func (l *DoubleLinkedList[T]) unlink(node *doubleLinkedNode[T]) {
+	if node.prev == nil {
+		l.head = node.next
+	} else {
+		node.prev.next = node.next
+	}
+
+	if node.next == nil {
+		l.tail = node.prev
+	} else {
+		node.next.prev = node.prev
+	}
+
+	node.prev = nil
+	node.next = nil
+	node.list = nil
+	l.size--
+}
+
+func (l *DoubleLinkedList[T]) linkBack(node *doubleLinkedNode[T]) {
+	node.prev = l.tail
+	node.next = nil
+	node.list = l
+
+	if l.tail == nil {
+		l.head = node
+	} else {
+		l.tail.next = node
+	}
+
+	l.tail = node
+	l.size++
+}
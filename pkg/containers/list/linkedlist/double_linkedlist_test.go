@@ -48,3 +48,79 @@ func TestDoubleLinkedString(t *testing.T) {
 		})
 	}
 }
+
+func TestDoubleLinkedElements(t *testing.T) {
+	t.Parallel()
+
+	l := linkedlist.NewDoubleLinked(1)
+	two := l.AppendElement(2)
+	three := l.AppendElement(3)
+	assert.Equal(t, 2, two.Value())
+
+	assert.True(t, l.MoveElementToBack(two))
+	assert.Equal(t, "DoubleLinkedList\n1,3,2", l.String())
+
+	// Moving the last element leaves the list unchanged.
+	assert.True(t, l.MoveElementToBack(two))
+	assert.Equal(t, "DoubleLinkedList\n1,3,2", l.String())
+
+	three.SetValue(30)
+	front, ok := l.FrontElement()
+	assert.True(t, ok)
+	assert.True(t, l.RemoveElement(front))
+	assert.Equal(t, "DoubleLinkedList\n30,2", l.String())
+
+	assert.True(t, l.RemoveElement(two))
+	assert.False(t, l.RemoveElement(two))
+	assert.False(t, l.MoveElementToBack(two))
+	assert.Equal(t, 1, l.Size())
+
+	assert.True(t, l.RemoveElement(three))
+	assert.True(t, l.Empty())
+
+	_, ok = l.FrontElement()
+	assert.False(t, ok)
+
+	other := linkedlist.NewDoubleLinked[int]()
+	four := other.AppendElement(4)
+	assert.False(t, l.RemoveElement(four))
+
+	l.Append(5)
+	back, _ := l.GetBack()
+	front2, _ := l.GetFront()
+	assert.Equal(t, 5, back)
+	assert.Equal(t, 5, front2)
+}
+
+func TestDoubleLinkedElementsShareState(t *testing.T) {
+	t.Parallel()
+
+	// Every handle to the same value agrees on whether it is still in the list.
+	l := linkedlist.NewDoubleLinked(1, 2, 3)
+	first, _ := l.FrontElement()
+	again, _ := l.FrontElement()
+	assert.True(t, l.RemoveElement(first))
+	assert.False(t, l.RemoveElement(again))
+	assert.False(t, l.MoveElementToBack(again))
+	assert.Equal(t, "DoubleLinkedList\n2,3", l.String())
+	assert.Equal(t, 2, l.Size())
+
+	// Values removed without their handles are no longer in the list either.
+	front, _ := l.FrontElement()
+	_, _ = l.PopFront()
+	assert.False(t, l.RemoveElement(front))
+
+	back := l.AppendElement(4)
+	_, _ = l.PopBack()
+	assert.False(t, l.MoveElementToBack(back))
+	assert.Equal(t, "DoubleLinkedList\n3", l.String())
+
+	// Values inserted in any way can be removed through their handles.
+	l.PrependAll(1, 2)
+	l.InsertAll(1, 10, 20)
+	l.Insert(1, 5)
+	for element, ok := l.FrontElement(); ok; element, ok = l.FrontElement() {
+		assert.True(t, l.RemoveElement(element))
+	}
+	assert.True(t, l.Empty())
+}
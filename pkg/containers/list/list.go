@@ -6,11 +6,24 @@ import (
 	"github.com/kaschnit/go-ds/pkg/containers/iterable"
 )
 
+// ReadOnlyList is the part of List that does not modify the list. It is implemented by
+// immutable lists as well as by every List.
+type ReadOnlyList[T any] interface {
+	enumerable.Enumerable[int, T]
+	iterable.ForwardIterable[int, T]
+
+	Empty() bool
+	Size() int
+	String() string
+	GetFront() (value T, ok bool)
+	GetBack() (value T, ok bool)
+	Get(index int) (value T, ok bool)
+}
+
 //nolint:interfacebloat
 type List[T any] interface {
 	container.Container
-	enumerable.Enumerable[int, T]
-	iterable.ForwardIterable[int, T]
+	ReadOnlyList[T]
 
 	Append(value T)
 	AppendAll(values ...T)
@@ -20,7 +33,4 @@ type List[T any] interface {
 	InsertAll(index int, values ...T) (ok bool)
 	PopBack() (value T, ok bool)
 	PopFront() (value T, ok bool)
-	GetFront() (value T, ok bool)
-	GetBack() (value T, ok bool)
-	Get(index int) (value T, ok bool)
 }
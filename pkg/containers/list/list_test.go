@@ -9,6 +9,7 @@ import (
 	"github.com/kaschnit/go-ds/pkg/containers/list/arraylist"
 	"github.com/kaschnit/go-ds/pkg/containers/list/concurrentlist"
 	"github.com/kaschnit/go-ds/pkg/containers/list/linkedlist"
+	"github.com/kaschnit/go-ds/pkg/containers/list/persistentvector"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,6 +26,7 @@ func getReversibleListsForTest[T any](values ...T) []iterable.ReverseIterable[in
 	return []iterable.ReverseIterable[int, T]{
 		arraylist.New(values...),
 		linkedlist.NewDoubleLinked(values...),
+		persistentvector.New(values...),
 	}
 }
 
@@ -0,0 +1,462 @@
+package persistentvector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/iterator"
+)
+
+const (
+	bitsPerLevel = 5
+	branching    = 1 << bitsPerLevel
+	levelMask    = branching - 1
+)
+
+type persistentVectorIterator[T any] struct {
+	index  int
+	vector *PersistentVector[T]
+	step   int
+}
+
+func (p *persistentVectorIterator[T]) Key() (int, bool) {
+	return p.index, p.index >= 0 && p.index < p.vector.Size()
+}
+
+func (p *persistentVectorIterator[T]) Value() (T, bool) {
+	return p.vector.Get(p.index)
+}
+
+func (p *persistentVectorIterator[T]) Next() (iterator.ForwardIterator[int, T], bool) {
+	if !p.HasNext() {
+		return nil, false
+	}
+
+	return &persistentVectorIterator[T]{
+		index:  p.index + p.step,
+		vector: p.vector,
+		step:   p.step,
+	}, true
+}
+
+func (p *persistentVectorIterator[T]) HasNext() bool {
+	nextIndex := p.index + p.step
+
+	return nextIndex >= 0 && nextIndex < p.vector.Size()
+}
+
+// node is a node of the trie. Leaves hold up to 32 values and every other node holds up to
+// 32 children. Nodes are never modified once they are part of a vector; every slice is
+// copied before it is changed, so vectors can share them freely.
+type node[T any] struct {
+	children []*node[T]
+	values   []T
+}
+
+// PersistentVector is an immutable list implemented as a trie with 32 children per node,
+// along with a separate tail holding the last up to 32 values. Append, Set and Pop return
+// a new vector and leave the vector they are called on unchanged. The new vector shares all
+// of the trie with the old one except for the path to the changed value, so each of these
+// costs O(log32 n), which is at most 7 levels for any vector that fits in memory. Appending
+// and popping usually only touch the tail.
+// Since it never changes, a PersistentVector is safe to share between goroutines.
+type PersistentVector[T any] struct {
+	size  int
+	shift uint
+	root  *node[T]
+	tail  []T
+}
+
+func New[T any](values ...T) *PersistentVector[T] {
+	size := len(values)
+	offset := tailOffset(size)
+
+	// Build the trie bottom up from full leaves, rather than appending one value at a time.
+	level := make([]*node[T], 0, offset/branching)
+	for i := 0; i < offset; i += branching {
+		level = append(level, &node[T]{
+			children: nil,
+			values:   append([]T(nil), values[i:i+branching]...),
+		})
+	}
+
+	shift := uint(bitsPerLevel)
+	for len(level) > branching {
+		parents := make([]*node[T], 0, (len(level)+branching-1)/branching)
+		for i := 0; i < len(level); i += branching {
+			end := min(i+branching, len(level))
+			parents = append(parents, &node[T]{
+				children: level[i:end:end],
+				values:   nil,
+			})
+		}
+
+		level = parents
+		shift += bitsPerLevel
+	}
+
+	return &PersistentVector[T]{
+		size:  size,
+		shift: shift,
+		root: &node[T]{
+			children: level,
+			values:   nil,
+		},
+		tail: append([]T(nil), values[offset:]...),
+	}
+}
+
+func (v *PersistentVector[T]) Empty() bool {
+	return v.Size() == 0
+}
+
+func (v *PersistentVector[T]) Size() int {
+	return v.size
+}
+
+func (v *PersistentVector[T]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("PersistentVector\n")
+
+	strs := make([]string, 0, v.size)
+	v.ForEach(func(_ int, value T) {
+		strs = append(strs, fmt.Sprintf("%v", value))
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (v *PersistentVector[T]) ForEach(op enumerable.Op[int, T]) {
+	v.forEach(func(index int, value T) bool {
+		op(index, value)
+
+		return true
+	})
+}
+
+func (v *PersistentVector[T]) Any(predicate enumerable.Predicate[int, T]) bool {
+	_, _, found := v.Find(predicate)
+
+	return found
+}
+
+func (v *PersistentVector[T]) All(predicate enumerable.Predicate[int, T]) bool {
+	return v.forEach(predicate)
+}
+
+func (v *PersistentVector[T]) Find(predicate enumerable.Predicate[int, T]) (int, T, bool) {
+	foundIndex, foundValue, found := 0, *new(T), false
+
+	v.forEach(func(index int, value T) bool {
+		if predicate(index, value) {
+			foundIndex, foundValue, found = index, value, true
+
+			return false
+		}
+
+		return true
+	})
+
+	return foundIndex, foundValue, found
+}
+
+func (v *PersistentVector[T]) Iterator() (iterator.ForwardIterator[int, T], bool) {
+	if v.Empty() {
+		return nil, false
+	}
+
+	return &persistentVectorIterator[T]{
+		index:  0,
+		vector: v,
+		step:   1,
+	}, true
+}
+
+func (v *PersistentVector[T]) IteratorReverse() (iterator.ForwardIterator[int, T], bool) {
+	if v.Empty() {
+		return nil, false
+	}
+
+	return &persistentVectorIterator[T]{
+		index:  v.size - 1,
+		vector: v,
+		step:   -1,
+	}, true
+}
+
+func (v *PersistentVector[T]) GetFront() (T, bool) {
+	return v.Get(0)
+}
+
+func (v *PersistentVector[T]) GetBack() (T, bool) {
+	return v.Get(v.size - 1)
+}
+
+func (v *PersistentVector[T]) Get(index int) (T, bool) {
+	if index < 0 || index >= v.size {
+		return *new(T), false
+	}
+
+	return v.leafFor(index)[index&levelMask], true
+}
+
+// Append returns a new vector with the value added to the end.
+func (v *PersistentVector[T]) Append(value T) *PersistentVector[T] {
+	result := &PersistentVector[T]{
+		size:  v.size + 1,
+		shift: v.shift,
+		root:  v.root,
+		tail:  nil,
+	}
+
+	if len(v.tail) < branching {
+		result.tail = appendCopy(v.tail, value)
+
+		return result
+	}
+
+	// The tail is full, so it becomes a leaf of the trie and a new tail is started.
+	leaf := &node[T]{
+		children: nil,
+		values:   v.tail,
+	}
+
+	if (v.size >> bitsPerLevel) > (1 << v.shift) {
+		// The trie is full, so it gets a new root with the old root as its first child.
+		result.root = &node[T]{
+			children: []*node[T]{v.root, newPath(v.shift, leaf)},
+			values:   nil,
+		}
+		result.shift += bitsPerLevel
+	} else {
+		result.root = v.pushLeaf(v.shift, v.root, leaf)
+	}
+
+	result.tail = []T{value}
+
+	return result
+}
+
+// AppendAll returns a new vector with the values added to the end.
+func (v *PersistentVector[T]) AppendAll(values ...T) *PersistentVector[T] {
+	result := v
+	for _, value := range values {
+		result = result.Append(value)
+	}
+
+	return result
+}
+
+// Set returns a new vector with the value at the index replaced.
+// Returns false if the index is out of range.
+func (v *PersistentVector[T]) Set(index int, value T) (*PersistentVector[T], bool) {
+	if index < 0 || index >= v.size {
+		return v, false
+	}
+
+	result := &PersistentVector[T]{
+		size:  v.size,
+		shift: v.shift,
+		root:  v.root,
+		tail:  v.tail,
+	}
+
+	if index >= tailOffset(v.size) {
+		result.tail = append([]T(nil), v.tail...)
+		result.tail[index&levelMask] = value
+	} else {
+		result.root = setPath(v.shift, v.root, index, value)
+	}
+
+	return result, true
+}
+
+// Pop returns a new vector without the last value, along with the value.
+// Returns false if the vector is empty.
+func (v *PersistentVector[T]) Pop() (*PersistentVector[T], T, bool) {
+	if v.size == 0 {
+		return v, *new(T), false
+	}
+
+	last := v.tail[len(v.tail)-1]
+
+	if v.size == 1 {
+		return New[T](), last, true
+	}
+
+	result := &PersistentVector[T]{
+		size:  v.size - 1,
+		shift: v.shift,
+		root:  v.root,
+		tail:  nil,
+	}
+
+	if len(v.tail) > 1 {
+		result.tail = v.tail[: len(v.tail)-1 : len(v.tail)-1]
+
+		return result, last, true
+	}
+
+	// The tail is now empty, so the last leaf of the trie becomes the tail.
+	result.tail = v.leafFor(v.size - 2)
+	result.root = v.popLeaf(v.shift, v.root)
+
+	if result.root == nil {
+		result.root = &node[T]{
+			children: nil,
+			values:   nil,
+		}
+	}
+
+	if result.shift > bitsPerLevel && len(result.root.children) == 1 {
+		result.root = result.root.children[0]
+		result.shift -= bitsPerLevel
+	}
+
+	return result, last, true
+}
+
+// tailOffset returns the index of the first value in the tail of a vector of the given size.
+func tailOffset(size int) int {
+	if size <= branching {
+		return 0
+	}
+
+	return ((size - 1) >> bitsPerLevel) << bitsPerLevel
+}
+
+// leafFor returns the values of the leaf, or the tail, that holds the index.
+func (v *PersistentVector[T]) leafFor(index int) []T {
+	if index >= tailOffset(v.size) {
+		return v.tail
+	}
+
+	n := v.root
+	for level := v.shift; level > 0; level -= bitsPerLevel {
+		n = n.children[(index>>level)&levelMask]
+	}
+
+	return n.values
+}
+
+func (v *PersistentVector[T]) forEach(op func(index int, value T) bool) bool {
+	for start := 0; start < v.size; start += branching {
+		for i, value := range v.leafFor(start) {
+			if !op(start+i, value) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// pushLeaf returns a copy of the node with the leaf added after its last leaf.
+func (v *PersistentVector[T]) pushLeaf(level uint, parent *node[T], leaf *node[T]) *node[T] {
+	// The leaf goes at the position of the last value currently in the trie, plus one leaf.
+	i := ((v.size - 1) >> level) & levelMask
+
+	child := leaf
+	if level > bitsPerLevel {
+		if i < len(parent.children) {
+			child = v.pushLeaf(level-bitsPerLevel, parent.children[i], leaf)
+		} else {
+			child = newPath(level-bitsPerLevel, leaf)
+		}
+	}
+
+	result := &node[T]{
+		children: nil,
+		values:   nil,
+	}
+
+	if i < len(parent.children) {
+		result.children = append([]*node[T](nil), parent.children...)
+		result.children[i] = child
+	} else {
+		result.children = appendCopy(parent.children, child)
+	}
+
+	return result
+}
+
+// popLeaf returns a copy of the node without its last leaf, or nil if that leaves it empty.
+func (v *PersistentVector[T]) popLeaf(level uint, parent *node[T]) *node[T] {
+	i := ((v.size - 2) >> level) & levelMask
+
+	if level > bitsPerLevel {
+		child := v.popLeaf(level-bitsPerLevel, parent.children[i])
+		if child == nil && i == 0 {
+			return nil
+		}
+
+		result := &node[T]{
+			children: append([]*node[T](nil), parent.children[:i+1]...),
+			values:   nil,
+		}
+
+		if child == nil {
+			result.children = result.children[:i:i]
+		} else {
+			result.children[i] = child
+		}
+
+		return result
+	}
+
+	if i == 0 {
+		return nil
+	}
+
+	return &node[T]{
+		children: parent.children[:i:i],
+		values:   nil,
+	}
+}
+
+// newPath returns a chain of nodes down to the leaf, for a leaf that starts a new branch.
+func newPath[T any](level uint, leaf *node[T]) *node[T] {
+	if level == 0 {
+		return leaf
+	}
+
+	return &node[T]{
+		children: []*node[T]{newPath(level-bitsPerLevel, leaf)},
+		values:   nil,
+	}
+}
+
+// setPath returns a copy of the node with the value at the index replaced.
+func setPath[T any](level uint, n *node[T], index int, value T) *node[T] {
+	if level == 0 {
+		values := append([]T(nil), n.values...)
+		values[index&levelMask] = value
+
+		return &node[T]{
+			children: nil,
+			values:   values,
+		}
+	}
+
+	i := (index >> level) & levelMask
+	children := append([]*node[T](nil), n.children...)
+	children[i] = setPath(level-bitsPerLevel, n.children[i], index, value)
+
+	return &node[T]{
+		children: children,
+		values:   nil,
+	}
+}
+
+// appendCopy returns a new slice with the value after the values, never sharing the
+// backing array of the original slice.
+func appendCopy[T any](values []T, value T) []T {
+	result := make([]T, len(values)+1)
+	copy(result, values)
+	result[len(values)] = value
+
+	return result
+}
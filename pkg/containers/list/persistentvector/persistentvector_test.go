@@ -0,0 +1,255 @@
+package persistentvector_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/iterable"
+	"github.com/kaschnit/go-ds/pkg/containers/list"
+	"github.com/kaschnit/go-ds/pkg/containers/list/persistentvector"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that PersistentVector implements ReadOnlyList and is iterable in both directions.
+var (
+	_ list.ReadOnlyList[int]             = persistentvector.New(1)
+	_ iterable.ReverseIterable[int, int] = persistentvector.New(1)
+)
+
+func rangeOf(n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = i
+	}
+
+	return values
+}
+
+func assertContents(t *testing.T, expected []int, v *persistentvector.PersistentVector[int]) {
+	t.Helper()
+
+	assert.Equal(t, len(expected), v.Size())
+	assert.Equal(t, len(expected) == 0, v.Empty())
+
+	actual := make([]int, 0, v.Size())
+	v.ForEach(func(index int, value int) {
+		assert.Equal(t, len(actual), index)
+		actual = append(actual, value)
+	})
+	assert.Equal(t, expected, actual)
+
+	for i, value := range expected {
+		got, ok := v.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, value, got)
+	}
+}
+
+func TestPersistentVectorString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "PersistentVector\n", persistentvector.New[int]().String())
+	assert.Equal(t, "PersistentVector\n1,2,3", persistentvector.New(1, 2, 3).String())
+}
+
+func TestPersistentVectorNew(t *testing.T) {
+	t.Parallel()
+
+	// Sizes around the tail size and the capacity of each level of the trie.
+	for _, size := range []int{0, 1, 31, 32, 33, 64, 65, 1056, 1057, 32*32*32 + 33, 40000} {
+		values := rangeOf(size)
+		assertContents(t, values, persistentvector.New(values...))
+		assertContents(t, values, persistentvector.New[int]().AppendAll(values...))
+	}
+}
+
+func TestPersistentVectorGetOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	v := persistentvector.New(1, 2)
+	_, ok := v.Get(-1)
+	assert.False(t, ok)
+	_, ok = v.Get(2)
+	assert.False(t, ok)
+
+	front, ok := v.GetFront()
+	assert.True(t, ok)
+	assert.Equal(t, 1, front)
+
+	back, ok := v.GetBack()
+	assert.True(t, ok)
+	assert.Equal(t, 2, back)
+
+	_, ok = persistentvector.New[int]().GetBack()
+	assert.False(t, ok)
+}
+
+func TestPersistentVectorAppendIsPersistent(t *testing.T) {
+	t.Parallel()
+
+	base := persistentvector.New(rangeOf(32)...)
+	first := base.Append(100)
+	second := base.Append(200)
+
+	assertContents(t, rangeOf(32), base)
+	assertContents(t, append(rangeOf(32), 100), first)
+	assertContents(t, append(rangeOf(32), 200), second)
+}
+
+func TestPersistentVectorSet(t *testing.T) {
+	t.Parallel()
+
+	base := persistentvector.New(rangeOf(100)...)
+
+	// One index in the trie and one in the tail.
+	set, ok := base.Set(5, -5)
+	assert.True(t, ok)
+	set, ok = set.Set(99, -99)
+	assert.True(t, ok)
+
+	expected := rangeOf(100)
+	expected[5] = -5
+	expected[99] = -99
+	assertContents(t, expected, set)
+	assertContents(t, rangeOf(100), base)
+
+	unchanged, ok := base.Set(100, 0)
+	assert.False(t, ok)
+	assert.Same(t, base, unchanged)
+}
+
+func TestPersistentVectorPop(t *testing.T) {
+	t.Parallel()
+
+	size := 32*32 + 65
+	v := persistentvector.New(rangeOf(size)...)
+	snapshot := v
+
+	for i := size - 1; i >= 0; i-- {
+		var value int
+		var ok bool
+
+		v, value, ok = v.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, i, value)
+		assert.Equal(t, i, v.Size())
+
+		if i%100 == 0 {
+			assertContents(t, rangeOf(i), v)
+		}
+	}
+
+	_, _, ok := v.Pop()
+	assert.False(t, ok)
+	assertContents(t, rangeOf(size), snapshot)
+
+	// The vector can grow again after shrinking.
+	assertContents(t, rangeOf(1100), v.AppendAll(rangeOf(1100)...))
+}
+
+func TestPersistentVectorRandomOperations(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(3))
+	v := persistentvector.New[int]()
+	expected := []int{}
+
+	type snapshot struct {
+		v        *persistentvector.PersistentVector[int]
+		expected []int
+	}
+	snapshots := []snapshot{}
+
+	for i := 0; i < 20000; i++ {
+		switch op := rng.Intn(10); {
+		case op < 6:
+			v = v.Append(i)
+			expected = append(expected, i)
+		case op < 8 && len(expected) > 0:
+			index := rng.Intn(len(expected))
+			v, _ = v.Set(index, -i)
+			expected[index] = -i
+		case len(expected) > 0:
+			var value int
+			v, value, _ = v.Pop()
+			assert.Equal(t, expected[len(expected)-1], value)
+			expected = expected[:len(expected)-1]
+		}
+
+		if i%1000 == 0 {
+			snapshots = append(snapshots, snapshot{v: v, expected: append([]int{}, expected...)})
+		}
+	}
+
+	assertContents(t, expected, v)
+	for _, s := range snapshots {
+		assertContents(t, s.expected, s.v)
+	}
+}
+
+func TestPersistentVectorIteration(t *testing.T) {
+	t.Parallel()
+
+	v := persistentvector.New(rangeOf(70)...)
+
+	values := []int{}
+	for it, ok := v.Iterator(); ok; it, ok = it.Next() {
+		key, _ := it.Key()
+		value, _ := it.Value()
+		assert.Equal(t, key, value)
+		values = append(values, value)
+	}
+	assert.Equal(t, rangeOf(70), values)
+
+	reversed := []int{}
+	for it, ok := v.IteratorReverse(); ok; it, ok = it.Next() {
+		value, _ := it.Value()
+		reversed = append(reversed, value)
+	}
+	assert.Len(t, reversed, 70)
+	assert.Equal(t, 69, reversed[0])
+	assert.Equal(t, 0, reversed[69])
+
+	_, ok := persistentvector.New[int]().Iterator()
+	assert.False(t, ok)
+}
+
+func TestPersistentVectorEnumeration(t *testing.T) {
+	t.Parallel()
+
+	v := persistentvector.New(rangeOf(100)...)
+	assert.True(t, v.Any(func(index int, value int) bool { return value == 99 }))
+	assert.False(t, v.Any(func(index int, value int) bool { return value == 100 }))
+	assert.True(t, v.All(func(index int, value int) bool { return index == value }))
+	assert.False(t, v.All(func(index int, value int) bool { return value < 50 }))
+
+	index, value, ok := v.Find(func(index int, value int) bool { return value > 40 })
+	assert.True(t, ok)
+	assert.Equal(t, 41, index)
+	assert.Equal(t, 41, value)
+}
+
+func TestPersistentVectorConcurrentReaders(t *testing.T) {
+	t.Parallel()
+
+	base := persistentvector.New(rangeOf(1000)...)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			own := base.Append(i)
+			own, _ = own.Set(0, i)
+			back, _ := own.GetBack()
+			assert.Equal(t, i, back)
+			assert.Equal(t, 1000, base.Size())
+
+			front, _ := base.GetFront()
+			assert.Equal(t, 0, front)
+		}(i)
+	}
+	wg.Wait()
+}
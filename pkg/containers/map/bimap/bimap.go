@@ -0,0 +1,358 @@
+package bimap
+
+import (
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+)
+
+type Builder[K any, HK comparable, V any, HV comparable] struct {
+	keyHashKey   compare.HashKey[K, HK]
+	valueHashKey compare.HashKey[V, HV]
+	forceReplace bool
+	entries      []entry.Entry[K, V]
+}
+
+func NewBuilder[K any, HK comparable, V any, HV comparable](
+	keyHashKey compare.HashKey[K, HK],
+	valueHashKey compare.HashKey[V, HV],
+) *Builder[K, HK, V, HV] {
+	return &Builder[K, HK, V, HV]{
+		keyHashKey:   keyHashKey,
+		valueHashKey: valueHashKey,
+		forceReplace: false,
+		entries:      []entry.Entry[K, V]{},
+	}
+}
+
+// ForceReplace configures what happens when a value is put for a key while another key
+// already has that value. By default the put is rejected and the map is left unchanged.
+// With ForceReplace, the other key is removed so the value can be put for the new key.
+func (b *Builder[K, HK, V, HV]) ForceReplace(forceReplace bool) *Builder[K, HK, V, HV] {
+	b.forceReplace = forceReplace
+
+	return b
+}
+
+func (b *Builder[K, HK, V, HV]) Put(key K, value V) *Builder[K, HK, V, HV] {
+	b.entries = append(b.entries, entry.New(key, value))
+
+	return b
+}
+
+func (b *Builder[K, HK, V, HV]) PutAll(entries ...entry.Entry[K, V]) *Builder[K, HK, V, HV] {
+	b.entries = append(b.entries, entries...)
+
+	return b
+}
+
+func (b *Builder[K, HK, V, HV]) Build() *BiMap[K, HK, V, HV] {
+	m := &BiMap[K, HK, V, HV]{
+		keyHashKey:   b.keyHashKey,
+		valueHashKey: b.valueHashKey,
+		forceReplace: b.forceReplace,
+		forward:      make(map[HK]entry.Entry[K, V]),
+		backward:     make(map[HV]entry.Entry[V, K]),
+		inverse:      nil,
+	}
+	m.PutAll(b.entries...)
+
+	return m
+}
+
+// BiMap is a map in which every value is unique, so that it can be looked up by value as
+// well as by key.
+type BiMap[K any, HK comparable, V any, HV comparable] struct {
+	keyHashKey   compare.HashKey[K, HK]
+	valueHashKey compare.HashKey[V, HV]
+	forceReplace bool
+
+	// forward and backward are shared with the inverse view, which has them swapped,
+	// so they must only ever be modified in place.
+	forward  map[HK]entry.Entry[K, V]
+	backward map[HV]entry.Entry[V, K]
+	inverse  *BiMap[V, HV, K, HK]
+}
+
+func New[K comparable, V comparable](entries ...entry.Entry[K, V]) *BiMap[K, K, V, V] {
+	return NewBuilder[K, K, V, V](compare.IdentityHashKey[K], compare.IdentityHashKey[V]).
+		PutAll(entries...).
+		Build()
+}
+
+// Inverse returns a view of the map with the keys and values swapped. The view is backed by
+// the map, so changes to either are visible in both.
+func (m *BiMap[K, HK, V, HV]) Inverse() *BiMap[V, HV, K, HK] {
+	if m.inverse == nil {
+		m.inverse = &BiMap[V, HV, K, HK]{
+			keyHashKey:   m.valueHashKey,
+			valueHashKey: m.keyHashKey,
+			forceReplace: m.forceReplace,
+			forward:      m.backward,
+			backward:     m.forward,
+			inverse:      m,
+		}
+	}
+
+	return m.inverse
+}
+
+func (m *BiMap[K, HK, V, HV]) Empty() bool {
+	return m.Size() == 0
+}
+
+func (m *BiMap[K, HK, V, HV]) Size() int {
+	return len(m.forward)
+}
+
+func (m *BiMap[K, HK, V, HV]) Clear() {
+	for k := range m.forward {
+		delete(m.forward, k)
+	}
+
+	for k := range m.backward {
+		delete(m.backward, k)
+	}
+}
+
+func (m *BiMap[K, HK, V, HV]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("BiMap\n")
+
+	strs := []string{}
+	for _, entry := range m.forward {
+		strs = append(strs, entry.String())
+	}
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (m *BiMap[K, HK, V, HV]) ForEach(op enumerable.Op[K, V]) {
+	for _, entry := range m.forward {
+		op(entry.Key(), entry.Value())
+	}
+}
+
+func (m *BiMap[K, HK, V, HV]) Any(predicate enumerable.Predicate[K, V]) bool {
+	for _, entry := range m.forward {
+		if predicate(entry.Key(), entry.Value()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *BiMap[K, HK, V, HV]) All(predicate enumerable.Predicate[K, V]) bool {
+	for _, entry := range m.forward {
+		if !predicate(entry.Key(), entry.Value()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *BiMap[K, HK, V, HV]) Find(predicate enumerable.Predicate[K, V]) (K, V, bool) {
+	for _, entry := range m.forward {
+		if predicate(entry.Key(), entry.Value()) {
+			return entry.Key(), entry.Value(), true
+		}
+	}
+
+	return *new(K), *new(V), false
+}
+
+func (m *BiMap[K, HK, V, HV]) Keys() []K {
+	keys := make([]K, 0, len(m.forward))
+	for _, entry := range m.forward {
+		keys = append(keys, entry.Key())
+	}
+
+	return keys
+}
+
+func (m *BiMap[K, HK, V, HV]) Values() []V {
+	values := make([]V, 0, len(m.forward))
+	for _, entry := range m.forward {
+		values = append(values, entry.Value())
+	}
+
+	return values
+}
+
+func (m *BiMap[K, HK, V, HV]) Entries() []entry.Entry[K, V] {
+	entries := make([]entry.Entry[K, V], 0, len(m.forward))
+	for _, entry := range m.forward {
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func (m *BiMap[K, HK, V, HV]) Get(key K) (V, bool) {
+	entry, ok := m.forward[m.keyHashKey(key)]
+	if !ok {
+		return *new(V), false
+	}
+
+	return entry.Value(), true
+}
+
+func (m *BiMap[K, HK, V, HV]) GetOrDefault(key K, defaultValue V) V {
+	if value, ok := m.Get(key); ok {
+		return value
+	}
+
+	return defaultValue
+}
+
+// GetKey returns the key that has the value.
+func (m *BiMap[K, HK, V, HV]) GetKey(value V) (K, bool) {
+	entry, ok := m.backward[m.valueHashKey(value)]
+	if !ok {
+		return *new(K), false
+	}
+
+	return entry.Value(), true
+}
+
+// Put puts the value for the key. If another key already has the value, the put is rejected
+// unless the map was built with ForceReplace.
+func (m *BiMap[K, HK, V, HV]) Put(key K, value V) {
+	m.TryPut(key, value)
+}
+
+// TryPut is the same as Put, but returns false if the put was rejected because another key
+// already has the value.
+func (m *BiMap[K, HK, V, HV]) TryPut(key K, value V) bool {
+	hashedKey := m.keyHashKey(key)
+	hashedValue := m.valueHashKey(value)
+
+	if existing, ok := m.backward[hashedValue]; ok {
+		existingKey := m.keyHashKey(existing.Value())
+		if existingKey != hashedKey {
+			if !m.forceReplace {
+				return false
+			}
+
+			delete(m.forward, existingKey)
+		}
+	}
+
+	if existing, ok := m.forward[hashedKey]; ok {
+		delete(m.backward, m.valueHashKey(existing.Value()))
+	}
+
+	m.forward[hashedKey] = entry.New(key, value)
+	m.backward[hashedValue] = entry.New(value, key)
+
+	return true
+}
+
+func (m *BiMap[K, HK, V, HV]) PutAll(entries ...entry.Entry[K, V]) {
+	for _, entry := range entries {
+		m.Put(entry.Key(), entry.Value())
+	}
+}
+
+func (m *BiMap[K, HK, V, HV]) PutAllFrom(other mapp.ReadOnlyMap[K, V]) {
+	m.PutAll(other.Entries()...)
+}
+
+// Swap puts the value for the key and returns the value it replaced. If the put is rejected
+// because another key has the value, the map is unchanged.
+func (m *BiMap[K, HK, V, HV]) Swap(key K, value V) (V, bool) {
+	old, existed := m.Get(key)
+	m.TryPut(key, value)
+
+	return old, existed
+}
+
+// Replace puts the value for the key only if the key is already in the map.
+// Returns false if the key is not in the map or the put is rejected.
+func (m *BiMap[K, HK, V, HV]) Replace(key K, value V) bool {
+	return m.ContainsKey(key) && m.TryPut(key, value)
+}
+
+func (m *BiMap[K, HK, V, HV]) RemoveKey(key K) bool {
+	hashedKey := m.keyHashKey(key)
+
+	existing, ok := m.forward[hashedKey]
+	if !ok {
+		return false
+	}
+
+	delete(m.forward, hashedKey)
+	delete(m.backward, m.valueHashKey(existing.Value()))
+
+	return true
+}
+
+func (m *BiMap[K, HK, V, HV]) RemoveAllKeys(keys ...K) int {
+	removed := 0
+
+	for _, key := range keys {
+		if m.RemoveKey(key) {
+			removed++
+		}
+	}
+
+	return removed
+}
+
+func (m *BiMap[K, HK, V, HV]) RemoveIf(predicate enumerable.Predicate[K, V]) int {
+	removed := 0
+
+	for hashedKey, entry := range m.forward {
+		if predicate(entry.Key(), entry.Value()) {
+			delete(m.forward, hashedKey)
+			delete(m.backward, m.valueHashKey(entry.Value()))
+			removed++
+		}
+	}
+
+	return removed
+}
+
+// RemoveValue removes the key that has the value.
+func (m *BiMap[K, HK, V, HV]) RemoveValue(value V) bool {
+	return m.Inverse().RemoveKey(value)
+}
+
+func (m *BiMap[K, HK, V, HV]) ContainsKey(key K) bool {
+	_, ok := m.forward[m.keyHashKey(key)]
+
+	return ok
+}
+
+func (m *BiMap[K, HK, V, HV]) ContainsAllKeys(keys ...K) bool {
+	for _, key := range keys {
+		if !m.ContainsKey(key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *BiMap[K, HK, V, HV]) ContainsAnyKey(keys ...K) bool {
+	for _, key := range keys {
+		if m.ContainsKey(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *BiMap[K, HK, V, HV]) ContainsValue(value V) bool {
+	_, ok := m.backward[m.valueHashKey(value)]
+
+	return ok
+}
@@ -0,0 +1,196 @@
+package bimap_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/bimap"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that BiMap implements Map.
+var _ mapp.Map[string, int] = &bimap.BiMap[string, string, int, int]{}
+
+func TestBiMapString(t *testing.T) {
+	t.Parallel()
+
+	m := bimap.New[string, int]()
+	assert.Equal(t, "BiMap\n", m.String())
+
+	m.Put("a", 1)
+	assert.Equal(t, "BiMap\nEntry{Key:a, Value:1}", m.String())
+	assert.Equal(t, "BiMap\nEntry{Key:1, Value:a}", m.Inverse().String())
+}
+
+func TestBiMapGetAndGetKey(t *testing.T) {
+	t.Parallel()
+
+	m := bimap.New(entry.New("one", 1), entry.New("two", 2))
+	assert.Equal(t, 2, m.Size())
+
+	value, ok := m.Get("one")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	key, ok := m.GetKey(2)
+	assert.True(t, ok)
+	assert.Equal(t, "two", key)
+
+	_, ok = m.GetKey(3)
+	assert.False(t, ok)
+	assert.True(t, m.ContainsValue(1))
+	assert.False(t, m.ContainsValue(3))
+}
+
+func TestBiMapPutExistingValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		forceReplace bool
+		expected     map[string]int
+	}{
+		{
+			name:         "rejected by default",
+			forceReplace: false,
+			expected:     map[string]int{"one": 1, "two": 2},
+		},
+		{
+			name:         "force replace",
+			forceReplace: true,
+			expected:     map[string]int{"uno": 1, "two": 2},
+		},
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := bimap.NewBuilder[string, string, int, int](strings.ToLower, func(value int) int { return value }).
+				ForceReplace(testCase.forceReplace).
+				Put("one", 1).
+				Put("two", 2).
+				Build()
+
+			assert.Equal(t, testCase.forceReplace, m.TryPut("uno", 1))
+
+			// Putting the same pair again is never a conflict.
+			assert.True(t, m.TryPut("two", 2))
+			assert.True(t, m.TryPut("TWO", 2))
+
+			actual := map[string]int{}
+			m.ForEach(func(key string, value int) {
+				actual[strings.ToLower(key)] = value
+			})
+			assert.Equal(t, testCase.expected, actual)
+			assert.Equal(t, len(testCase.expected), m.Inverse().Size())
+		})
+	}
+}
+
+func TestBiMapPutExistingKey(t *testing.T) {
+	t.Parallel()
+
+	m := bimap.New(entry.New("a", 1))
+	m.Put("a", 2)
+
+	assert.Equal(t, 1, m.Size())
+	assert.False(t, m.ContainsValue(1))
+	key, ok := m.GetKey(2)
+	assert.True(t, ok)
+	assert.Equal(t, "a", key)
+
+	// The old value is free to be used by another key.
+	assert.True(t, m.TryPut("b", 1))
+}
+
+func TestBiMapInverseIsLive(t *testing.T) {
+	t.Parallel()
+
+	m := bimap.New(entry.New("a", 1), entry.New("b", 2))
+	inverse := m.Inverse()
+	assert.Same(t, m, inverse.Inverse())
+
+	key, ok := inverse.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "a", key)
+
+	m.Put("c", 3)
+	assert.True(t, inverse.ContainsKey(3))
+
+	inverse.Put(4, "d")
+	value, ok := m.Get("d")
+	assert.True(t, ok)
+	assert.Equal(t, 4, value)
+
+	assert.True(t, inverse.RemoveKey(1))
+	assert.False(t, m.ContainsKey("a"))
+	assert.True(t, m.RemoveValue(2))
+	assert.False(t, inverse.ContainsKey(2))
+
+	keys := []int{}
+	inverse.ForEach(func(key int, value string) {
+		keys = append(keys, key)
+	})
+	sort.Ints(keys)
+	assert.Equal(t, []int{3, 4}, keys)
+
+	inverse.Clear()
+	assert.True(t, m.Empty())
+	assert.True(t, inverse.Empty())
+}
+
+func TestBiMapRemove(t *testing.T) {
+	t.Parallel()
+
+	m := bimap.New(entry.New("a", 1), entry.New("b", 2), entry.New("c", 3))
+	assert.Equal(t, 2, m.RemoveAllKeys("a", "b", "z"))
+	assert.False(t, m.RemoveKey("a"))
+	assert.False(t, m.RemoveValue(1))
+	assert.False(t, m.ContainsAnyKey("a", "b"))
+	assert.True(t, m.ContainsAllKeys("c"))
+	assert.Equal(t, 1, m.Inverse().Size())
+}
+
+func TestBiMapEnumeration(t *testing.T) {
+	t.Parallel()
+
+	m := bimap.New(entry.New("a", 1), entry.New("b", 2))
+	assert.True(t, m.Any(func(key string, value int) bool { return value == 2 }))
+	assert.False(t, m.Any(func(key string, value int) bool { return value == 3 }))
+	assert.True(t, m.All(func(key string, value int) bool { return value > 0 }))
+	assert.False(t, m.All(func(key string, value int) bool { return key == "a" }))
+
+	key, value, ok := m.Inverse().Find(func(key int, value string) bool { return value == "b" })
+	assert.True(t, ok)
+	assert.Equal(t, 2, key)
+	assert.Equal(t, "b", value)
+
+	_, _, ok = m.Find(func(key string, value int) bool { return value == 3 })
+	assert.False(t, ok)
+}
+
+func TestBiMapSwapAndReplaceExistingValue(t *testing.T) {
+	t.Parallel()
+
+	m := bimap.New(entry.New("a", 1), entry.New("b", 2))
+
+	old, existed := m.Swap("a", 3)
+	assert.True(t, existed)
+	assert.Equal(t, 1, old)
+	assert.True(t, m.ContainsValue(3))
+	assert.False(t, m.ContainsValue(1))
+
+	// Both are rejected because "b" already has the value.
+	m.Swap("a", 2)
+	assert.Equal(t, 3, m.GetOrDefault("a", 0))
+	assert.False(t, m.Replace("a", 2))
+	assert.Equal(t, 3, m.GetOrDefault("a", 0))
+
+	assert.Equal(t, 1, m.RemoveIf(func(key string, value int) bool { return value == 2 }))
+	assert.False(t, m.Inverse().ContainsKey(2))
+}
@@ -0,0 +1,772 @@
+package btreemap
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/iterator"
+	"golang.org/x/exp/constraints"
+)
+
+const (
+	// DefaultDegree gives nodes of up to 63 keys, which suits small keys such as numbers.
+	DefaultDegree = 32
+	minDegree     = 2
+)
+
+// Visitor is called for the entries visited by Ascend, Descend and AscendRange.
+// Return false to stop visiting.
+type Visitor[K any, V any] func(key K, value V) bool
+
+type Builder[K any, V any] struct {
+	comparator compare.Comparator[K]
+	degree     int
+	entries    []entry.Entry[K, V]
+}
+
+func NewBuilder[K any, V any](comparator compare.Comparator[K]) *Builder[K, V] {
+	return &Builder[K, V]{
+		comparator: comparator,
+		degree:     DefaultDegree,
+		entries:    nil,
+	}
+}
+
+// Degree sets the minimum degree of the tree, so that each node other than the root holds
+// between degree-1 and 2*degree-1 keys. Larger nodes make the tree shallower at the cost of
+// moving more keys on each change. Degrees below 2 are treated as 2.
+func (b *Builder[K, V]) Degree(degree int) *Builder[K, V] {
+	b.degree = max(degree, minDegree)
+
+	return b
+}
+
+func (b *Builder[K, V]) Put(key K, value V) *Builder[K, V] {
+	b.entries = append(b.entries, entry.New(key, value))
+
+	return b
+}
+
+func (b *Builder[K, V]) PutAll(entries ...entry.Entry[K, V]) *Builder[K, V] {
+	b.entries = append(b.entries, entries...)
+
+	return b
+}
+
+// Build builds the map from the entries, keeping the last value put for each key.
+// Rather than inserting the entries one at a time, it sorts them and builds the tree from the
+// bottom up with evenly filled nodes. If the entries are already sorted by key and unique,
+// this takes linear time.
+func (b *Builder[K, V]) Build() *BTreeMap[K, V] {
+	m := &BTreeMap[K, V]{
+		comparator: b.comparator,
+		degree:     b.degree,
+		root:       nil,
+		size:       0,
+		owner:      &editToken{},
+	}
+
+	entries := b.sortedEntries()
+	if len(entries) == 0 {
+		return m
+	}
+
+	// Find the height of the shallowest tree that can hold the entries.
+	capacities := []int{1}
+	for capacities[len(capacities)-1] <= len(entries) {
+		capacities = append(capacities, capacities[len(capacities)-1]*2*b.degree)
+	}
+
+	m.root = m.load(entries, capacities, len(capacities)-1, true)
+	m.size = len(entries)
+
+	return m
+}
+
+// sortedEntries returns the entries sorted by key without duplicate keys, keeping the last
+// value for each key.
+func (b *Builder[K, V]) sortedEntries() []entry.Entry[K, V] {
+	less := func(left entry.Entry[K, V], right entry.Entry[K, V]) bool {
+		return b.comparator(left.Key(), right.Key()) == compare.PriorityRightHigher
+	}
+
+	sorted := true
+	for i := 1; i < len(b.entries) && sorted; i++ {
+		sorted = less(b.entries[i-1], b.entries[i])
+	}
+
+	if sorted {
+		return b.entries
+	}
+
+	entries := append([]entry.Entry[K, V]{}, b.entries...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return less(entries[i], entries[j])
+	})
+
+	unique := entries[:0]
+	for _, entry := range entries {
+		if len(unique) > 0 && !less(unique[len(unique)-1], entry) {
+			unique[len(unique)-1] = entry
+		} else {
+			unique = append(unique, entry)
+		}
+	}
+
+	return unique
+}
+
+// BTreeMap is a sorted map implemented as a B-tree. Each node holds many keys in a slice, so a
+// lookup follows far fewer pointers than in a binary search tree and mostly reads memory that
+// is next to what it has just read. Keys are enumerated in order.
+// Cloning the map is cheap, since the clone shares all of the nodes with the map until either
+// of them modifies a node, at which point the one modifying it makes its own copy.
+type BTreeMap[K any, V any] struct {
+	comparator compare.Comparator[K]
+	degree     int
+	root       *node[K, V]
+	size       int
+	owner      *editToken
+}
+
+func New[K constraints.Ordered, V any](entries ...entry.Entry[K, V]) *BTreeMap[K, V] {
+	return NewBuilder[K, V](compare.OrderedComparator[K]).PutAll(entries...).Build()
+}
+
+// Clone returns a copy of the map in constant time. Cloning does not modify the map, so a map
+// may be cloned concurrently with reading or cloning it.
+func (m *BTreeMap[K, V]) Clone() *BTreeMap[K, V] {
+	// Neither map may modify the nodes they now share. The clone gets a new token, and the map
+	// gets one before its next modification.
+	m.owner.shared.Store(true)
+
+	return &BTreeMap[K, V]{
+		comparator: m.comparator,
+		degree:     m.degree,
+		root:       m.root,
+		size:       m.size,
+		owner:      &editToken{},
+	}
+}
+
+func (m *BTreeMap[K, V]) Empty() bool {
+	return m.Size() == 0
+}
+
+func (m *BTreeMap[K, V]) Size() int {
+	return m.size
+}
+
+func (m *BTreeMap[K, V]) Clear() {
+	m.root = nil
+	m.size = 0
+}
+
+func (m *BTreeMap[K, V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("BTreeMap\n")
+
+	strs := make([]string, 0, m.Size())
+	m.ForEach(func(key K, value V) {
+		strs = append(strs, entry.NewRef(key, value).String())
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+// ForEach visits the entries in order of their keys.
+func (m *BTreeMap[K, V]) ForEach(op enumerable.Op[K, V]) {
+	m.Ascend(func(key K, value V) bool {
+		op(key, value)
+
+		return true
+	})
+}
+
+func (m *BTreeMap[K, V]) Any(predicate enumerable.Predicate[K, V]) bool {
+	_, _, found := m.Find(predicate)
+
+	return found
+}
+
+func (m *BTreeMap[K, V]) All(predicate enumerable.Predicate[K, V]) bool {
+	return m.root == nil || m.root.walk(predicate)
+}
+
+// Find returns the first entry in order of the keys that satisfies the predicate.
+func (m *BTreeMap[K, V]) Find(predicate enumerable.Predicate[K, V]) (K, V, bool) {
+	foundKey, foundValue, found := *new(K), *new(V), false
+
+	m.Ascend(func(key K, value V) bool {
+		if predicate(key, value) {
+			foundKey, foundValue, found = key, value, true
+
+			return false
+		}
+
+		return true
+	})
+
+	return foundKey, foundValue, found
+}
+
+// Iterator returns an iterator over the entries in order of their keys.
+func (m *BTreeMap[K, V]) Iterator() (iterator.ForwardIterator[K, V], bool) {
+	if m.Empty() {
+		return nil, false
+	}
+
+	return newIterator(m.root, nil), true
+}
+
+// Ascend visits the entries in order of their keys.
+func (m *BTreeMap[K, V]) Ascend(visit Visitor[K, V]) {
+	if m.root != nil {
+		m.root.walk(visit)
+	}
+}
+
+// Descend visits the entries in reverse order of their keys.
+func (m *BTreeMap[K, V]) Descend(visit Visitor[K, V]) {
+	if m.root != nil {
+		m.root.walkReverse(visit)
+	}
+}
+
+// AscendRange visits the entries with keys in [start, end) in order of their keys.
+func (m *BTreeMap[K, V]) AscendRange(start K, end K, visit Visitor[K, V]) {
+	if m.root != nil {
+		m.ascendRange(m.root, start, end, visit)
+	}
+}
+
+func (m *BTreeMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Size())
+	m.ForEach(func(key K, _ V) {
+		keys = append(keys, key)
+	})
+
+	return keys
+}
+
+func (m *BTreeMap[K, V]) Values() []V {
+	values := make([]V, 0, m.Size())
+	m.ForEach(func(_ K, value V) {
+		values = append(values, value)
+	})
+
+	return values
+}
+
+func (m *BTreeMap[K, V]) Entries() []entry.Entry[K, V] {
+	entries := make([]entry.Entry[K, V], 0, m.Size())
+	m.ForEach(func(key K, value V) {
+		entries = append(entries, entry.New(key, value))
+	})
+
+	return entries
+}
+
+func (m *BTreeMap[K, V]) Get(key K) (V, bool) {
+	for n := m.root; n != nil; {
+		i, found := n.search(m.comparator, key)
+		if found {
+			return n.values[i], true
+		}
+
+		if n.leaf() {
+			break
+		}
+
+		n = n.children[i]
+	}
+
+	return *new(V), false
+}
+
+func (m *BTreeMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, ok := m.Get(key); ok {
+		return value
+	}
+
+	return defaultValue
+}
+
+// Min returns the entry with the smallest key.
+func (m *BTreeMap[K, V]) Min() (K, V, bool) {
+	if m.root == nil {
+		return *new(K), *new(V), false
+	}
+
+	n := m.root
+	for !n.leaf() {
+		n = n.children[0]
+	}
+
+	return n.keys[0], n.values[0], true
+}
+
+// Max returns the entry with the largest key.
+func (m *BTreeMap[K, V]) Max() (K, V, bool) {
+	if m.root == nil {
+		return *new(K), *new(V), false
+	}
+
+	n := m.root
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+
+	last := len(n.keys) - 1
+
+	return n.keys[last], n.values[last], true
+}
+
+func (m *BTreeMap[K, V]) Put(key K, value V) {
+	m.Swap(key, value)
+}
+
+func (m *BTreeMap[K, V]) PutAll(entries ...entry.Entry[K, V]) {
+	for _, entry := range entries {
+		m.Put(entry.Key(), entry.Value())
+	}
+}
+
+func (m *BTreeMap[K, V]) PutAllFrom(other mapp.ReadOnlyMap[K, V]) {
+	m.PutAll(other.Entries()...)
+}
+
+func (m *BTreeMap[K, V]) Swap(key K, value V) (V, bool) {
+	m.prepareEdit()
+
+	if m.root == nil {
+		m.root = m.newNode()
+	}
+
+	m.root = m.root.editable(m.owner)
+
+	// Split a full root before descending, which is the only way the tree grows taller.
+	if len(m.root.keys) >= m.maxKeys() {
+		splitKey, splitValue, next := m.root.split(m.maxKeys()/2, m.owner)
+
+		root := m.newNode()
+		root.keys = append(root.keys, splitKey)
+		root.values = append(root.values, splitValue)
+		root.children = append(root.children, m.root, next)
+		m.root = root
+	}
+
+	old, existed := m.insert(m.root, key, value)
+	if !existed {
+		m.size++
+	}
+
+	return old, existed
+}
+
+func (m *BTreeMap[K, V]) Replace(key K, value V) bool {
+	if !m.ContainsKey(key) {
+		return false
+	}
+
+	m.Put(key, value)
+
+	return true
+}
+
+func (m *BTreeMap[K, V]) RemoveKey(key K) bool {
+	if !m.ContainsKey(key) {
+		return false
+	}
+
+	m.prepareEdit()
+	m.root = m.root.editable(m.owner)
+	m.remove(m.root, key, removeKey)
+	m.size--
+
+	// Drop an empty root, which is the only way the tree grows shorter.
+	if len(m.root.keys) == 0 {
+		if m.root.leaf() {
+			m.root = nil
+		} else {
+			m.root = m.root.children[0]
+		}
+	}
+
+	return true
+}
+
+func (m *BTreeMap[K, V]) RemoveAllKeys(keys ...K) int {
+	removed := 0
+
+	for _, key := range keys {
+		if m.RemoveKey(key) {
+			removed++
+		}
+	}
+
+	return removed
+}
+
+func (m *BTreeMap[K, V]) RemoveIf(predicate enumerable.Predicate[K, V]) int {
+	keys := []K{}
+	m.ForEach(func(key K, value V) {
+		if predicate(key, value) {
+			keys = append(keys, key)
+		}
+	})
+
+	return m.RemoveAllKeys(keys...)
+}
+
+func (m *BTreeMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.Get(key)
+
+	return ok
+}
+
+func (m *BTreeMap[K, V]) ContainsAllKeys(keys ...K) bool {
+	for _, key := range keys {
+		if !m.ContainsKey(key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *BTreeMap[K, V]) ContainsAnyKey(keys ...K) bool {
+	for _, key := range keys {
+		if m.ContainsKey(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *BTreeMap[K, V]) maxKeys() int {
+	return 2*m.degree - 1
+}
+
+func (m *BTreeMap[K, V]) minKeys() int {
+	return m.degree - 1
+}
+
+// prepareEdit gives the map a new token if its nodes have been shared with a clone,
+// which must happen before the map modifies or creates any node.
+func (m *BTreeMap[K, V]) prepareEdit() {
+	if m.owner.shared.Load() {
+		m.owner = &editToken{}
+	}
+}
+
+func (m *BTreeMap[K, V]) newNode() *node[K, V] {
+	return &node[K, V]{
+		keys:     make([]K, 0, m.maxKeys()),
+		values:   make([]V, 0, m.maxKeys()),
+		children: nil,
+		owner:    m.owner,
+	}
+}
+
+// insert puts the key below the node, which must be owned by the map and not be full.
+// Full children are split before descending into them, so there is always room for a key
+// moved up from a split.
+func (m *BTreeMap[K, V]) insert(n *node[K, V], key K, value V) (V, bool) {
+	i, found := n.search(m.comparator, key)
+	if found {
+		old := n.values[i]
+		n.values[i] = value
+
+		return old, true
+	}
+
+	if n.leaf() {
+		n.keys = insertAt(n.keys, i, key)
+		n.values = insertAt(n.values, i, value)
+
+		return *new(V), false
+	}
+
+	if len(n.children[i].keys) >= m.maxKeys() {
+		splitKey, splitValue, next := n.editableChild(i, m.owner).split(m.maxKeys()/2, m.owner)
+		n.keys = insertAt(n.keys, i, splitKey)
+		n.values = insertAt(n.values, i, splitValue)
+		n.children = insertAt(n.children, i+1, next)
+
+		switch m.comparator(key, splitKey) {
+		case compare.PriorityEqual:
+			old := n.values[i]
+			n.values[i] = value
+
+			return old, true
+		case compare.PriorityLeftHigher:
+			i++
+		case compare.PriorityRightHigher:
+		}
+	}
+
+	return m.insert(n.editableChild(i, m.owner), key, value)
+}
+
+type removal int
+
+const (
+	removeKey removal = iota
+	removeMax
+)
+
+// remove removes the key, or the largest key for removeMax, from below the node, which must be
+// owned by the map and hold more than the minimum number of keys unless it is the root.
+// Children with the minimum number of keys are grown before descending into them, so there is
+// always a key to spare when one is removed.
+func (m *BTreeMap[K, V]) remove(n *node[K, V], key K, kind removal) (K, V) {
+	var (
+		i     int
+		found bool
+	)
+
+	switch kind {
+	case removeMax:
+		i = len(n.keys)
+		if n.leaf() {
+			i--
+			found = true
+		}
+	case removeKey:
+		i, found = n.search(m.comparator, key)
+	}
+
+	if n.leaf() {
+		var (
+			removedKey   K
+			removedValue V
+		)
+		n.keys, removedKey = removeAt(n.keys, i)
+		n.values, removedValue = removeAt(n.values, i)
+
+		return removedKey, removedValue
+	}
+
+	if len(n.children[i].keys) <= m.minKeys() {
+		m.growChild(n, i)
+
+		// Growing the child moves keys around, so search the node again.
+		return m.remove(n, key, kind)
+	}
+
+	child := n.editableChild(i, m.owner)
+
+	if found {
+		// Replace the key with its predecessor, the largest key in the child before it.
+		removedKey, removedValue := n.keys[i], n.values[i]
+		n.keys[i], n.values[i] = m.remove(child, key, removeMax)
+
+		return removedKey, removedValue
+	}
+
+	return m.remove(child, key, kind)
+}
+
+// growChild gives the child at the index an extra key, by taking one from a sibling through the
+// node if the sibling can spare one, or otherwise by merging the child with a sibling.
+func (m *BTreeMap[K, V]) growChild(n *node[K, V], i int) {
+	switch {
+	case i > 0 && len(n.children[i-1].keys) > m.minKeys():
+		child := n.editableChild(i, m.owner)
+		left := n.editableChild(i-1, m.owner)
+
+		var (
+			stolenKey   K
+			stolenValue V
+		)
+		left.keys, stolenKey = removeAt(left.keys, len(left.keys)-1)
+		left.values, stolenValue = removeAt(left.values, len(left.values)-1)
+
+		child.keys = insertAt(child.keys, 0, n.keys[i-1])
+		child.values = insertAt(child.values, 0, n.values[i-1])
+		n.keys[i-1], n.values[i-1] = stolenKey, stolenValue
+
+		if !left.leaf() {
+			var stolenChild *node[K, V]
+			left.children, stolenChild = removeAt(left.children, len(left.children)-1)
+			child.children = insertAt(child.children, 0, stolenChild)
+		}
+	case i < len(n.keys) && len(n.children[i+1].keys) > m.minKeys():
+		child := n.editableChild(i, m.owner)
+		right := n.editableChild(i+1, m.owner)
+
+		var (
+			stolenKey   K
+			stolenValue V
+		)
+		right.keys, stolenKey = removeAt(right.keys, 0)
+		right.values, stolenValue = removeAt(right.values, 0)
+
+		child.keys = append(child.keys, n.keys[i])
+		child.values = append(child.values, n.values[i])
+		n.keys[i], n.values[i] = stolenKey, stolenValue
+
+		if !right.leaf() {
+			var stolenChild *node[K, V]
+			right.children, stolenChild = removeAt(right.children, 0)
+			child.children = append(child.children, stolenChild)
+		}
+	default:
+		// Merge the child with its right sibling, or its left sibling if it is the last child.
+		if i >= len(n.keys) {
+			i--
+		}
+
+		child := n.editableChild(i, m.owner)
+
+		var (
+			mergeKey   K
+			mergeValue V
+			mergeChild *node[K, V]
+		)
+		n.keys, mergeKey = removeAt(n.keys, i)
+		n.values, mergeValue = removeAt(n.values, i)
+		n.children, mergeChild = removeAt(n.children, i+1)
+
+		child.keys = append(append(child.keys, mergeKey), mergeChild.keys...)
+		child.values = append(append(child.values, mergeValue), mergeChild.values...)
+		child.children = append(child.children, mergeChild.children...)
+	}
+}
+
+// ascendRange visits the entries below the node with keys in [start, end) in order, stopping
+// early if the visitor returns false or a key reaches the end.
+func (m *BTreeMap[K, V]) ascendRange(n *node[K, V], start K, end K, visit Visitor[K, V]) bool {
+	i, _ := n.search(m.comparator, start)
+
+	for ; i <= len(n.keys); i++ {
+		if !n.leaf() && !m.ascendRange(n.children[i], start, end, visit) {
+			return false
+		}
+
+		if i == len(n.keys) {
+			break
+		}
+
+		if m.comparator(n.keys[i], end) != compare.PriorityRightHigher || !visit(n.keys[i], n.values[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// load builds a subtree of the height from the sorted entries, splitting them as evenly as
+// possible between the children. capacities[h] is one more than the number of keys that a
+// subtree of height h can hold.
+func (m *BTreeMap[K, V]) load(entries []entry.Entry[K, V], capacities []int, height int, isRoot bool) *node[K, V] {
+	n := m.newNode()
+
+	if height == 1 {
+		for _, entry := range entries {
+			n.keys = append(n.keys, entry.Key())
+			n.values = append(n.values, entry.Value())
+		}
+
+		return n
+	}
+
+	// Use as few children as can hold the entries, but at least as many as a node must have.
+	// Then each child gets at least as many entries as a subtree of its height must have.
+	minChildren := minDegree
+	if !isRoot {
+		minChildren = m.degree
+	}
+
+	children := max((len(entries)+capacities[height-1])/capacities[height-1], minChildren)
+	perChild := (len(entries) - (children - 1)) / children
+	extra := (len(entries) - (children - 1)) % children
+
+	n.children = make([]*node[K, V], 0, 2*m.degree)
+
+	start := 0
+	for c := 0; c < children; c++ {
+		end := start + perChild
+		if c < extra {
+			end++
+		}
+
+		n.children = append(n.children, m.load(entries[start:end], capacities, height-1, false))
+
+		if c < children-1 {
+			n.keys = append(n.keys, entries[end].Key())
+			n.values = append(n.values, entries[end].Value())
+		}
+
+		start = end + 1
+	}
+
+	return n
+}
+
+// frame is an immutable stack of the keys an iterator has yet to visit. A frame refers to
+// the key at index i of the node, which is visited after the child before it.
+type frame[K any, V any] struct {
+	n    *node[K, V]
+	i    int
+	next *frame[K, V]
+}
+
+type bTreeMapIterator[K any, V any] struct {
+	current *frame[K, V]
+}
+
+// newIterator returns an iterator at the smallest key below the node, followed by the keys of
+// the stack.
+func newIterator[K any, V any](n *node[K, V], stack *frame[K, V]) *bTreeMapIterator[K, V] {
+	for ; !n.leaf(); n = n.children[0] {
+		stack = &frame[K, V]{n: n, i: 0, next: stack}
+	}
+
+	return &bTreeMapIterator[K, V]{
+		current: &frame[K, V]{n: n, i: 0, next: stack},
+	}
+}
+
+func (it *bTreeMapIterator[K, V]) Key() (K, bool) {
+	return it.current.n.keys[it.current.i], true
+}
+
+func (it *bTreeMapIterator[K, V]) Value() (V, bool) {
+	return it.current.n.values[it.current.i], true
+}
+
+func (it *bTreeMapIterator[K, V]) Next() (iterator.ForwardIterator[K, V], bool) {
+	if !it.HasNext() {
+		return nil, false
+	}
+
+	n, i, stack := it.current.n, it.current.i, it.current.next
+
+	if !n.leaf() {
+		// Visit the child after the key, then the next key of the node if it has one.
+		if i+1 < len(n.keys) {
+			stack = &frame[K, V]{n: n, i: i + 1, next: stack}
+		}
+
+		return newIterator(n.children[i+1], stack), true
+	}
+
+	if i+1 < len(n.keys) {
+		return &bTreeMapIterator[K, V]{current: &frame[K, V]{n: n, i: i + 1, next: stack}}, true
+	}
+
+	return &bTreeMapIterator[K, V]{current: stack}, true
+}
+
+func (it *bTreeMapIterator[K, V]) HasNext() bool {
+	return !it.current.n.leaf() || it.current.i+1 < len(it.current.n.keys) || it.current.next != nil
+}
@@ -0,0 +1,378 @@
+package btreemap_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/btreemap"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that BTreeMap implements Map.
+var _ mapp.Map[string, int] = btreemap.New[string, int]()
+
+func sequence(start int, end int) []int {
+	values := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		values = append(values, i)
+	}
+
+	return values
+}
+
+func TestBTreeMapString(t *testing.T) {
+	t.Parallel()
+
+	m := btreemap.New[string, int]()
+	assert.Equal(t, "BTreeMap\n", m.String())
+
+	m.Put("b", 2)
+	m.Put("a", 1)
+	assert.Equal(t, "BTreeMap\nEntry{Key:a, Value:1},Entry{Key:b, Value:2}", m.String())
+}
+
+func TestBTreeMapPutGetRemove(t *testing.T) {
+	t.Parallel()
+
+	m := btreemap.NewBuilder[string, int](compare.OrderedComparator[string]).
+		Degree(2).
+		Put("b", 2).
+		PutAll(entry.New("d", 4), entry.New("a", 1)).
+		Build()
+
+	old, existed := m.Swap("b", 20)
+	assert.True(t, existed)
+	assert.Equal(t, 2, old)
+
+	_, existed = m.Swap("c", 3)
+	assert.False(t, existed)
+	assert.Equal(t, 4, m.Size())
+
+	assert.True(t, m.Replace("d", 40))
+	assert.False(t, m.Replace("e", 50))
+	assert.Equal(t, 40, m.GetOrDefault("d", 0))
+	assert.True(t, m.ContainsAllKeys("a", "b", "c", "d"))
+	assert.False(t, m.ContainsAnyKey("e", "f"))
+
+	assert.True(t, m.RemoveKey("c"))
+	assert.False(t, m.RemoveKey("c"))
+	assert.Equal(t, 1, m.RemoveAllKeys("a", "e"))
+	assert.Equal(t, []string{"b", "d"}, m.Keys())
+	assert.Equal(t, []int{20, 40}, m.Values())
+
+	m.PutAllFrom(btreemap.New(entry.New("a", 1), entry.New("z", 26)))
+	assert.Equal(t, 2, m.RemoveIf(func(_ string, value int) bool { return value > 25 }))
+	assert.Equal(t, []entry.Entry[string, int]{entry.New("a", 1), entry.New("b", 20)}, m.Entries())
+
+	m.Clear()
+	assert.True(t, m.Empty())
+	assert.False(t, m.RemoveKey("a"))
+
+	_, _, found := m.Min()
+	assert.False(t, found)
+}
+
+func TestBTreeMapBuild(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		entries []entry.Entry[int, int]
+	}{
+		{name: "no entries", entries: nil},
+		{name: "one entry", entries: []entry.Entry[int, int]{entry.New(1, 1)}},
+		{name: "sorted entries", entries: nil},
+		{name: "unsorted entries with duplicates", entries: nil},
+	}
+
+	for _, key := range sequence(0, 1000) {
+		tests[2].entries = append(tests[2].entries, entry.New(key, key))
+	}
+
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 1000; i++ {
+		tests[3].entries = append(tests[3].entries, entry.New(rng.Intn(300), i))
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+
+		for _, degree := range []int{1, 2, 3, 7, 32} {
+			degree := degree
+			t.Run(fmt.Sprintf("%s with degree %d", testCase.name, degree), func(t *testing.T) {
+				t.Parallel()
+
+				m := btreemap.NewBuilder[int, int](compare.OrderedComparator[int]).
+					Degree(degree).
+					PutAll(testCase.entries...).
+					Build()
+
+				// The last value put for each key wins, as if the entries were put one at a time.
+				expected := map[int]int{}
+				for _, entry := range testCase.entries {
+					expected[entry.Key()] = entry.Value()
+				}
+
+				keys := make([]int, 0, len(expected))
+				for key := range expected {
+					keys = append(keys, key)
+				}
+				sort.Ints(keys)
+
+				assert.Equal(t, len(expected), m.Size())
+				assert.Equal(t, keys, m.Keys())
+
+				for key, value := range expected {
+					assert.Equal(t, value, m.GetOrDefault(key, -1))
+				}
+
+				// A loaded tree can be modified like any other.
+				removed := 0
+				for _, key := range keys {
+					if key%2 == 0 {
+						assert.True(t, m.RemoveKey(key))
+						removed++
+					}
+				}
+
+				m.Put(-1, -1)
+				assert.Equal(t, len(expected)-removed+1, m.Size())
+			})
+		}
+	}
+}
+
+func TestBTreeMapIteration(t *testing.T) {
+	t.Parallel()
+
+	m := btreemap.NewBuilder[int, string](compare.OrderedComparator[int]).Degree(2).Build()
+	for _, key := range rand.New(rand.NewSource(42)).Perm(50) {
+		m.Put(key, fmt.Sprint(key))
+	}
+
+	ascending := []int{}
+	m.Ascend(func(key int, _ string) bool {
+		ascending = append(ascending, key)
+
+		return true
+	})
+	assert.Equal(t, sequence(0, 50), ascending)
+
+	descending := []int{}
+	m.Descend(func(key int, _ string) bool {
+		descending = append(descending, key)
+
+		return key > 40
+	})
+	assert.Equal(t, []int{49, 48, 47, 46, 45, 44, 43, 42, 41, 40}, descending)
+
+	iterated := []int{}
+	for it, ok := m.Iterator(); ok; it, ok = it.Next() {
+		key, _ := it.Key()
+		value, _ := it.Value()
+		assert.Equal(t, fmt.Sprint(key), value)
+		iterated = append(iterated, key)
+	}
+	assert.Equal(t, sequence(0, 50), iterated)
+
+	minKey, _, _ := m.Min()
+	maxKey, _, _ := m.Max()
+	assert.Equal(t, 0, minKey)
+	assert.Equal(t, 49, maxKey)
+}
+
+func TestBTreeMapAscendRange(t *testing.T) {
+	t.Parallel()
+
+	m := btreemap.NewBuilder[int, int](compare.OrderedComparator[int]).Degree(2).Build()
+	for key := 0; key < 100; key += 2 {
+		m.Put(key, key)
+	}
+
+	tests := []struct {
+		name     string
+		start    int
+		end      int
+		expected []int
+	}{
+		{name: "keys at both ends", start: 10, end: 20, expected: []int{10, 12, 14, 16, 18}},
+		{name: "keys between the ends", start: 11, end: 19, expected: []int{12, 14, 16, 18}},
+		{name: "empty range", start: 10, end: 10, expected: []int{}},
+		{name: "range past the keys", start: 95, end: 200, expected: []int{96, 98}},
+		{name: "range before the keys", start: -10, end: 0, expected: []int{}},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			visited := []int{}
+			m.AscendRange(testCase.start, testCase.end, func(key int, _ int) bool {
+				visited = append(visited, key)
+
+				return true
+			})
+			assert.Equal(t, testCase.expected, visited)
+		})
+	}
+}
+
+func TestBTreeMapEnumeration(t *testing.T) {
+	t.Parallel()
+
+	m := btreemap.New(entry.New(3, 30), entry.New(1, 10), entry.New(2, 20))
+
+	key, value, found := m.Find(func(_ int, value int) bool { return value > 10 })
+	assert.True(t, found)
+	assert.Equal(t, 2, key)
+	assert.Equal(t, 20, value)
+
+	assert.True(t, m.Any(func(key int, _ int) bool { return key == 3 }))
+	assert.True(t, m.All(func(key int, value int) bool { return value == key*10 }))
+	assert.False(t, m.All(func(key int, _ int) bool { return key < 3 }))
+}
+
+func TestBTreeMapClone(t *testing.T) {
+	t.Parallel()
+
+	m := btreemap.NewBuilder[int, int](compare.OrderedComparator[int]).Degree(2).Build()
+	for _, key := range sequence(0, 100) {
+		m.Put(key, key)
+	}
+
+	clone := m.Clone()
+	cloneOfClone := clone.Clone()
+
+	// Each map copies the shared nodes it modifies, so the others are unaffected.
+	for _, key := range sequence(0, 50) {
+		m.RemoveKey(key)
+		clone.Put(key, -key)
+	}
+
+	clone.Put(100, 100)
+
+	assert.Equal(t, sequence(50, 100), m.Keys())
+	assert.Equal(t, sequence(0, 101), clone.Keys())
+	assert.Equal(t, sequence(0, 100), cloneOfClone.Keys())
+	assert.Equal(t, -10, clone.GetOrDefault(10, 0))
+	assert.Equal(t, 10, cloneOfClone.GetOrDefault(10, 0))
+}
+
+func TestBTreeMapConcurrentClone(t *testing.T) {
+	t.Parallel()
+
+	m := btreemap.NewBuilder[int, int](compare.OrderedComparator[int]).Degree(2).Build()
+	for _, key := range sequence(0, 100) {
+		m.Put(key, key)
+	}
+
+	// Cloning does not write to the map, so it may be cloned from many goroutines at once,
+	// and each goroutine may modify its own clone.
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		i := i
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			clone := m.Clone()
+			clone.Put(i, -i)
+			clone.RemoveKey(99)
+			assert.Equal(t, -i, clone.GetOrDefault(i, 0))
+			assert.Equal(t, sequence(0, 100), m.Keys())
+		}()
+	}
+	wg.Wait()
+
+	// The map makes its own copies of the nodes it shares with the clones.
+	m.RemoveKey(0)
+	assert.Equal(t, sequence(1, 100), m.Keys())
+}
+
+func TestBTreeMapRandomOperations(t *testing.T) {
+	t.Parallel()
+
+	for _, degree := range []int{2, 3, 4, 16} {
+		degree := degree
+		t.Run(fmt.Sprintf("degree %d", degree), func(t *testing.T) {
+			t.Parallel()
+
+			rng := rand.New(rand.NewSource(int64(degree)))
+			expected := map[int]int{}
+			m := btreemap.NewBuilder[int, int](compare.OrderedComparator[int]).Degree(degree).Build()
+
+			var snapshot *btreemap.BTreeMap[int, int]
+			snapshotKeys := []int{}
+
+			for i := 0; i < 5000; i++ {
+				key := rng.Intn(500)
+
+				switch rng.Intn(5) {
+				case 0, 1:
+					_, existed := expected[key]
+					delete(expected, key)
+					assert.Equal(t, existed, m.RemoveKey(key))
+				case 2:
+					if i%500 == 0 {
+						snapshot, snapshotKeys = m.Clone(), m.Keys()
+					}
+				default:
+					expected[key] = i
+					m.Put(key, i)
+				}
+			}
+
+			keys := make([]int, 0, len(expected))
+			for key := range expected {
+				keys = append(keys, key)
+			}
+			sort.Ints(keys)
+
+			assert.Equal(t, len(expected), m.Size())
+			assert.Equal(t, keys, m.Keys())
+
+			for key, value := range expected {
+				assert.Equal(t, value, m.GetOrDefault(key, -1))
+			}
+
+			if snapshot != nil {
+				assert.Equal(t, snapshotKeys, snapshot.Keys())
+			}
+		})
+	}
+}
+
+func BenchmarkBTreeMapPut(b *testing.B) {
+	keys := rand.New(rand.NewSource(42)).Perm(b.N)
+	m := btreemap.New[int, int]()
+
+	b.ResetTimer()
+
+	for _, key := range keys {
+		m.Put(key, key)
+	}
+}
+
+func BenchmarkBTreeMapGet(b *testing.B) {
+	const size = 1 << 16
+
+	entries := make([]entry.Entry[int, int], 0, size)
+	for _, key := range sequence(0, size) {
+		entries = append(entries, entry.New(key, key))
+	}
+
+	m := btreemap.New(entries...)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.Get(i % size)
+	}
+}
@@ -0,0 +1,150 @@
+package btreemap
+
+import (
+	"sort"
+	"sync/atomic"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+)
+
+// editToken marks the nodes that a map owns and may therefore modify in place. Nodes owned by
+// another token are shared with a clone and must be copied before they are modified.
+// Cloning a map marks its token as shared rather than replacing it, so that cloning does not
+// write to the map, and the map replaces the token before its next modification.
+type editToken struct {
+	shared atomic.Bool
+}
+
+// node is a node of the B-tree. Every node other than the root holds between degree-1 and
+// 2*degree-1 keys, and an internal node has one more child than it has keys. The keys and values
+// are held in separate slices so that searching a node only touches the keys.
+type node[K any, V any] struct {
+	keys     []K
+	values   []V
+	children []*node[K, V]
+	owner    *editToken
+}
+
+func (n *node[K, V]) leaf() bool {
+	return len(n.children) == 0
+}
+
+// editable returns the node if the token owns it, otherwise a copy that it owns.
+func (n *node[K, V]) editable(owner *editToken) *node[K, V] {
+	if n.owner == owner {
+		return n
+	}
+
+	result := &node[K, V]{
+		keys:     append(make([]K, 0, cap(n.keys)), n.keys...),
+		values:   append(make([]V, 0, cap(n.values)), n.values...),
+		children: nil,
+		owner:    owner,
+	}
+
+	if !n.leaf() {
+		result.children = append(make([]*node[K, V], 0, cap(n.children)), n.children...)
+	}
+
+	return result
+}
+
+// editableChild replaces the child at the index with one that the token owns and returns it.
+// The node itself must be owned by the token.
+func (n *node[K, V]) editableChild(i int, owner *editToken) *node[K, V] {
+	child := n.children[i].editable(owner)
+	n.children[i] = child
+
+	return child
+}
+
+// search returns the index of the first key that is not less than the key,
+// and whether that key is equal to it.
+func (n *node[K, V]) search(comparator compare.Comparator[K], key K) (int, bool) {
+	i := sort.Search(len(n.keys), func(i int) bool {
+		return comparator(n.keys[i], key) != compare.PriorityRightHigher
+	})
+
+	return i, i < len(n.keys) && comparator(n.keys[i], key) == compare.PriorityEqual
+}
+
+// split moves the keys after index i and the children after them into a new node, and returns
+// the key and value at i, which the caller moves up into the parent, along with the new node.
+func (n *node[K, V]) split(i int, owner *editToken) (K, V, *node[K, V]) {
+	key, value := n.keys[i], n.values[i]
+
+	next := &node[K, V]{
+		keys:     append(make([]K, 0, cap(n.keys)), n.keys[i+1:]...),
+		values:   append(make([]V, 0, cap(n.values)), n.values[i+1:]...),
+		children: nil,
+		owner:    owner,
+	}
+	n.keys, n.values = truncate(n.keys, i), truncate(n.values, i)
+
+	if !n.leaf() {
+		next.children = append(make([]*node[K, V], 0, cap(n.children)), n.children[i+1:]...)
+		n.children = truncate(n.children, i+1)
+	}
+
+	return key, value, next
+}
+
+// walk visits the entries below the node in order, stopping early if the op returns false.
+func (n *node[K, V]) walk(op func(key K, value V) bool) bool {
+	for i := range n.keys {
+		if !n.leaf() && !n.children[i].walk(op) {
+			return false
+		}
+
+		if !op(n.keys[i], n.values[i]) {
+			return false
+		}
+	}
+
+	return n.leaf() || n.children[len(n.keys)].walk(op)
+}
+
+// walkReverse visits the entries below the node in reverse order,
+// stopping early if the op returns false.
+func (n *node[K, V]) walkReverse(op func(key K, value V) bool) bool {
+	if !n.leaf() && !n.children[len(n.keys)].walkReverse(op) {
+		return false
+	}
+
+	for i := len(n.keys) - 1; i >= 0; i-- {
+		if !op(n.keys[i], n.values[i]) {
+			return false
+		}
+
+		if !n.leaf() && !n.children[i].walkReverse(op) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func insertAt[T any](values []T, i int, value T) []T {
+	values = append(values, *new(T))
+	copy(values[i+1:], values[i:])
+	values[i] = value
+
+	return values
+}
+
+func removeAt[T any](values []T, i int) ([]T, T) {
+	removed := values[i]
+	copy(values[i:], values[i+1:])
+
+	return truncate(values, len(values)-1), removed
+}
+
+// truncate shortens the slice, clearing what it drops so that it can be garbage collected.
+func truncate[T any](values []T, length int) []T {
+	var zero T
+	for i := length; i < len(values); i++ {
+		values[i] = zero
+	}
+
+	return values[:length]
+}
@@ -14,15 +14,25 @@ func MakeThreadSafe[K any, V any](m mapp.Map[K, V]) *ConcurrentMap[K, V] {
 		return c
 	}
 
+	accessOrdered := false
+	if a, ok := m.(mapp.AccessOrdered); ok {
+		accessOrdered = a.AccessOrdered()
+	}
+
 	return &ConcurrentMap[K, V]{
-		inner:  m,
-		rwlock: sync.RWMutex{},
+		inner:         m,
+		rwlock:        sync.RWMutex{},
+		accessOrdered: accessOrdered,
 	}
 }
 
 type ConcurrentMap[K any, V any] struct {
 	inner  mapp.Map[K, V]
 	rwlock sync.RWMutex
+
+	// accessOrdered is whether reading an entry of the inner map modifies it, in which case
+	// Get and GetOrDefault take the write lock.
+	accessOrdered bool
 }
 
 func (m *ConcurrentMap[K, V]) Empty() bool {
@@ -83,13 +93,41 @@ func (m *ConcurrentMap[K, V]) Find(predicate enumerable.Predicate[K, V]) (K, V,
 	return m.inner.Find(predicate)
 }
 
-func (m *ConcurrentMap[K, V]) Get(key K) (V, bool) {
+func (m *ConcurrentMap[K, V]) Keys() []K {
 	m.rwlock.RLock()
 	defer m.rwlock.RUnlock()
 
+	return m.inner.Keys()
+}
+
+func (m *ConcurrentMap[K, V]) Values() []V {
+	m.rwlock.RLock()
+	defer m.rwlock.RUnlock()
+
+	return m.inner.Values()
+}
+
+func (m *ConcurrentMap[K, V]) Entries() []entry.Entry[K, V] {
+	m.rwlock.RLock()
+	defer m.rwlock.RUnlock()
+
+	return m.inner.Entries()
+}
+
+func (m *ConcurrentMap[K, V]) Get(key K) (V, bool) {
+	unlock := m.lockForGet()
+	defer unlock()
+
 	return m.inner.Get(key)
 }
 
+func (m *ConcurrentMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	unlock := m.lockForGet()
+	defer unlock()
+
+	return m.inner.GetOrDefault(key, defaultValue)
+}
+
 func (m *ConcurrentMap[K, V]) Put(key K, value V) {
 	m.rwlock.Lock()
 	defer m.rwlock.Unlock()
@@ -102,6 +140,29 @@ func (m *ConcurrentMap[K, V]) PutAll(entries ...entry.Entry[K, V]) {
 	m.inner.PutAll(entries...)
 }
 
+func (m *ConcurrentMap[K, V]) PutAllFrom(other mapp.ReadOnlyMap[K, V]) {
+	// Read the other map before locking, in case it is this map.
+	entries := other.Entries()
+
+	m.rwlock.Lock()
+	defer m.rwlock.Unlock()
+	m.inner.PutAll(entries...)
+}
+
+func (m *ConcurrentMap[K, V]) Swap(key K, value V) (V, bool) {
+	m.rwlock.Lock()
+	defer m.rwlock.Unlock()
+
+	return m.inner.Swap(key, value)
+}
+
+func (m *ConcurrentMap[K, V]) Replace(key K, value V) bool {
+	m.rwlock.Lock()
+	defer m.rwlock.Unlock()
+
+	return m.inner.Replace(key, value)
+}
+
 func (m *ConcurrentMap[K, V]) RemoveKey(key K) bool {
 	m.rwlock.Lock()
 	defer m.rwlock.Unlock()
@@ -116,6 +177,13 @@ func (m *ConcurrentMap[K, V]) RemoveAllKeys(keys ...K) int {
 	return m.inner.RemoveAllKeys(keys...)
 }
 
+func (m *ConcurrentMap[K, V]) RemoveIf(predicate enumerable.Predicate[K, V]) int {
+	m.rwlock.Lock()
+	defer m.rwlock.Unlock()
+
+	return m.inner.RemoveIf(predicate)
+}
+
 func (m *ConcurrentMap[K, V]) ContainsKey(key K) bool {
 	m.rwlock.RLock()
 	defer m.rwlock.RUnlock()
@@ -136,3 +204,16 @@ func (m *ConcurrentMap[K, V]) ContainsAnyKey(keys ...K) bool {
 
 	return m.inner.ContainsAnyKey(keys...)
 }
+
+// lockForGet locks the map for reading an entry, and returns the function that unlocks it.
+func (m *ConcurrentMap[K, V]) lockForGet() func() {
+	if m.accessOrdered {
+		m.rwlock.Lock()
+
+		return m.rwlock.Unlock
+	}
+
+	m.rwlock.RLock()
+
+	return m.rwlock.RUnlock
+}
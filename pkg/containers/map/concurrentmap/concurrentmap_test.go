@@ -8,10 +8,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kaschnit/go-ds/pkg/compare"
 	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
 	"github.com/kaschnit/go-ds/pkg/containers/map/concurrentmap"
 	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
 	"github.com/kaschnit/go-ds/pkg/containers/map/hashmap"
+	"github.com/kaschnit/go-ds/pkg/containers/map/linkedhashmap"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -134,6 +136,38 @@ func TestConcurrentMapConcurrentPutAndRemove(t *testing.T) {
 	}
 }
 
+func TestConcurrentMapConcurrentGetAccessOrder(t *testing.T) {
+	t.Parallel()
+
+	// Reads reorder an access order map, so they must not run at the same time.
+	inner := linkedhashmap.NewBuilder[int, int, int](compare.IdentityHashKey[int]).AccessOrder(true).Build()
+	for i := 0; i < 100; i++ {
+		inner.Put(i, i)
+	}
+
+	m := concurrentmap.MakeThreadSafe[int, int](inner)
+	waitGroup := sync.WaitGroup{}
+
+	for i := 0; i < 8; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			for j := 0; j < 1000; j++ {
+				value, ok := m.Get(j % 100)
+				assert.True(t, ok)
+				assert.Equal(t, j%100, value)
+				assert.Equal(t, -1, m.GetOrDefault(-1, -1))
+			}
+		}()
+	}
+
+	waitGroup.Wait()
+
+	assert.Equal(t, 100, m.Size())
+	assert.Len(t, m.Keys(), 100)
+}
+
 func TestMakeThreadSafe_AlreadyThreadSafe(t *testing.T) {
 	t.Parallel()
 
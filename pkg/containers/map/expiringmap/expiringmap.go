@@ -0,0 +1,530 @@
+package expiringmap
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaschnit/go-ds/pkg/clock"
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/queue/priorityqueue/heappq"
+)
+
+// Number of removed or replaced entries the deadline heap may hold before it is compacted,
+// on top of one for each entry in the map.
+const compactionSlack = 16
+
+// ExpiryCallback is called with an entry that was removed from the map because it expired.
+type ExpiryCallback[K any, V any] func(key K, value V)
+
+type expiringEntry[K any, V any] struct {
+	key   K
+	value V
+
+	// deadline is when the entry expires, the zero time means the entry never expires.
+	deadline time.Time
+}
+
+func (e *expiringEntry[K, V]) expiredAt(now time.Time) bool {
+	return !e.deadline.IsZero() && !now.Before(e.deadline)
+}
+
+type Builder[K any, HK comparable, V any] struct {
+	hashkey         compare.HashKey[K, HK]
+	defaultTTL      time.Duration
+	clock           clock.Clock
+	onExpire        ExpiryCallback[K, V]
+	janitorInterval time.Duration
+	entries         []entry.Entry[K, V]
+}
+
+func NewBuilder[K any, HK comparable, V any](hashkey compare.HashKey[K, HK]) *Builder[K, HK, V] {
+	return &Builder[K, HK, V]{
+		hashkey: hashkey,
+		clock:   clock.System(),
+	}
+}
+
+// DefaultTTL sets the time to live of entries added with Put. Zero, the default,
+// means entries added with Put never expire.
+func (b *Builder[K, HK, V]) DefaultTTL(ttl time.Duration) *Builder[K, HK, V] {
+	b.defaultTTL = ttl
+
+	return b
+}
+
+// Clock sets the clock used to decide when entries expire. Defaults to the system clock.
+func (b *Builder[K, HK, V]) Clock(c clock.Clock) *Builder[K, HK, V] {
+	b.clock = c
+
+	return b
+}
+
+// OnExpire sets a callback to be called with every entry that expires.
+func (b *Builder[K, HK, V]) OnExpire(onExpire ExpiryCallback[K, V]) *Builder[K, HK, V] {
+	b.onExpire = onExpire
+
+	return b
+}
+
+// Janitor starts a background goroutine that removes expired entries at the interval,
+// so that expired entries are removed and reported even if the map is not used.
+// The janitor must be stopped with StopJanitor when the map is no longer needed.
+func (b *Builder[K, HK, V]) Janitor(interval time.Duration) *Builder[K, HK, V] {
+	b.janitorInterval = interval
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) Put(key K, value V) *Builder[K, HK, V] {
+	b.entries = append(b.entries, entry.New(key, value))
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) PutAll(entries ...entry.Entry[K, V]) *Builder[K, HK, V] {
+	b.entries = append(b.entries, entries...)
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) Build() *ExpiringMap[K, HK, V] {
+	m := &ExpiringMap[K, HK, V]{
+		hashkey:    b.hashkey,
+		defaultTTL: b.defaultTTL,
+		clock:      b.clock,
+		onExpire:   b.onExpire,
+		entries:    make(map[HK]*expiringEntry[K, V]),
+		deadlines: heappq.NewBuilder(func(left *expiringEntry[K, V], right *expiringEntry[K, V]) compare.Priority {
+			return compare.OppositeOrderedComparator(left.deadline.UnixNano(), right.deadline.UnixNano())
+		}).Build(),
+		mutex:       sync.Mutex{},
+		stopJanitor: nil,
+	}
+	m.PutAll(b.entries...)
+
+	if b.janitorInterval > 0 {
+		m.startJanitor(b.janitorInterval)
+	}
+
+	return m
+}
+
+// ExpiringMap is a map whose entries can expire after a time to live. Expired entries are
+// removed lazily whenever the map is used, and optionally by a background janitor.
+// Because of the janitor, the map is safe for concurrent use. The callbacks passed to
+// ForEach, Any, All and Find are called while the map is locked, so they must not use the map.
+type ExpiringMap[K any, HK comparable, V any] struct {
+	hashkey    compare.HashKey[K, HK]
+	defaultTTL time.Duration
+	clock      clock.Clock
+	onExpire   ExpiryCallback[K, V]
+	entries    map[HK]*expiringEntry[K, V]
+
+	// deadlines holds every entry that can expire, with the earliest deadline at the root.
+	// Entries that are removed or replaced stay in the heap until their deadline passes,
+	// and are skipped because they are no longer in the entries map.
+	deadlines *heappq.HeapPQ[*expiringEntry[K, V]]
+
+	mutex       sync.Mutex
+	stopJanitor chan struct{}
+}
+
+func New[K comparable, V any](defaultTTL time.Duration, entries ...entry.Entry[K, V]) *ExpiringMap[K, K, V] {
+	return NewBuilder[K, K, V](compare.IdentityHashKey[K]).DefaultTTL(defaultTTL).PutAll(entries...).Build()
+}
+
+func (m *ExpiringMap[K, HK, V]) Empty() bool {
+	return m.Size() == 0
+}
+
+func (m *ExpiringMap[K, HK, V]) Size() int {
+	defer m.unlock(m.lock())
+
+	return len(m.entries)
+}
+
+func (m *ExpiringMap[K, HK, V]) Clear() {
+	defer m.unlock(m.lock())
+
+	for k := range m.entries {
+		delete(m.entries, k)
+	}
+
+	m.deadlines.Clear()
+}
+
+func (m *ExpiringMap[K, HK, V]) String() string {
+	defer m.unlock(m.lock())
+
+	sb := strings.Builder{}
+	sb.WriteString("ExpiringMap\n")
+
+	strs := make([]string, 0, len(m.entries))
+	for _, e := range m.entries {
+		strs = append(strs, entry.NewRef(e.key, e.value).String())
+	}
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (m *ExpiringMap[K, HK, V]) ForEach(op enumerable.Op[K, V]) {
+	defer m.unlock(m.lock())
+
+	for _, e := range m.entries {
+		op(e.key, e.value)
+	}
+}
+
+func (m *ExpiringMap[K, HK, V]) Any(predicate enumerable.Predicate[K, V]) bool {
+	defer m.unlock(m.lock())
+
+	for _, e := range m.entries {
+		if predicate(e.key, e.value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ExpiringMap[K, HK, V]) All(predicate enumerable.Predicate[K, V]) bool {
+	defer m.unlock(m.lock())
+
+	for _, e := range m.entries {
+		if !predicate(e.key, e.value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *ExpiringMap[K, HK, V]) Find(predicate enumerable.Predicate[K, V]) (K, V, bool) {
+	defer m.unlock(m.lock())
+
+	for _, e := range m.entries {
+		if predicate(e.key, e.value) {
+			return e.key, e.value, true
+		}
+	}
+
+	return *new(K), *new(V), false
+}
+
+func (m *ExpiringMap[K, HK, V]) Keys() []K {
+	defer m.unlock(m.lock())
+
+	keys := make([]K, 0, len(m.entries))
+	for _, e := range m.entries {
+		keys = append(keys, e.key)
+	}
+
+	return keys
+}
+
+func (m *ExpiringMap[K, HK, V]) Values() []V {
+	defer m.unlock(m.lock())
+
+	values := make([]V, 0, len(m.entries))
+	for _, e := range m.entries {
+		values = append(values, e.value)
+	}
+
+	return values
+}
+
+func (m *ExpiringMap[K, HK, V]) Entries() []entry.Entry[K, V] {
+	defer m.unlock(m.lock())
+
+	entries := make([]entry.Entry[K, V], 0, len(m.entries))
+	for _, e := range m.entries {
+		entries = append(entries, entry.New(e.key, e.value))
+	}
+
+	return entries
+}
+
+func (m *ExpiringMap[K, HK, V]) Get(key K) (V, bool) {
+	defer m.unlock(m.lock())
+
+	e, ok := m.entries[m.hashkey(key)]
+	if !ok {
+		return *new(V), false
+	}
+
+	return e.value, true
+}
+
+func (m *ExpiringMap[K, HK, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, ok := m.Get(key); ok {
+		return value
+	}
+
+	return defaultValue
+}
+
+// Put sets the value for the key with the map's default time to live.
+func (m *ExpiringMap[K, HK, V]) Put(key K, value V) {
+	m.PutWithTTL(key, value, m.defaultTTL)
+}
+
+// PutWithTTL sets the value for the key, which expires once the time to live has passed.
+// A time to live of zero or less means the entry never expires.
+func (m *ExpiringMap[K, HK, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	defer m.unlock(m.lock())
+
+	m.put(key, value, ttl)
+}
+
+func (m *ExpiringMap[K, HK, V]) PutAll(entries ...entry.Entry[K, V]) {
+	defer m.unlock(m.lock())
+
+	for _, entry := range entries {
+		m.put(entry.Key(), entry.Value(), m.defaultTTL)
+	}
+}
+
+// PutAllFrom puts all of the entries of the other map with the map's default time to live.
+func (m *ExpiringMap[K, HK, V]) PutAllFrom(other mapp.ReadOnlyMap[K, V]) {
+	// Read the other map before locking, in case it is this map.
+	m.PutAll(other.Entries()...)
+}
+
+// Swap sets the value for the key with the map's default time to live,
+// and returns the value it replaced.
+func (m *ExpiringMap[K, HK, V]) Swap(key K, value V) (V, bool) {
+	defer m.unlock(m.lock())
+
+	old, existed := m.entries[m.hashkey(key)]
+	m.put(key, value, m.defaultTTL)
+
+	if !existed {
+		return *new(V), false
+	}
+
+	return old.value, true
+}
+
+// Replace sets the value for the key with the map's default time to live,
+// only if the key is already in the map.
+func (m *ExpiringMap[K, HK, V]) Replace(key K, value V) bool {
+	defer m.unlock(m.lock())
+
+	if _, ok := m.entries[m.hashkey(key)]; !ok {
+		return false
+	}
+
+	m.put(key, value, m.defaultTTL)
+
+	return true
+}
+
+func (m *ExpiringMap[K, HK, V]) RemoveKey(key K) bool {
+	defer m.unlock(m.lock())
+
+	return m.removeKey(key)
+}
+
+func (m *ExpiringMap[K, HK, V]) RemoveAllKeys(keys ...K) int {
+	defer m.unlock(m.lock())
+
+	removed := 0
+
+	for _, key := range keys {
+		if m.removeKey(key) {
+			removed++
+		}
+	}
+
+	return removed
+}
+
+func (m *ExpiringMap[K, HK, V]) RemoveIf(predicate enumerable.Predicate[K, V]) int {
+	defer m.unlock(m.lock())
+
+	removed := 0
+
+	for hashedKey, e := range m.entries {
+		if predicate(e.key, e.value) {
+			delete(m.entries, hashedKey)
+			removed++
+		}
+	}
+
+	m.compactDeadlines()
+
+	return removed
+}
+
+func (m *ExpiringMap[K, HK, V]) ContainsKey(key K) bool {
+	defer m.unlock(m.lock())
+
+	_, ok := m.entries[m.hashkey(key)]
+
+	return ok
+}
+
+func (m *ExpiringMap[K, HK, V]) ContainsAllKeys(keys ...K) bool {
+	defer m.unlock(m.lock())
+
+	for _, key := range keys {
+		if _, ok := m.entries[m.hashkey(key)]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *ExpiringMap[K, HK, V]) ContainsAnyKey(keys ...K) bool {
+	defer m.unlock(m.lock())
+
+	for _, key := range keys {
+		if _, ok := m.entries[m.hashkey(key)]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TTL returns the time left until the key expires. Returns false if the key is not in the map.
+// An entry that never expires has a time to live of zero.
+func (m *ExpiringMap[K, HK, V]) TTL(key K) (time.Duration, bool) {
+	defer m.unlock(m.lock())
+
+	e, ok := m.entries[m.hashkey(key)]
+	if !ok {
+		return 0, false
+	}
+
+	if e.deadline.IsZero() {
+		return 0, true
+	}
+
+	return e.deadline.Sub(m.clock.Now()), true
+}
+
+// RemoveExpired removes all expired entries now rather than waiting for the map to be used.
+func (m *ExpiringMap[K, HK, V]) RemoveExpired() {
+	m.unlock(m.lock())
+}
+
+// StopJanitor stops the background janitor, if there is one. It is safe to call more than once.
+func (m *ExpiringMap[K, HK, V]) StopJanitor() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.stopJanitor != nil {
+		close(m.stopJanitor)
+		m.stopJanitor = nil
+	}
+}
+
+func (m *ExpiringMap[K, HK, V]) startJanitor(interval time.Duration) {
+	stop := make(chan struct{})
+	m.stopJanitor = stop
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				m.RemoveExpired()
+			}
+		}
+	}()
+}
+
+// lock locks the map and removes the expired entries, returning them so that they can be
+// reported by unlock once the map is unlocked. This allows the expiry callback to use the map.
+// Used as defer m.unlock(m.lock()).
+func (m *ExpiringMap[K, HK, V]) lock() []*expiringEntry[K, V] {
+	m.mutex.Lock()
+
+	return m.removeExpired()
+}
+
+func (m *ExpiringMap[K, HK, V]) unlock(expired []*expiringEntry[K, V]) {
+	m.mutex.Unlock()
+
+	if m.onExpire != nil {
+		for _, e := range expired {
+			m.onExpire(e.key, e.value)
+		}
+	}
+}
+
+func (m *ExpiringMap[K, HK, V]) removeExpired() []*expiringEntry[K, V] {
+	var expired []*expiringEntry[K, V]
+
+	now := m.clock.Now()
+
+	for e, ok := m.deadlines.Peek(); ok && e.expiredAt(now); e, ok = m.deadlines.Peek() {
+		m.deadlines.Pop()
+
+		// Skip entries that were removed or replaced before they expired.
+		hashedKey := m.hashkey(e.key)
+		if m.entries[hashedKey] != e {
+			continue
+		}
+
+		delete(m.entries, hashedKey)
+		expired = append(expired, e)
+	}
+
+	return expired
+}
+
+func (m *ExpiringMap[K, HK, V]) put(key K, value V, ttl time.Duration) {
+	e := &expiringEntry[K, V]{
+		key:      key,
+		value:    value,
+		deadline: time.Time{},
+	}
+	m.entries[m.hashkey(key)] = e
+
+	if ttl > 0 {
+		e.deadline = m.clock.Now().Add(ttl)
+		m.deadlines.Push(e)
+	}
+
+	m.compactDeadlines()
+}
+
+func (m *ExpiringMap[K, HK, V]) removeKey(key K) bool {
+	hashedKey := m.hashkey(key)
+
+	_, ok := m.entries[hashedKey]
+	delete(m.entries, hashedKey)
+	m.compactDeadlines()
+
+	return ok
+}
+
+// compactDeadlines drops removed and replaced entries from the deadline heap once they
+// make up most of it, so that a map whose keys are overwritten or removed often does not
+// keep growing or keep their keys and values alive until they would have expired.
+func (m *ExpiringMap[K, HK, V]) compactDeadlines() {
+	if m.deadlines.Size() <= 2*len(m.entries)+compactionSlack {
+		return
+	}
+
+	m.deadlines.Clear()
+
+	for _, e := range m.entries {
+		if !e.deadline.IsZero() {
+			m.deadlines.Push(e)
+		}
+	}
+}
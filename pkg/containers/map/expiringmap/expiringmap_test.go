@@ -0,0 +1,254 @@
+package expiringmap_test
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kaschnit/go-ds/pkg/clock"
+	"github.com/kaschnit/go-ds/pkg/compare"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/map/expiringmap"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that ExpiringMap implements Map.
+var _ mapp.Map[string, int] = &expiringmap.ExpiringMap[string, string, int]{}
+
+func newClock() *clock.Manual {
+	return clock.NewManual(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestExpiringMapString(t *testing.T) {
+	t.Parallel()
+
+	m := expiringmap.New(0, entry.New(1, "a"))
+	assert.Equal(t, "ExpiringMap\nEntry{Key:1, Value:a}", m.String())
+
+	m.Clear()
+	assert.Equal(t, "ExpiringMap\n", m.String())
+}
+
+func TestExpiringMapLazyExpiry(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	expired := []string{}
+	m := expiringmap.NewBuilder[string, string, int](compare.IdentityHashKey[string]).
+		Clock(c).
+		DefaultTTL(time.Minute).
+		OnExpire(func(key string, _ int) {
+			expired = append(expired, key)
+		}).
+		Build()
+
+	m.Put("default", 1)
+	m.PutWithTTL("short", 2, time.Second)
+	m.PutWithTTL("forever", 3, 0)
+	assert.Equal(t, 3, m.Size())
+
+	ttl, ok := m.TTL("short")
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, ttl)
+
+	ttl, ok = m.TTL("forever")
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), ttl)
+
+	_, ok = m.TTL("missing")
+	assert.False(t, ok)
+
+	c.Advance(time.Second)
+
+	_, ok = m.Get("short")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"short"}, expired)
+
+	value, ok := m.Get("default")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	c.Advance(time.Hour)
+	assert.False(t, m.ContainsKey("default"))
+	assert.True(t, m.ContainsKey("forever"))
+	assert.Equal(t, []string{"short", "default"}, expired)
+	assert.Equal(t, 1, m.Size())
+}
+
+func TestExpiringMapReplaceAndRemove(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	expired := []string{}
+	m := expiringmap.NewBuilder[string, string, int](compare.IdentityHashKey[string]).
+		Clock(c).
+		OnExpire(func(key string, _ int) {
+			expired = append(expired, key)
+		}).
+		Build()
+
+	m.PutWithTTL("a", 1, time.Second)
+	m.PutWithTTL("b", 2, time.Second)
+
+	// Replacing an entry resets its time to live.
+	m.PutWithTTL("a", 10, time.Minute)
+
+	// Removed entries do not expire.
+	assert.True(t, m.RemoveKey("b"))
+
+	c.Advance(2 * time.Second)
+	m.RemoveExpired()
+	assert.Empty(t, expired)
+
+	value, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+
+	c.Advance(time.Minute)
+	m.RemoveExpired()
+	assert.Equal(t, []string{"a"}, expired)
+	assert.True(t, m.Empty())
+}
+
+func TestExpiringMapOverwriteManyTimes(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	m := expiringmap.NewBuilder[int, int, int](compare.IdentityHashKey[int]).Clock(c).DefaultTTL(time.Hour).Build()
+
+	for i := 0; i < 10000; i++ {
+		m.Put(i%10, i)
+	}
+
+	assert.Equal(t, 10, m.Size())
+
+	c.Advance(time.Hour)
+	assert.True(t, m.Empty())
+}
+
+func TestExpiringMapRemovedValuesAreReleased(t *testing.T) {
+	t.Parallel()
+
+	const count = 1000
+
+	c := newClock()
+	m := expiringmap.NewBuilder[int, int, *[1024]byte](compare.IdentityHashKey[int]).
+		Clock(c).
+		DefaultTTL(24 * time.Hour).
+		Build()
+
+	var released atomic.Int64
+
+	for i := 0; i < count; i++ {
+		value := new([1024]byte)
+		runtime.AddCleanup(value, func(*atomic.Int64) { released.Add(1) }, &released)
+		m.Put(i, value)
+	}
+
+	for i := 0; i < count; i++ {
+		assert.True(t, m.RemoveKey(i))
+	}
+
+	assert.True(t, m.Empty())
+
+	// Only a few removed entries may stay behind in the deadline heap until it is compacted again.
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+
+		return released.Load() >= count-100
+	}, 5*time.Second, 10*time.Millisecond)
+
+	runtime.KeepAlive(m)
+}
+
+func TestExpiringMapCallbackCanUseMap(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+
+	var m *expiringmap.ExpiringMap[string, string, int]
+
+	m = expiringmap.NewBuilder[string, string, int](compare.IdentityHashKey[string]).
+		Clock(c).
+		OnExpire(func(key string, value int) {
+			// Renew the entry with a longer time to live.
+			m.PutWithTTL(key, value+1, time.Hour)
+		}).
+		Build()
+
+	m.PutWithTTL("a", 1, time.Second)
+	c.Advance(time.Second)
+
+	// The expired entry is reported after the map is unlocked, so it is renewed.
+	assert.False(t, m.ContainsKey("a"))
+
+	value, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+}
+
+func TestExpiringMapJanitor(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	mutex := sync.Mutex{}
+	expired := []string{}
+	m := expiringmap.NewBuilder[string, string, int](compare.IdentityHashKey[string]).
+		Clock(c).
+		Janitor(time.Millisecond).
+		OnExpire(func(key string, _ int) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			expired = append(expired, key)
+		}).
+		Build()
+	defer m.StopJanitor()
+
+	m.PutWithTTL("a", 1, time.Second)
+	c.Advance(time.Second)
+
+	// The janitor reports the expiry without the map being used.
+	assert.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return len(expired) == 1
+	}, time.Second, time.Millisecond)
+
+	m.StopJanitor()
+	m.StopJanitor()
+}
+
+func TestExpiringMapEnumeration(t *testing.T) {
+	t.Parallel()
+
+	c := newClock()
+	m := expiringmap.NewBuilder[string, string, int](compare.IdentityHashKey[string]).
+		Clock(c).
+		Put("a", 1).
+		Put("b", 2).
+		Build()
+	m.PutWithTTL("c", 3, time.Second)
+	c.Advance(time.Second)
+
+	keys := []string{}
+	m.ForEach(func(key string, _ int) {
+		keys = append(keys, key)
+	})
+	assert.ElementsMatch(t, []string{"a", "b"}, keys)
+
+	assert.False(t, m.Any(func(key string, _ int) bool { return key == "c" }))
+	assert.True(t, m.All(func(_ string, value int) bool { return value < 3 }))
+
+	_, _, ok := m.Find(func(_ string, value int) bool { return value == 3 })
+	assert.False(t, ok)
+
+	assert.True(t, m.ContainsAllKeys("a", "b"))
+	assert.False(t, m.ContainsAnyKey("c"))
+	assert.False(t, strings.Contains(m.String(), "Key:c"))
+}
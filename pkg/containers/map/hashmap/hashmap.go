@@ -5,6 +5,7 @@ import (
 
 	"github.com/kaschnit/go-ds/pkg/compare"
 	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
 	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
 )
 
@@ -118,6 +119,33 @@ func (m *HashMap[K, HK, V]) Find(predicate enumerable.Predicate[K, V]) (K, V, bo
 	return *new(K), *new(V), false
 }
 
+func (m *HashMap[K, HK, V]) Keys() []K {
+	keys := make([]K, 0, len(m.entries))
+	for _, entry := range m.entries {
+		keys = append(keys, entry.Key())
+	}
+
+	return keys
+}
+
+func (m *HashMap[K, HK, V]) Values() []V {
+	values := make([]V, 0, len(m.entries))
+	for _, entry := range m.entries {
+		values = append(values, entry.Value())
+	}
+
+	return values
+}
+
+func (m *HashMap[K, HK, V]) Entries() []entry.Entry[K, V] {
+	entries := make([]entry.Entry[K, V], 0, len(m.entries))
+	for _, entry := range m.entries {
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
 func (m *HashMap[K, HK, V]) Get(key K) (V, bool) {
 	entry, ok := m.entries[m.hashkey(key)]
 	if !ok {
@@ -127,6 +155,14 @@ func (m *HashMap[K, HK, V]) Get(key K) (V, bool) {
 	return entry.Value(), true
 }
 
+func (m *HashMap[K, HK, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, ok := m.Get(key); ok {
+		return value
+	}
+
+	return defaultValue
+}
+
 func (m *HashMap[K, HK, V]) Put(key K, value V) {
 	m.entries[m.hashkey(key)] = entry.New(key, value)
 }
@@ -137,6 +173,29 @@ func (m *HashMap[K, HK, V]) PutAll(entries ...entry.Entry[K, V]) {
 	}
 }
 
+func (m *HashMap[K, HK, V]) PutAllFrom(other mapp.ReadOnlyMap[K, V]) {
+	m.PutAll(other.Entries()...)
+}
+
+func (m *HashMap[K, HK, V]) Swap(key K, value V) (V, bool) {
+	hashedKey := m.hashkey(key)
+	old, existed := m.entries[hashedKey]
+	m.entries[hashedKey] = entry.New(key, value)
+
+	return old.Value(), existed
+}
+
+func (m *HashMap[K, HK, V]) Replace(key K, value V) bool {
+	hashedKey := m.hashkey(key)
+	if !m.containsHashedKey(hashedKey) {
+		return false
+	}
+
+	m.entries[hashedKey] = entry.New(key, value)
+
+	return true
+}
+
 func (m *HashMap[K, HK, V]) RemoveKey(key K) bool {
 	hashedKey := m.hashkey(key)
 	contained := m.containsHashedKey(hashedKey)
@@ -157,6 +216,19 @@ func (m *HashMap[K, HK, V]) RemoveAllKeys(keys ...K) int {
 	return removed
 }
 
+func (m *HashMap[K, HK, V]) RemoveIf(predicate enumerable.Predicate[K, V]) int {
+	removed := 0
+
+	for hashedKey, entry := range m.entries {
+		if predicate(entry.Key(), entry.Value()) {
+			delete(m.entries, hashedKey)
+			removed++
+		}
+	}
+
+	return removed
+}
+
 func (m *HashMap[K, HK, V]) ContainsKey(key K) bool {
 	return m.containsHashedKey(m.hashkey(key))
 }
@@ -0,0 +1,453 @@
+package linkedhashmap
+
+import (
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/iterator"
+)
+
+type linkedHashMapIterator[K any, V any] struct {
+	node   *linkedNode[K, V]
+	nextOp func(node *linkedNode[K, V]) *linkedNode[K, V]
+}
+
+func (a *linkedHashMapIterator[K, V]) Key() (K, bool) {
+	return a.node.key, true
+}
+
+func (a *linkedHashMapIterator[K, V]) Value() (V, bool) {
+	return a.node.value, true
+}
+
+func (a *linkedHashMapIterator[K, V]) Next() (iterator.ForwardIterator[K, V], bool) {
+	if !a.HasNext() {
+		return nil, false
+	}
+
+	return &linkedHashMapIterator[K, V]{
+		node:   a.nextOp(a.node),
+		nextOp: a.nextOp,
+	}, true
+}
+
+func (a *linkedHashMapIterator[K, V]) HasNext() bool {
+	return a.nextOp(a.node) != nil
+}
+
+type linkedNode[K any, V any] struct {
+	key   K
+	value V
+	prev  *linkedNode[K, V]
+	next  *linkedNode[K, V]
+}
+
+type Builder[K any, HK comparable, V any] struct {
+	hashkey     compare.HashKey[K, HK]
+	accessOrder bool
+	entries     []entry.Entry[K, V]
+}
+
+func NewBuilder[K any, HK comparable, V any](hashkey compare.HashKey[K, HK]) *Builder[K, HK, V] {
+	return &Builder[K, HK, V]{
+		hashkey: hashkey,
+	}
+}
+
+// AccessOrder makes the map order its entries from least recently to most recently accessed,
+// rather than by insertion. Both Get and Put count as an access.
+func (b *Builder[K, HK, V]) AccessOrder(accessOrder bool) *Builder[K, HK, V] {
+	b.accessOrder = accessOrder
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) Put(key K, value V) *Builder[K, HK, V] {
+	b.entries = append(b.entries, entry.New(key, value))
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) PutAll(entries ...entry.Entry[K, V]) *Builder[K, HK, V] {
+	b.entries = append(b.entries, entries...)
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) Build() *LinkedHashMap[K, HK, V] {
+	m := &LinkedHashMap[K, HK, V]{
+		hashkey:     b.hashkey,
+		accessOrder: b.accessOrder,
+		nodes:       make(map[HK]*linkedNode[K, V], len(b.entries)),
+		head:        nil,
+		tail:        nil,
+	}
+	m.PutAll(b.entries...)
+
+	return m
+}
+
+// LinkedHashMap is a hash map that remembers the order of its entries, so enumeration and
+// iteration are deterministic. By default entries are ordered by when their key was first
+// inserted; see Builder.AccessOrder for ordering by most recent access.
+type LinkedHashMap[K any, HK comparable, V any] struct {
+	hashkey     compare.HashKey[K, HK]
+	accessOrder bool
+	nodes       map[HK]*linkedNode[K, V]
+	head        *linkedNode[K, V]
+	tail        *linkedNode[K, V]
+}
+
+func New[K comparable, V any](entries ...entry.Entry[K, V]) *LinkedHashMap[K, K, V] {
+	return NewBuilder[K, K, V](compare.IdentityHashKey[K]).PutAll(entries...).Build()
+}
+
+func (m *LinkedHashMap[K, HK, V]) Empty() bool {
+	return m.Size() == 0
+}
+
+func (m *LinkedHashMap[K, HK, V]) Size() int {
+	return len(m.nodes)
+}
+
+func (m *LinkedHashMap[K, HK, V]) Clear() {
+	for k := range m.nodes {
+		delete(m.nodes, k)
+	}
+
+	m.head = nil
+	m.tail = nil
+}
+
+func (m *LinkedHashMap[K, HK, V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("LinkedHashMap\n")
+
+	strs := make([]string, 0, m.Size())
+	for node := m.head; node != nil; node = node.next {
+		strs = append(strs, entry.NewRef(node.key, node.value).String())
+	}
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (m *LinkedHashMap[K, HK, V]) ForEach(op enumerable.Op[K, V]) {
+	for node := m.head; node != nil; node = node.next {
+		op(node.key, node.value)
+	}
+}
+
+func (m *LinkedHashMap[K, HK, V]) Any(predicate enumerable.Predicate[K, V]) bool {
+	for node := m.head; node != nil; node = node.next {
+		if predicate(node.key, node.value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *LinkedHashMap[K, HK, V]) All(predicate enumerable.Predicate[K, V]) bool {
+	for node := m.head; node != nil; node = node.next {
+		if !predicate(node.key, node.value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *LinkedHashMap[K, HK, V]) Find(predicate enumerable.Predicate[K, V]) (K, V, bool) {
+	for node := m.head; node != nil; node = node.next {
+		if predicate(node.key, node.value) {
+			return node.key, node.value, true
+		}
+	}
+
+	return *new(K), *new(V), false
+}
+
+func (m *LinkedHashMap[K, HK, V]) Keys() []K {
+	keys := make([]K, 0, m.Size())
+	for node := m.head; node != nil; node = node.next {
+		keys = append(keys, node.key)
+	}
+
+	return keys
+}
+
+func (m *LinkedHashMap[K, HK, V]) Values() []V {
+	values := make([]V, 0, m.Size())
+	for node := m.head; node != nil; node = node.next {
+		values = append(values, node.value)
+	}
+
+	return values
+}
+
+func (m *LinkedHashMap[K, HK, V]) Entries() []entry.Entry[K, V] {
+	entries := make([]entry.Entry[K, V], 0, m.Size())
+	for node := m.head; node != nil; node = node.next {
+		entries = append(entries, entry.New(node.key, node.value))
+	}
+
+	return entries
+}
+
+func (m *LinkedHashMap[K, HK, V]) Iterator() (iterator.ForwardIterator[K, V], bool) {
+	if m.Empty() {
+		return nil, false
+	}
+
+	return &linkedHashMapIterator[K, V]{
+		node: m.head,
+		nextOp: func(node *linkedNode[K, V]) *linkedNode[K, V] {
+			return node.next
+		},
+	}, true
+}
+
+func (m *LinkedHashMap[K, HK, V]) IteratorReverse() (iterator.ForwardIterator[K, V], bool) {
+	if m.Empty() {
+		return nil, false
+	}
+
+	return &linkedHashMapIterator[K, V]{
+		node: m.tail,
+		nextOp: func(node *linkedNode[K, V]) *linkedNode[K, V] {
+			return node.prev
+		},
+	}, true
+}
+
+// AccessOrdered returns whether the map orders its entries by most recent access,
+// in which case Get and GetOrDefault modify the map.
+func (m *LinkedHashMap[K, HK, V]) AccessOrdered() bool {
+	return m.accessOrder
+}
+
+// Get returns the value for the key. In access order mode this moves the entry to the end
+// of the map's order.
+func (m *LinkedHashMap[K, HK, V]) Get(key K) (V, bool) {
+	node, ok := m.nodes[m.hashkey(key)]
+	if !ok {
+		return *new(V), false
+	}
+
+	if m.accessOrder {
+		m.moveToBack(node)
+	}
+
+	return node.value, true
+}
+
+func (m *LinkedHashMap[K, HK, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, ok := m.Get(key); ok {
+		return value
+	}
+
+	return defaultValue
+}
+
+// Put sets the value for the key. A new key is placed at the end of the map's order.
+// An existing key keeps its position, unless the map is in access order mode,
+// in which case it is moved to the end.
+func (m *LinkedHashMap[K, HK, V]) Put(key K, value V) {
+	hashedKey := m.hashkey(key)
+
+	if node, ok := m.nodes[hashedKey]; ok {
+		node.key = key
+		node.value = value
+
+		if m.accessOrder {
+			m.moveToBack(node)
+		}
+
+		return
+	}
+
+	node := &linkedNode[K, V]{
+		key:   key,
+		value: value,
+		prev:  nil,
+		next:  nil,
+	}
+	m.nodes[hashedKey] = node
+	m.linkBack(node)
+}
+
+func (m *LinkedHashMap[K, HK, V]) PutAll(entries ...entry.Entry[K, V]) {
+	for _, entry := range entries {
+		m.Put(entry.Key(), entry.Value())
+	}
+}
+
+func (m *LinkedHashMap[K, HK, V]) PutAllFrom(other mapp.ReadOnlyMap[K, V]) {
+	m.PutAll(other.Entries()...)
+}
+
+func (m *LinkedHashMap[K, HK, V]) Swap(key K, value V) (V, bool) {
+	old, existed := *new(V), false
+	if node, ok := m.nodes[m.hashkey(key)]; ok {
+		old, existed = node.value, true
+	}
+
+	m.Put(key, value)
+
+	return old, existed
+}
+
+func (m *LinkedHashMap[K, HK, V]) Replace(key K, value V) bool {
+	if !m.ContainsKey(key) {
+		return false
+	}
+
+	m.Put(key, value)
+
+	return true
+}
+
+func (m *LinkedHashMap[K, HK, V]) RemoveKey(key K) bool {
+	hashedKey := m.hashkey(key)
+
+	node, ok := m.nodes[hashedKey]
+	if !ok {
+		return false
+	}
+
+	delete(m.nodes, hashedKey)
+	m.unlink(node)
+
+	return true
+}
+
+func (m *LinkedHashMap[K, HK, V]) RemoveAllKeys(keys ...K) int {
+	removed := 0
+
+	for _, key := range keys {
+		if m.RemoveKey(key) {
+			removed++
+		}
+	}
+
+	return removed
+}
+
+func (m *LinkedHashMap[K, HK, V]) RemoveIf(predicate enumerable.Predicate[K, V]) int {
+	removed := 0
+
+	for node := m.head; node != nil; {
+		next := node.next
+
+		if predicate(node.key, node.value) {
+			delete(m.nodes, m.hashkey(node.key))
+			m.unlink(node)
+			removed++
+		}
+
+		node = next
+	}
+
+	return removed
+}
+
+func (m *LinkedHashMap[K, HK, V]) ContainsKey(key K) bool {
+	_, contains := m.nodes[m.hashkey(key)]
+
+	return contains
+}
+
+func (m *LinkedHashMap[K, HK, V]) ContainsAllKeys(keys ...K) bool {
+	for _, key := range keys {
+		if !m.ContainsKey(key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *LinkedHashMap[K, HK, V]) ContainsAnyKey(keys ...K) bool {
+	for _, key := range keys {
+		if m.ContainsKey(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Front returns the first entry in the map's order, which is the least recently inserted
+// entry, or the least recently accessed entry in access order mode.
+func (m *LinkedHashMap[K, HK, V]) Front() (K, V, bool) {
+	if m.head == nil {
+		return *new(K), *new(V), false
+	}
+
+	return m.head.key, m.head.value, true
+}
+
+// Back returns the last entry in the map's order, which is the most recently inserted
+// entry, or the most recently accessed entry in access order mode.
+func (m *LinkedHashMap[K, HK, V]) Back() (K, V, bool) {
+	if m.tail == nil {
+		return *new(K), *new(V), false
+	}
+
+	return m.tail.key, m.tail.value, true
+}
+
+// MoveToBack moves the entry for the key to the end of the map's order, as if it had
+// just been inserted. Returns false if the key is not in the map.
+func (m *LinkedHashMap[K, HK, V]) MoveToBack(key K) bool {
+	node, ok := m.nodes[m.hashkey(key)]
+	if ok {
+		m.moveToBack(node)
+	}
+
+	return ok
+}
+
+func (m *LinkedHashMap[K, HK, V]) linkBack(node *linkedNode[K, V]) {
+	node.prev = m.tail
+	node.next = nil
+
+	if m.tail == nil {
+		m.head = node
+	} else {
+		m.tail.next = node
+	}
+
+	m.tail = node
+}
+
+func (m *LinkedHashMap[K, HK, V]) unlink(node *linkedNode[K, V]) {
+	if node.prev == nil {
+		m.head = node.next
+	} else {
+		node.prev.next = node.next
+	}
+
+	if node.next == nil {
+		m.tail = node.prev
+	} else {
+		node.next.prev = node.prev
+	}
+
+	node.prev = nil
+	node.next = nil
+}
+
+func (m *LinkedHashMap[K, HK, V]) moveToBack(node *linkedNode[K, V]) {
+	if m.tail == node {
+		return
+	}
+
+	m.unlink(node)
+	m.linkBack(node)
+}
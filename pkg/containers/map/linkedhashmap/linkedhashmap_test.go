@@ -0,0 +1,212 @@
+package linkedhashmap_test
+
+import (
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/iterable"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/map/linkedhashmap"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that LinkedHashMap implements Map and is iterable in both directions.
+var (
+	_ mapp.Map[string, int]                 = &linkedhashmap.LinkedHashMap[string, string, int]{}
+	_ iterable.ForwardIterable[string, int] = &linkedhashmap.LinkedHashMap[string, string, int]{}
+	_ iterable.ReverseIterable[string, int] = &linkedhashmap.LinkedHashMap[string, string, int]{}
+)
+
+func keysInOrder[K comparable, V any](m *linkedhashmap.LinkedHashMap[K, K, V]) []K {
+	keys := make([]K, 0, m.Size())
+	m.ForEach(func(key K, _ V) {
+		keys = append(keys, key)
+	})
+
+	return keys
+}
+
+func TestLinkedHashMapString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		mapping  *linkedhashmap.LinkedHashMap[int, int, string]
+		expected string
+	}{
+		{
+			name:     "empty map",
+			mapping:  linkedhashmap.New[int, string](),
+			expected: "LinkedHashMap\n",
+		},
+		{
+			name:     "map with 1 item",
+			mapping:  linkedhashmap.New(entry.New(987654321, "foo")),
+			expected: "LinkedHashMap\nEntry{Key:987654321, Value:foo}",
+		},
+		{
+			name: "map with a few items",
+			mapping: linkedhashmap.New(
+				entry.New(100, "abc"),
+				entry.New(-202, "def"),
+				entry.New(5, "ghi"),
+			),
+			expected: "LinkedHashMap\nEntry{Key:100, Value:abc},Entry{Key:-202, Value:def},Entry{Key:5, Value:ghi}",
+		},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expected, testCase.mapping.String())
+		})
+	}
+}
+
+func TestLinkedHashMapInsertionOrder(t *testing.T) {
+	t.Parallel()
+
+	m := linkedhashmap.New(entry.New("c", 1), entry.New("a", 2), entry.New("b", 3))
+	assert.Equal(t, []string{"c", "a", "b"}, keysInOrder(m))
+
+	// Updating an existing key does not change the order.
+	m.Put("c", 100)
+	assert.Equal(t, []string{"c", "a", "b"}, keysInOrder(m))
+
+	// Reading does not change the order.
+	value, ok := m.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 100, value)
+	assert.Equal(t, []string{"c", "a", "b"}, keysInOrder(m))
+
+	// Removing and re-adding moves the key to the end.
+	assert.True(t, m.RemoveKey("c"))
+	m.Put("c", 1)
+	assert.Equal(t, []string{"a", "b", "c"}, keysInOrder(m))
+
+	key, value, ok := m.Front()
+	assert.True(t, ok)
+	assert.Equal(t, "a", key)
+	assert.Equal(t, 2, value)
+
+	key, value, ok = m.Back()
+	assert.True(t, ok)
+	assert.Equal(t, "c", key)
+	assert.Equal(t, 1, value)
+
+	assert.True(t, m.MoveToBack("a"))
+	assert.False(t, m.MoveToBack("missing"))
+	assert.Equal(t, []string{"b", "c", "a"}, keysInOrder(m))
+
+	m.Clear()
+	assert.Equal(t, []string{}, keysInOrder(m))
+
+	_, _, ok = m.Front()
+	assert.False(t, ok)
+
+	_, _, ok = m.Back()
+	assert.False(t, ok)
+}
+
+func TestLinkedHashMapAccessOrder(t *testing.T) {
+	t.Parallel()
+
+	m := linkedhashmap.NewBuilder[string, string, int](compare.IdentityHashKey[string]).
+		AccessOrder(true).
+		Put("a", 1).
+		Put("b", 2).
+		Put("c", 3).
+		Build()
+	assert.Equal(t, []string{"a", "b", "c"}, keysInOrder(m))
+
+	_, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"b", "c", "a"}, keysInOrder(m))
+
+	m.Put("b", 20)
+	assert.Equal(t, []string{"c", "a", "b"}, keysInOrder(m))
+
+	// Checking for a key is not an access.
+	assert.True(t, m.ContainsKey("c"))
+	assert.Equal(t, []string{"c", "a", "b"}, keysInOrder(m))
+
+	_, ok = m.Get("missing")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"c", "a", "b"}, keysInOrder(m))
+}
+
+func TestLinkedHashMapIteration(t *testing.T) {
+	t.Parallel()
+
+	m := linkedhashmap.New(entry.New("x", 1), entry.New("y", 2), entry.New("z", 3))
+
+	keys := []string{}
+	values := []int{}
+
+	for itr, ok := m.Iterator(); ok; itr, ok = itr.Next() {
+		key, _ := itr.Key()
+		value, _ := itr.Value()
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	assert.Equal(t, []string{"x", "y", "z"}, keys)
+	assert.Equal(t, []int{1, 2, 3}, values)
+
+	keys = []string{}
+	values = []int{}
+
+	for itr, ok := m.IteratorReverse(); ok; itr, ok = itr.Next() {
+		key, _ := itr.Key()
+		value, _ := itr.Value()
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	assert.Equal(t, []string{"z", "y", "x"}, keys)
+	assert.Equal(t, []int{3, 2, 1}, values)
+
+	empty := linkedhashmap.New[string, int]()
+
+	_, ok := empty.Iterator()
+	assert.False(t, ok)
+
+	_, ok = empty.IteratorReverse()
+	assert.False(t, ok)
+}
+
+func TestLinkedHashMapKeysValuesEntriesOrder(t *testing.T) {
+	t.Parallel()
+
+	m := linkedhashmap.New(entry.New("c", 3), entry.New("a", 1), entry.New("b", 2))
+	m.Put("c", 30)
+	assert.Equal(t, []string{"c", "a", "b"}, m.Keys())
+	assert.Equal(t, []int{30, 1, 2}, m.Values())
+	assert.Equal(t, []entry.Entry[string, int]{entry.New("c", 30), entry.New("a", 1), entry.New("b", 2)}, m.Entries())
+
+	assert.Equal(t, 1, m.RemoveIf(func(key string, value int) bool { return key == "a" }))
+	assert.Equal(t, []string{"c", "b"}, m.Keys())
+	back, _, _ := m.Back()
+	assert.Equal(t, "b", back)
+}
+
+func TestLinkedHashMapSwapAccessOrder(t *testing.T) {
+	t.Parallel()
+
+	m := linkedhashmap.NewBuilder[string, string, int](compare.IdentityHashKey[string]).
+		AccessOrder(true).
+		Put("a", 1).
+		Put("b", 2).
+		Build()
+
+	old, existed := m.Swap("a", 10)
+	assert.True(t, existed)
+	assert.Equal(t, 1, old)
+	assert.Equal(t, []string{"b", "a"}, m.Keys())
+
+	// Putting an access ordered map into itself must not loop forever.
+	m.PutAllFrom(m)
+	assert.Equal(t, []string{"b", "a"}, m.Keys())
+}
@@ -6,16 +6,41 @@ import (
 	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
 )
 
-type Map[K any, V any] interface {
-	container.Container
+// ReadOnlyMap is the part of Map that does not modify the map. It is implemented by
+// immutable maps as well as by every Map.
+type ReadOnlyMap[K any, V any] interface {
 	enumerable.Enumerable[K, V]
 
+	Empty() bool
+	Size() int
+	String() string
+	Keys() []K
+	Values() []V
+	Entries() []entry.Entry[K, V]
 	Get(key K) (V, bool)
+	GetOrDefault(key K, defaultValue V) V
+	ContainsKey(key K) bool
+	ContainsAllKeys(keys ...K) bool
+	ContainsAnyKey(keys ...K) bool
+}
+
+type Map[K any, V any] interface {
+	container.Container
+	ReadOnlyMap[K, V]
+
 	Put(key K, value V)
 	PutAll(entries ...entry.Entry[K, V])
+	PutAllFrom(other ReadOnlyMap[K, V])
+	Swap(key K, value V) (old V, existed bool)
+	Replace(key K, value V) (replaced bool)
 	RemoveKey(key K) bool
 	RemoveAllKeys(keys ...K) int
-	ContainsKey(key K) bool
-	ContainsAllKeys(keys ...K) bool
-	ContainsAnyKey(keys ...K) bool
+	RemoveIf(predicate enumerable.Predicate[K, V]) int
+}
+
+// AccessOrdered is implemented by maps that can reorder their entries when they are read.
+// When AccessOrdered returns true, Get and GetOrDefault modify the map, so wrappers that
+// allow concurrent reads must treat them as writes.
+type AccessOrdered interface {
+	AccessOrdered() bool
 }
@@ -2,18 +2,25 @@ package mapp_test
 
 import (
 	"fmt"
+	"sort"
 	"testing"
 
 	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
 	"github.com/kaschnit/go-ds/pkg/containers/map/concurrentmap"
 	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/map/expiringmap"
 	"github.com/kaschnit/go-ds/pkg/containers/map/hashmap"
+	"github.com/kaschnit/go-ds/pkg/containers/map/linkedhashmap"
+	"github.com/kaschnit/go-ds/pkg/containers/map/openhashmap"
 	"github.com/stretchr/testify/assert"
 )
 
 func getMapsForTest[K comparable, V any](entries ...entry.Entry[K, V]) []mapp.Map[K, V] {
 	return []mapp.Map[K, V]{
 		hashmap.New(entries...),
+		linkedhashmap.New(entries...),
+		expiringmap.New(0, entries...),
+		openhashmap.New(entries...),
 		concurrentmap.MakeThreadSafe[K, V](hashmap.New(entries...)),
 	}
 }
@@ -983,3 +990,198 @@ func TestFindNotOk(t *testing.T) {
 		})
 	}
 }
+
+func TestKeysValuesEntries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		initial        []entry.Entry[string, int]
+		expectedKeys   []string
+		expectedValues []int
+	}{
+		{
+			name:           "empty",
+			initial:        []entry.Entry[string, int]{},
+			expectedKeys:   []string{},
+			expectedValues: []int{},
+		},
+		{
+			name: "3 items",
+			initial: []entry.Entry[string, int]{
+				entry.New("b", 2),
+				entry.New("a", 1),
+				entry.New("c", 1),
+			},
+			expectedKeys:   []string{"a", "b", "c"},
+			expectedValues: []int{1, 1, 2},
+		},
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			maps := getMapsForTest(testCase.initial...)
+			for i := range maps {
+				m := maps[i]
+				t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+					t.Parallel()
+
+					keys := m.Keys()
+					sort.Strings(keys)
+					assert.Equal(t, testCase.expectedKeys, keys)
+
+					values := m.Values()
+					sort.Ints(values)
+					assert.Equal(t, testCase.expectedValues, values)
+
+					entries := m.Entries()
+					assert.Len(t, entries, len(testCase.initial))
+					for _, e := range entries {
+						value, ok := m.Get(e.Key())
+						assert.True(t, ok)
+						assert.Equal(t, value, e.Value())
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestGetOrDefault(t *testing.T) {
+	t.Parallel()
+
+	maps := getMapsForTest(entry.New("a", 1), entry.New("zero", 0))
+	for i := range maps {
+		m := maps[i]
+		t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, 1, m.GetOrDefault("a", 100))
+			assert.Equal(t, 0, m.GetOrDefault("zero", 100))
+			assert.Equal(t, 100, m.GetOrDefault("missing", 100))
+			assert.False(t, m.ContainsKey("missing"))
+		})
+	}
+}
+
+func TestSwap(t *testing.T) {
+	t.Parallel()
+
+	maps := getMapsForTest(entry.New("a", 1))
+	for i := range maps {
+		m := maps[i]
+		t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+			t.Parallel()
+
+			old, existed := m.Swap("a", 2)
+			assert.True(t, existed)
+			assert.Equal(t, 1, old)
+			assert.Equal(t, 2, m.GetOrDefault("a", 0))
+
+			old, existed = m.Swap("b", 3)
+			assert.False(t, existed)
+			assert.Equal(t, 0, old)
+			assert.Equal(t, 3, m.GetOrDefault("b", 0))
+			assert.Equal(t, 2, m.Size())
+		})
+	}
+}
+
+func TestReplace(t *testing.T) {
+	t.Parallel()
+
+	maps := getMapsForTest(entry.New("a", 1))
+	for i := range maps {
+		m := maps[i]
+		t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+			t.Parallel()
+
+			assert.True(t, m.Replace("a", 2))
+			assert.Equal(t, 2, m.GetOrDefault("a", 0))
+
+			assert.False(t, m.Replace("b", 3))
+			assert.False(t, m.ContainsKey("b"))
+			assert.Equal(t, 1, m.Size())
+		})
+	}
+}
+
+func TestRemoveIf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		predicate       func(key string, value int) bool
+		expectedRemoved int
+		expectedKeys    []string
+	}{
+		{
+			name:            "none",
+			predicate:       func(key string, value int) bool { return value > 10 },
+			expectedRemoved: 0,
+			expectedKeys:    []string{"a", "b", "c", "d"},
+		},
+		{
+			name:            "some",
+			predicate:       func(key string, value int) bool { return value%2 == 0 },
+			expectedRemoved: 2,
+			expectedKeys:    []string{"a", "c"},
+		},
+		{
+			name:            "all",
+			predicate:       func(key string, value int) bool { return key != "" },
+			expectedRemoved: 4,
+			expectedKeys:    []string{},
+		},
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			maps := getMapsForTest(entry.New("a", 1), entry.New("b", 2), entry.New("c", 3), entry.New("d", 4))
+			for i := range maps {
+				m := maps[i]
+				t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+					t.Parallel()
+
+					assert.Equal(t, testCase.expectedRemoved, m.RemoveIf(testCase.predicate))
+
+					keys := m.Keys()
+					sort.Strings(keys)
+					assert.Equal(t, testCase.expectedKeys, keys)
+					assert.Equal(t, len(testCase.expectedKeys), m.Size())
+				})
+			}
+		})
+	}
+}
+
+func TestPutAllFrom(t *testing.T) {
+	t.Parallel()
+
+	maps := getMapsForTest(entry.New("a", 1), entry.New("b", 2))
+	others := getMapsForTest(entry.New("b", 20), entry.New("c", 30))
+	for i := range maps {
+		m := maps[i]
+		other := others[i]
+		t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+			t.Parallel()
+
+			m.PutAllFrom(other)
+			assert.Equal(t, 3, m.Size())
+			assert.Equal(t, 1, m.GetOrDefault("a", 0))
+			assert.Equal(t, 20, m.GetOrDefault("b", 0))
+			assert.Equal(t, 30, m.GetOrDefault("c", 0))
+			assert.Equal(t, 2, other.Size())
+
+			// Putting a map into itself changes nothing.
+			m.PutAllFrom(m)
+			assert.Equal(t, 3, m.Size())
+		})
+	}
+}
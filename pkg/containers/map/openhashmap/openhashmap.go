@@ -0,0 +1,406 @@
+package openhashmap
+
+import (
+	"math/bits"
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+)
+
+const (
+	minCapacity = 8
+
+	// The table grows once it is more than maxLoadNumerator/maxLoadDenominator full.
+	maxLoadNumerator   = 7
+	maxLoadDenominator = 8
+
+	// fibonacciMultiplier is 2^64 divided by the golden ratio. Multiplying by it spreads
+	// the bits of a weak hash, such as an identity hash of small integers, into the high bits
+	// that are used to pick a slot.
+	fibonacciMultiplier = 0x9e3779b97f4a7c15
+)
+
+type slot[K any, V any] struct {
+	key   K
+	value V
+	hash  uint64
+
+	// distance is one more than how far the slot is from the slot its hash points to,
+	// so that zero means the slot is empty.
+	distance uint32
+}
+
+type Builder[K any, V any] struct {
+	hasher   compare.Hasher[K]
+	equal    compare.Equal[K]
+	capacity int
+	entries  []entry.Entry[K, V]
+}
+
+func NewBuilder[K any, V any](hasher compare.Hasher[K], equal compare.Equal[K]) *Builder[K, V] {
+	return &Builder[K, V]{
+		hasher:   hasher,
+		equal:    equal,
+		capacity: 0,
+		entries:  []entry.Entry[K, V]{},
+	}
+}
+
+// Capacity sets how many entries the map can hold before it needs to grow.
+func (b *Builder[K, V]) Capacity(capacity int) *Builder[K, V] {
+	b.capacity = capacity
+
+	return b
+}
+
+func (b *Builder[K, V]) Put(key K, value V) *Builder[K, V] {
+	b.entries = append(b.entries, entry.New(key, value))
+
+	return b
+}
+
+func (b *Builder[K, V]) PutAll(entries ...entry.Entry[K, V]) *Builder[K, V] {
+	b.entries = append(b.entries, entries...)
+
+	return b
+}
+
+func (b *Builder[K, V]) Build() *OpenHashMap[K, V] {
+	m := &OpenHashMap[K, V]{
+		hasher: b.hasher,
+		equal:  b.equal,
+		slots:  nil,
+		shift:  0,
+		size:   0,
+	}
+	m.resize(max(b.capacity, len(b.entries)))
+	m.PutAll(b.entries...)
+
+	return m
+}
+
+// OpenHashMap is a hash map that stores its entries in a single slice using open addressing
+// with Robin Hood hashing. Keys are hashed and compared with the given functions directly,
+// so keys such as byte slices can be used without converting them to a comparable key first.
+type OpenHashMap[K any, V any] struct {
+	hasher compare.Hasher[K]
+	equal  compare.Equal[K]
+
+	// slots has a power of two length, shift is 64 minus the log2 of the length.
+	slots []slot[K, V]
+	shift uint
+	size  int
+}
+
+func New[K comparable, V any](entries ...entry.Entry[K, V]) *OpenHashMap[K, V] {
+	return NewBuilder[K, V](compare.DefaultHasher[K], compare.DefaultEqual[K]).PutAll(entries...).Build()
+}
+
+func (m *OpenHashMap[K, V]) Empty() bool {
+	return m.Size() == 0
+}
+
+func (m *OpenHashMap[K, V]) Size() int {
+	return m.size
+}
+
+func (m *OpenHashMap[K, V]) Clear() {
+	for i := range m.slots {
+		m.slots[i] = slot[K, V]{}
+	}
+
+	m.size = 0
+}
+
+func (m *OpenHashMap[K, V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("OpenHashMap\n")
+
+	strs := make([]string, 0, m.size)
+	m.ForEach(func(key K, value V) {
+		strs = append(strs, entry.NewRef(key, value).String())
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (m *OpenHashMap[K, V]) ForEach(op enumerable.Op[K, V]) {
+	for i := range m.slots {
+		if m.slots[i].distance != 0 {
+			op(m.slots[i].key, m.slots[i].value)
+		}
+	}
+}
+
+func (m *OpenHashMap[K, V]) Any(predicate enumerable.Predicate[K, V]) bool {
+	_, _, found := m.Find(predicate)
+
+	return found
+}
+
+func (m *OpenHashMap[K, V]) All(predicate enumerable.Predicate[K, V]) bool {
+	for i := range m.slots {
+		if m.slots[i].distance != 0 && !predicate(m.slots[i].key, m.slots[i].value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *OpenHashMap[K, V]) Find(predicate enumerable.Predicate[K, V]) (K, V, bool) {
+	for i := range m.slots {
+		if m.slots[i].distance != 0 && predicate(m.slots[i].key, m.slots[i].value) {
+			return m.slots[i].key, m.slots[i].value, true
+		}
+	}
+
+	return *new(K), *new(V), false
+}
+
+func (m *OpenHashMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	m.ForEach(func(key K, _ V) {
+		keys = append(keys, key)
+	})
+
+	return keys
+}
+
+func (m *OpenHashMap[K, V]) Values() []V {
+	values := make([]V, 0, m.size)
+	m.ForEach(func(_ K, value V) {
+		values = append(values, value)
+	})
+
+	return values
+}
+
+func (m *OpenHashMap[K, V]) Entries() []entry.Entry[K, V] {
+	entries := make([]entry.Entry[K, V], 0, m.size)
+	m.ForEach(func(key K, value V) {
+		entries = append(entries, entry.New(key, value))
+	})
+
+	return entries
+}
+
+func (m *OpenHashMap[K, V]) Get(key K) (V, bool) {
+	index, ok := m.find(key)
+	if !ok {
+		return *new(V), false
+	}
+
+	return m.slots[index].value, true
+}
+
+func (m *OpenHashMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, ok := m.Get(key); ok {
+		return value
+	}
+
+	return defaultValue
+}
+
+func (m *OpenHashMap[K, V]) Put(key K, value V) {
+	m.Swap(key, value)
+}
+
+func (m *OpenHashMap[K, V]) PutAll(entries ...entry.Entry[K, V]) {
+	for _, entry := range entries {
+		m.Put(entry.Key(), entry.Value())
+	}
+}
+
+func (m *OpenHashMap[K, V]) PutAllFrom(other mapp.ReadOnlyMap[K, V]) {
+	m.PutAll(other.Entries()...)
+}
+
+func (m *OpenHashMap[K, V]) Swap(key K, value V) (V, bool) {
+	hash := m.hasher(key)
+	if index, ok := m.findHashed(key, hash); ok {
+		old := m.slots[index].value
+		m.slots[index].value = value
+
+		return old, true
+	}
+
+	if (m.size+1)*maxLoadDenominator > len(m.slots)*maxLoadNumerator {
+		m.resize(2 * (m.size + 1))
+	}
+
+	m.insert(slot[K, V]{
+		key:      key,
+		value:    value,
+		hash:     hash,
+		distance: 1,
+	})
+
+	return *new(V), false
+}
+
+func (m *OpenHashMap[K, V]) Replace(key K, value V) bool {
+	index, ok := m.find(key)
+	if !ok {
+		return false
+	}
+
+	m.slots[index].value = value
+
+	return true
+}
+
+func (m *OpenHashMap[K, V]) RemoveKey(key K) bool {
+	index, ok := m.find(key)
+	if !ok {
+		return false
+	}
+
+	m.removeAt(index)
+
+	return true
+}
+
+func (m *OpenHashMap[K, V]) RemoveAllKeys(keys ...K) int {
+	removed := 0
+
+	for _, key := range keys {
+		if m.RemoveKey(key) {
+			removed++
+		}
+	}
+
+	return removed
+}
+
+func (m *OpenHashMap[K, V]) RemoveIf(predicate enumerable.Predicate[K, V]) int {
+	// Removing shifts later entries back, so find the keys first and remove them after.
+	keys := []K{}
+	m.ForEach(func(key K, value V) {
+		if predicate(key, value) {
+			keys = append(keys, key)
+		}
+	})
+
+	return m.RemoveAllKeys(keys...)
+}
+
+func (m *OpenHashMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.find(key)
+
+	return ok
+}
+
+func (m *OpenHashMap[K, V]) ContainsAllKeys(keys ...K) bool {
+	for _, key := range keys {
+		if !m.ContainsKey(key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *OpenHashMap[K, V]) ContainsAnyKey(keys ...K) bool {
+	for _, key := range keys {
+		if m.ContainsKey(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// home returns the slot that the hash points to.
+func (m *OpenHashMap[K, V]) home(hash uint64) int {
+	return int((hash * fibonacciMultiplier) >> m.shift)
+}
+
+func (m *OpenHashMap[K, V]) next(index int) int {
+	return (index + 1) & (len(m.slots) - 1)
+}
+
+func (m *OpenHashMap[K, V]) find(key K) (int, bool) {
+	return m.findHashed(key, m.hasher(key))
+}
+
+func (m *OpenHashMap[K, V]) findHashed(key K, hash uint64) (int, bool) {
+	index := m.home(hash)
+	for distance := uint32(1); ; distance++ {
+		s := &m.slots[index]
+
+		// Entries are ordered by distance along a probe sequence, so once an entry is closer
+		// to its home than the key would be, the key cannot be further along.
+		if s.distance < distance {
+			return 0, false
+		}
+
+		if s.hash == hash && m.equal(s.key, key) {
+			return index, true
+		}
+
+		index = m.next(index)
+	}
+}
+
+// insert puts an entry for a key that is not in the map, which must have room for it.
+func (m *OpenHashMap[K, V]) insert(entry slot[K, V]) {
+	index := m.home(entry.hash)
+	for {
+		s := &m.slots[index]
+		if s.distance == 0 {
+			*s = entry
+			m.size++
+
+			return
+		}
+
+		// Take the slot from an entry that is closer to its home, and carry that entry on
+		// instead. This keeps the distances of all entries close to the average.
+		if s.distance < entry.distance {
+			*s, entry = entry, *s
+		}
+
+		index = m.next(index)
+		entry.distance++
+	}
+}
+
+func (m *OpenHashMap[K, V]) removeAt(index int) {
+	// Shift the following entries of the probe sequence back by one, rather than leaving
+	// a tombstone, so lookups never have to skip over removed entries.
+	for next := m.next(index); m.slots[next].distance > 1; next = m.next(next) {
+		m.slots[index] = m.slots[next]
+		m.slots[index].distance--
+		index = next
+	}
+
+	m.slots[index] = slot[K, V]{}
+	m.size--
+}
+
+// resize reallocates the table with room for the given number of entries, and rehashes
+// the existing ones into it.
+func (m *OpenHashMap[K, V]) resize(entries int) {
+	length := minCapacity
+	for length*maxLoadNumerator < entries*maxLoadDenominator {
+		length *= 2
+	}
+
+	old := m.slots
+	m.slots = make([]slot[K, V], length)
+	m.shift = uint(64 - bits.TrailingZeros(uint(length)))
+	m.size = 0
+
+	for i := range old {
+		if old[i].distance != 0 {
+			old[i].distance = 1
+			m.insert(old[i])
+		}
+	}
+}
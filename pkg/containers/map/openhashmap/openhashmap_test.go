@@ -0,0 +1,222 @@
+package openhashmap_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/map/hashmap"
+	"github.com/kaschnit/go-ds/pkg/containers/map/openhashmap"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that OpenHashMap implements Map.
+var _ mapp.Map[string, int] = &openhashmap.OpenHashMap[string, int]{}
+
+type compositeKey struct {
+	tenant string
+	id     []byte
+}
+
+func hashCompositeKey(key compositeKey) uint64 {
+	return compare.StringHasher(key.tenant)*31 + compare.BytesHasher(key.id)
+}
+
+func equalCompositeKeys(left compositeKey, right compositeKey) bool {
+	return left.tenant == right.tenant && compare.BytesEqual(left.id, right.id)
+}
+
+func TestOpenHashMapString(t *testing.T) {
+	t.Parallel()
+
+	m := openhashmap.New[string, int]()
+	assert.Equal(t, "OpenHashMap\n", m.String())
+
+	m.Put("a", 1)
+	assert.Equal(t, "OpenHashMap\nEntry{Key:a, Value:1}", m.String())
+}
+
+func TestOpenHashMapBytesKeys(t *testing.T) {
+	t.Parallel()
+
+	m := openhashmap.NewBuilder[[]byte, int](compare.BytesHasher, compare.BytesEqual).
+		Put([]byte("foo"), 1).
+		Build()
+	m.Put([]byte("bar"), 2)
+	m.Put([]byte("foo"), 3)
+
+	assert.Equal(t, 2, m.Size())
+	assert.Equal(t, 3, m.GetOrDefault([]byte("foo"), 0))
+	assert.Equal(t, 2, m.GetOrDefault([]byte("bar"), 0))
+	assert.True(t, m.RemoveKey([]byte("foo")))
+	assert.False(t, m.ContainsKey([]byte("foo")))
+}
+
+func TestOpenHashMapCompositeKeys(t *testing.T) {
+	t.Parallel()
+
+	m := openhashmap.NewBuilder[compositeKey, string](hashCompositeKey, equalCompositeKeys).Build()
+	m.Put(compositeKey{tenant: "a", id: []byte{1, 2}}, "first")
+	m.Put(compositeKey{tenant: "b", id: []byte{1, 2}}, "second")
+
+	value, ok := m.Get(compositeKey{tenant: "a", id: []byte{1, 2}})
+	assert.True(t, ok)
+	assert.Equal(t, "first", value)
+
+	_, ok = m.Get(compositeKey{tenant: "a", id: []byte{1}})
+	assert.False(t, ok)
+}
+
+func TestOpenHashMapCollidingHashes(t *testing.T) {
+	t.Parallel()
+
+	// Every key has the same hash, so every entry is in one long probe sequence.
+	m := openhashmap.NewBuilder[int, int](func(int) uint64 { return 7 }, compare.DefaultEqual[int]).Build()
+	for i := 0; i < 100; i++ {
+		m.Put(i, i*10)
+	}
+
+	for i := 0; i < 100; i += 2 {
+		assert.True(t, m.RemoveKey(i))
+	}
+
+	assert.Equal(t, 50, m.Size())
+	for i := 0; i < 100; i++ {
+		value, ok := m.Get(i)
+		assert.Equal(t, i%2 == 1, ok)
+		if ok {
+			assert.Equal(t, i*10, value)
+		}
+	}
+}
+
+func TestOpenHashMapCapacity(t *testing.T) {
+	t.Parallel()
+
+	m := openhashmap.NewBuilder[int, int](compare.DefaultHasher[int], compare.DefaultEqual[int]).
+		Capacity(1000).
+		Build()
+	for i := 0; i < 1000; i++ {
+		m.Put(i, i)
+	}
+
+	assert.Equal(t, 1000, m.Size())
+	m.Clear()
+	assert.True(t, m.Empty())
+	assert.False(t, m.ContainsKey(1))
+}
+
+func TestOpenHashMapRandomOperations(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(42))
+	m := openhashmap.New[int, int]()
+	expected := map[int]int{}
+
+	for i := 0; i < 20000; i++ {
+		key := rng.Intn(500)
+
+		switch rng.Intn(3) {
+		case 0:
+			_, existed := expected[key]
+			assert.Equal(t, existed, m.RemoveKey(key))
+			delete(expected, key)
+		case 1:
+			value := rng.Int()
+			old, existed := m.Swap(key, value)
+			expectedOld, expectedExisted := expected[key]
+			assert.Equal(t, expectedExisted, existed)
+			assert.Equal(t, expectedOld, old)
+			expected[key] = value
+		default:
+			value, ok := m.Get(key)
+			expectedValue, expectedOk := expected[key]
+			assert.Equal(t, expectedOk, ok)
+			assert.Equal(t, expectedValue, value)
+		}
+	}
+
+	assert.Equal(t, len(expected), m.Size())
+	m.ForEach(func(key int, value int) {
+		assert.Equal(t, expected[key], value)
+	})
+}
+
+func benchmarkKeys(n int) [][]byte {
+	keys := make([][]byte, n)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("key-%d", i))
+	}
+
+	return keys
+}
+
+func BenchmarkPutBytes(b *testing.B) {
+	keys := benchmarkKeys(10000)
+
+	b.Run("OpenHashMap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m := openhashmap.NewBuilder[[]byte, int](compare.BytesHasher, compare.BytesEqual).Build()
+			for j, key := range keys {
+				m.Put(key, j)
+			}
+		}
+	})
+
+	b.Run("HashMap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m := hashmap.NewBuilder[[]byte, string, int](func(key []byte) string { return string(key) }).Build()
+			for j, key := range keys {
+				m.Put(key, j)
+			}
+		}
+	})
+}
+
+func BenchmarkGetBytes(b *testing.B) {
+	keys := benchmarkKeys(10000)
+
+	open := openhashmap.NewBuilder[[]byte, int](compare.BytesHasher, compare.BytesEqual).Build()
+	hashed := hashmap.NewBuilder[[]byte, string, int](func(key []byte) string { return string(key) }).Build()
+	for j, key := range keys {
+		open.Put(key, j)
+		hashed.Put(key, j)
+	}
+
+	b.Run("OpenHashMap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			open.Get(keys[i%len(keys)])
+		}
+	})
+
+	b.Run("HashMap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			hashed.Get(keys[i%len(keys)])
+		}
+	})
+}
+
+func BenchmarkGetInt(b *testing.B) {
+	entries := make([]entry.Entry[int, int], 10000)
+	for i := range entries {
+		entries[i] = entry.New(i, i)
+	}
+
+	open := openhashmap.New(entries...)
+	hashed := hashmap.New(entries...)
+
+	b.Run("OpenHashMap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			open.Get(i % len(entries))
+		}
+	})
+
+	b.Run("HashMap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			hashed.Get(i % len(entries))
+		}
+	})
+}
@@ -0,0 +1,279 @@
+package persistentmap
+
+import (
+	"math/bits"
+)
+
+const (
+	bitsPerLevel = 5
+	levelMask    = 1<<bitsPerLevel - 1
+	hashBits     = 64
+)
+
+// editToken marks the nodes that a builder created and may therefore modify in place.
+// It must not be zero-sized, so that every token has a distinct address.
+type editToken struct {
+	_ byte
+}
+
+type leaf[K any, HK comparable, V any] struct {
+	key       K
+	hashedKey HK
+	hash      uint64
+	value     V
+}
+
+// node is a node of the trie. Each level of the trie uses the next few bits of the hash
+// to choose one of 32 positions. A position either holds a single entry, whose bit is set
+// in leafMap, or a child node for the entries that share those bits, whose bit is set in
+// childMap. Leaves and children are stored densely, in the order of their positions.
+// Once the hash is used up, entries whose hashes are equal are kept in a collision node,
+// which has no bitmaps and just a list of leaves.
+type node[K any, HK comparable, V any] struct {
+	leafMap  uint32
+	childMap uint32
+	leaves   []leaf[K, HK, V]
+	children []*node[K, HK, V]
+	edit     *editToken
+}
+
+func newNode[K any, HK comparable, V any](edit *editToken) *node[K, HK, V] {
+	return &node[K, HK, V]{
+		leafMap:  0,
+		childMap: 0,
+		leaves:   nil,
+		children: nil,
+		edit:     edit,
+	}
+}
+
+func position(hash uint64, shift uint) uint32 {
+	return 1 << ((hash >> shift) & levelMask)
+}
+
+func index(bitmap uint32, bit uint32) int {
+	return bits.OnesCount32(bitmap & (bit - 1))
+}
+
+// editable returns the node if the edit token owns it, otherwise a copy that it owns.
+func (n *node[K, HK, V]) editable(edit *editToken) *node[K, HK, V] {
+	if edit != nil && n.edit == edit {
+		return n
+	}
+
+	return &node[K, HK, V]{
+		leafMap:  n.leafMap,
+		childMap: n.childMap,
+		leaves:   append([]leaf[K, HK, V](nil), n.leaves...),
+		children: append([]*node[K, HK, V](nil), n.children...),
+		edit:     edit,
+	}
+}
+
+func (n *node[K, HK, V]) get(hashedKey HK, hash uint64, shift uint) (*leaf[K, HK, V], bool) {
+	for {
+		if shift >= hashBits {
+			for i := range n.leaves {
+				if n.leaves[i].hashedKey == hashedKey {
+					return &n.leaves[i], true
+				}
+			}
+
+			return nil, false
+		}
+
+		bit := position(hash, shift)
+		if n.leafMap&bit != 0 {
+			l := &n.leaves[index(n.leafMap, bit)]
+
+			return l, l.hashedKey == hashedKey
+		}
+
+		if n.childMap&bit == 0 {
+			return nil, false
+		}
+
+		n = n.children[index(n.childMap, bit)]
+		shift += bitsPerLevel
+	}
+}
+
+// put returns the node with the entry put in it, and whether the entry's key is new.
+func (n *node[K, HK, V]) put(edit *editToken, entry leaf[K, HK, V], shift uint) (*node[K, HK, V], bool) {
+	if shift >= hashBits {
+		for i := range n.leaves {
+			if n.leaves[i].hashedKey == entry.hashedKey {
+				result := n.editable(edit)
+				result.leaves[i] = entry
+
+				return result, false
+			}
+		}
+
+		result := n.editable(edit)
+		result.leaves = append(result.leaves, entry)
+
+		return result, true
+	}
+
+	bit := position(entry.hash, shift)
+
+	if n.leafMap&bit != 0 {
+		i := index(n.leafMap, bit)
+		existing := n.leaves[i]
+
+		result := n.editable(edit)
+		if existing.hashedKey == entry.hashedKey {
+			result.leaves[i] = entry
+
+			return result, false
+		}
+
+		// Both entries share this position, so push them down into a new child.
+		child := merge(edit, existing, entry, shift+bitsPerLevel)
+		result.leaves = removeAt(result.leaves, i)
+		result.leafMap ^= bit
+		result.childMap |= bit
+		result.children = insertAt(result.children, index(result.childMap, bit), child)
+
+		return result, true
+	}
+
+	if n.childMap&bit != 0 {
+		i := index(n.childMap, bit)
+
+		child, added := n.children[i].put(edit, entry, shift+bitsPerLevel)
+		if child == n.children[i] {
+			return n, added
+		}
+
+		result := n.editable(edit)
+		result.children[i] = child
+
+		return result, added
+	}
+
+	result := n.editable(edit)
+	result.leafMap |= bit
+	result.leaves = insertAt(result.leaves, index(result.leafMap, bit), entry)
+
+	return result, true
+}
+
+// remove returns the node with the key removed from it, and whether the key was there.
+func (n *node[K, HK, V]) remove(edit *editToken, hashedKey HK, hash uint64, shift uint) (*node[K, HK, V], bool) {
+	if shift >= hashBits {
+		for i := range n.leaves {
+			if n.leaves[i].hashedKey == hashedKey {
+				result := n.editable(edit)
+				result.leaves = removeAt(result.leaves, i)
+
+				return result, true
+			}
+		}
+
+		return n, false
+	}
+
+	bit := position(hash, shift)
+
+	if n.leafMap&bit != 0 {
+		i := index(n.leafMap, bit)
+		if n.leaves[i].hashedKey != hashedKey {
+			return n, false
+		}
+
+		result := n.editable(edit)
+		result.leaves = removeAt(result.leaves, i)
+		result.leafMap ^= bit
+
+		return result, true
+	}
+
+	if n.childMap&bit == 0 {
+		return n, false
+	}
+
+	i := index(n.childMap, bit)
+
+	child, removed := n.children[i].remove(edit, hashedKey, hash, shift+bitsPerLevel)
+	if !removed {
+		return n, false
+	}
+
+	result := n.editable(edit)
+
+	// A child that is left with a single entry is replaced by the entry, so that the trie
+	// is never deeper than it needs to be.
+	if len(child.children) == 0 && len(child.leaves) == 1 {
+		result.children = removeAt(result.children, i)
+		result.childMap ^= bit
+		result.leafMap |= bit
+		result.leaves = insertAt(result.leaves, index(result.leafMap, bit), child.leaves[0])
+	} else {
+		result.children[i] = child
+	}
+
+	return result, true
+}
+
+func (n *node[K, HK, V]) forEach(op func(l *leaf[K, HK, V]) bool) bool {
+	for i := range n.leaves {
+		if !op(&n.leaves[i]) {
+			return false
+		}
+	}
+
+	for _, child := range n.children {
+		if !child.forEach(op) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// merge creates a node holding two entries with different keys.
+func merge[K any, HK comparable, V any](edit *editToken, first leaf[K, HK, V], second leaf[K, HK, V], shift uint) *node[K, HK, V] {
+	result := newNode[K, HK, V](edit)
+
+	if shift >= hashBits {
+		result.leaves = []leaf[K, HK, V]{first, second}
+
+		return result
+	}
+
+	firstBit := position(first.hash, shift)
+	secondBit := position(second.hash, shift)
+
+	if firstBit == secondBit {
+		result.childMap = firstBit
+		result.children = []*node[K, HK, V]{merge(edit, first, second, shift+bitsPerLevel)}
+
+		return result
+	}
+
+	result.leafMap = firstBit | secondBit
+	if firstBit < secondBit {
+		result.leaves = []leaf[K, HK, V]{first, second}
+	} else {
+		result.leaves = []leaf[K, HK, V]{second, first}
+	}
+
+	return result
+}
+
+func insertAt[T any](values []T, i int, value T) []T {
+	values = append(values, *new(T))
+	copy(values[i+1:], values[i:])
+	values[i] = value
+
+	return values
+}
+
+func removeAt[T any](values []T, i int) []T {
+	copy(values[i:], values[i+1:])
+	values[len(values)-1] = *new(T)
+
+	return values[:len(values)-1]
+}
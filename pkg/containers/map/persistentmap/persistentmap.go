@@ -0,0 +1,295 @@
+package persistentmap
+
+import (
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+)
+
+// Builder builds a PersistentMap. Unlike the map, the builder is modified in place, which makes
+// it much cheaper to put many entries at once than putting them into a map one at a time.
+// A builder can be reused after Build, without affecting the maps it has already built.
+type Builder[K any, HK comparable, V any] struct {
+	hashkey compare.HashKey[K, HK]
+	hasher  compare.Hasher[HK]
+	root    *node[K, HK, V]
+	size    int
+	edit    *editToken
+}
+
+func NewBuilder[K any, HK comparable, V any](hashkey compare.HashKey[K, HK]) *Builder[K, HK, V] {
+	edit := &editToken{}
+
+	return &Builder[K, HK, V]{
+		hashkey: hashkey,
+		hasher:  compare.DefaultHasher[HK],
+		root:    newNode[K, HK, V](edit),
+		size:    0,
+		edit:    edit,
+	}
+}
+
+// Hasher sets the function used to hash the keys after they are converted by the HashKey.
+// Defaults to compare.DefaultHasher.
+func (b *Builder[K, HK, V]) Hasher(hasher compare.Hasher[HK]) *Builder[K, HK, V] {
+	entries := []leaf[K, HK, V]{}
+	b.root.forEach(func(l *leaf[K, HK, V]) bool {
+		entries = append(entries, *l)
+
+		return true
+	})
+
+	b.hasher = hasher
+	b.root = newNode[K, HK, V](b.edit)
+	b.size = 0
+
+	for _, l := range entries {
+		b.Put(l.key, l.value)
+	}
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) Put(key K, value V) *Builder[K, HK, V] {
+	hashedKey := b.hashkey(key)
+
+	root, added := b.root.put(b.edit, leaf[K, HK, V]{
+		key:       key,
+		hashedKey: hashedKey,
+		hash:      b.hasher(hashedKey),
+		value:     value,
+	}, 0)
+
+	b.root = root
+	if added {
+		b.size++
+	}
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) PutAll(entries ...entry.Entry[K, V]) *Builder[K, HK, V] {
+	for _, entry := range entries {
+		b.Put(entry.Key(), entry.Value())
+	}
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) Remove(key K) *Builder[K, HK, V] {
+	hashedKey := b.hashkey(key)
+
+	root, removed := b.root.remove(b.edit, hashedKey, b.hasher(hashedKey), 0)
+
+	b.root = root
+	if removed {
+		b.size--
+	}
+
+	return b
+}
+
+func (b *Builder[K, HK, V]) Build() *PersistentMap[K, HK, V] {
+	m := &PersistentMap[K, HK, V]{
+		hashkey: b.hashkey,
+		hasher:  b.hasher,
+		root:    b.root,
+		size:    b.size,
+	}
+
+	// The map now shares the builder's nodes, so the builder must copy them from here on.
+	b.edit = &editToken{}
+
+	return m
+}
+
+// PersistentMap is an immutable map implemented as a hash array mapped trie. Put and Remove
+// return a new map and leave the map they are called on unchanged. The new map shares all of
+// the trie with the old one except for the path to the changed entry, so making a modified
+// copy of a map costs about as much as a lookup.
+// Since it never changes, a PersistentMap is safe to share between goroutines.
+type PersistentMap[K any, HK comparable, V any] struct {
+	hashkey compare.HashKey[K, HK]
+	hasher  compare.Hasher[HK]
+	root    *node[K, HK, V]
+	size    int
+}
+
+func New[K comparable, V any](entries ...entry.Entry[K, V]) *PersistentMap[K, K, V] {
+	return NewBuilder[K, K, V](compare.IdentityHashKey[K]).PutAll(entries...).Build()
+}
+
+// ToBuilder returns a builder that starts out with the entries of the map.
+// Modifying the builder does not modify the map.
+func (m *PersistentMap[K, HK, V]) ToBuilder() *Builder[K, HK, V] {
+	return &Builder[K, HK, V]{
+		hashkey: m.hashkey,
+		hasher:  m.hasher,
+		root:    m.root,
+		size:    m.size,
+		edit:    &editToken{},
+	}
+}
+
+func (m *PersistentMap[K, HK, V]) Empty() bool {
+	return m.Size() == 0
+}
+
+func (m *PersistentMap[K, HK, V]) Size() int {
+	return m.size
+}
+
+func (m *PersistentMap[K, HK, V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("PersistentMap\n")
+
+	strs := make([]string, 0, m.size)
+	m.ForEach(func(key K, value V) {
+		strs = append(strs, entry.NewRef(key, value).String())
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (m *PersistentMap[K, HK, V]) ForEach(op enumerable.Op[K, V]) {
+	m.root.forEach(func(l *leaf[K, HK, V]) bool {
+		op(l.key, l.value)
+
+		return true
+	})
+}
+
+func (m *PersistentMap[K, HK, V]) Any(predicate enumerable.Predicate[K, V]) bool {
+	_, _, found := m.Find(predicate)
+
+	return found
+}
+
+func (m *PersistentMap[K, HK, V]) All(predicate enumerable.Predicate[K, V]) bool {
+	return m.root.forEach(func(l *leaf[K, HK, V]) bool {
+		return predicate(l.key, l.value)
+	})
+}
+
+func (m *PersistentMap[K, HK, V]) Find(predicate enumerable.Predicate[K, V]) (K, V, bool) {
+	var found *leaf[K, HK, V]
+
+	m.root.forEach(func(l *leaf[K, HK, V]) bool {
+		if predicate(l.key, l.value) {
+			found = l
+
+			return false
+		}
+
+		return true
+	})
+
+	if found == nil {
+		return *new(K), *new(V), false
+	}
+
+	return found.key, found.value, true
+}
+
+func (m *PersistentMap[K, HK, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	m.ForEach(func(key K, _ V) {
+		keys = append(keys, key)
+	})
+
+	return keys
+}
+
+func (m *PersistentMap[K, HK, V]) Values() []V {
+	values := make([]V, 0, m.size)
+	m.ForEach(func(_ K, value V) {
+		values = append(values, value)
+	})
+
+	return values
+}
+
+func (m *PersistentMap[K, HK, V]) Entries() []entry.Entry[K, V] {
+	entries := make([]entry.Entry[K, V], 0, m.size)
+	m.ForEach(func(key K, value V) {
+		entries = append(entries, entry.New(key, value))
+	})
+
+	return entries
+}
+
+func (m *PersistentMap[K, HK, V]) Get(key K) (V, bool) {
+	hashedKey := m.hashkey(key)
+
+	l, ok := m.root.get(hashedKey, m.hasher(hashedKey), 0)
+	if !ok {
+		return *new(V), false
+	}
+
+	return l.value, true
+}
+
+func (m *PersistentMap[K, HK, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, ok := m.Get(key); ok {
+		return value
+	}
+
+	return defaultValue
+}
+
+func (m *PersistentMap[K, HK, V]) ContainsKey(key K) bool {
+	_, ok := m.Get(key)
+
+	return ok
+}
+
+func (m *PersistentMap[K, HK, V]) ContainsAllKeys(keys ...K) bool {
+	for _, key := range keys {
+		if !m.ContainsKey(key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *PersistentMap[K, HK, V]) ContainsAnyKey(keys ...K) bool {
+	for _, key := range keys {
+		if m.ContainsKey(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Put returns a new map with the value set for the key.
+func (m *PersistentMap[K, HK, V]) Put(key K, value V) *PersistentMap[K, HK, V] {
+	return m.ToBuilder().Put(key, value).Build()
+}
+
+// PutAll returns a new map with all of the entries put in it.
+func (m *PersistentMap[K, HK, V]) PutAll(entries ...entry.Entry[K, V]) *PersistentMap[K, HK, V] {
+	return m.ToBuilder().PutAll(entries...).Build()
+}
+
+// Remove returns a new map without the key. Returns the same map if the key is not in it.
+func (m *PersistentMap[K, HK, V]) Remove(key K) *PersistentMap[K, HK, V] {
+	hashedKey := m.hashkey(key)
+
+	root, removed := m.root.remove(nil, hashedKey, m.hasher(hashedKey), 0)
+	if !removed {
+		return m
+	}
+
+	return &PersistentMap[K, HK, V]{
+		hashkey: m.hashkey,
+		hasher:  m.hasher,
+		root:    root,
+		size:    m.size - 1,
+	}
+}
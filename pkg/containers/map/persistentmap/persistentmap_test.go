@@ -0,0 +1,255 @@
+package persistentmap_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/map/persistentmap"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that PersistentMap implements ReadOnlyMap.
+var _ mapp.ReadOnlyMap[string, int] = &persistentmap.PersistentMap[string, string, int]{}
+
+func assertContents(t *testing.T, expected map[int]int, m *persistentmap.PersistentMap[int, int, int]) {
+	t.Helper()
+
+	assert.Equal(t, len(expected), m.Size())
+
+	actual := map[int]int{}
+	m.ForEach(func(key int, value int) {
+		actual[key] = value
+	})
+	assert.Equal(t, expected, actual)
+
+	for key, value := range expected {
+		assert.Equal(t, value, m.GetOrDefault(key, -1))
+	}
+}
+
+func TestPersistentMapString(t *testing.T) {
+	t.Parallel()
+
+	m := persistentmap.New[string, int]()
+	assert.Equal(t, "PersistentMap\n", m.String())
+
+	m = m.Put("a", 1)
+	assert.Equal(t, "PersistentMap\nEntry{Key:a, Value:1}", m.String())
+}
+
+func TestPersistentMapPutIsPersistent(t *testing.T) {
+	t.Parallel()
+
+	empty := persistentmap.New[string, int]()
+	one := empty.Put("a", 1)
+	two := one.Put("b", 2)
+	replaced := two.Put("a", 100)
+
+	assert.True(t, empty.Empty())
+	assert.Equal(t, 1, one.Size())
+	assert.Equal(t, 2, two.Size())
+	assert.Equal(t, 2, replaced.Size())
+
+	assert.Equal(t, 1, one.GetOrDefault("a", 0))
+	assert.False(t, one.ContainsKey("b"))
+	assert.Equal(t, 1, two.GetOrDefault("a", 0))
+	assert.Equal(t, 100, replaced.GetOrDefault("a", 0))
+	assert.True(t, replaced.ContainsAllKeys("a", "b"))
+	assert.False(t, replaced.ContainsAnyKey("c", "d"))
+}
+
+func TestPersistentMapRemoveIsPersistent(t *testing.T) {
+	t.Parallel()
+
+	m := persistentmap.New(entry.New("a", 1), entry.New("b", 2))
+	removed := m.Remove("a")
+
+	assert.Equal(t, 2, m.Size())
+	assert.True(t, m.ContainsKey("a"))
+	assert.Equal(t, 1, removed.Size())
+	assert.False(t, removed.ContainsKey("a"))
+
+	// Removing a missing key does not make a new map.
+	assert.Same(t, removed, removed.Remove("a"))
+}
+
+func TestPersistentMapBuilder(t *testing.T) {
+	t.Parallel()
+
+	builder := persistentmap.NewBuilder[string, string, int](strings.ToLower).
+		Put("A", 1).
+		PutAll(entry.New("b", 2), entry.New("c", 3)).
+		Remove("C")
+	first := builder.Build()
+
+	// Changing the builder after Build does not change the map that was built.
+	second := builder.Put("a", 10).Put("d", 4).Build()
+
+	assert.Equal(t, 2, first.Size())
+	assert.Equal(t, 1, first.GetOrDefault("a", 0))
+	assert.False(t, first.ContainsKey("d"))
+
+	assert.Equal(t, 3, second.Size())
+	assert.Equal(t, 10, second.GetOrDefault("A", 0))
+	assert.True(t, second.ContainsKey("D"))
+
+	// Nor does a builder started from a map.
+	third := second.ToBuilder().Remove("a").Put("b", 20).Build()
+	assert.Equal(t, 2, third.Size())
+	assert.Equal(t, 10, second.GetOrDefault("a", 0))
+	assert.Equal(t, 2, second.GetOrDefault("b", 0))
+	assert.Equal(t, 20, third.GetOrDefault("b", 0))
+}
+
+func TestPersistentMapHashCollisions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		hasher compare.Hasher[int]
+	}{
+		{
+			name:   "all hashes equal",
+			hasher: func(int) uint64 { return 12345 },
+		},
+		{
+			name:   "few distinct hashes",
+			hasher: func(key int) uint64 { return uint64(key%3) << 61 },
+		},
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := persistentmap.NewBuilder[int, int, int](compare.IdentityHashKey[int]).Hasher(testCase.hasher)
+			expected := map[int]int{}
+			for key := 0; key < 30; key++ {
+				builder.Put(key, key*10)
+				expected[key] = key * 10
+			}
+
+			m := builder.Build()
+			assertContents(t, expected, m)
+
+			for key := 0; key < 30; key += 2 {
+				m = m.Remove(key)
+				delete(expected, key)
+			}
+			assertContents(t, expected, m)
+			assert.False(t, m.ContainsKey(0))
+		})
+	}
+}
+
+func TestPersistentMapHasherAfterPut(t *testing.T) {
+	t.Parallel()
+
+	// Changing the hasher rehashes what was already put.
+	m := persistentmap.NewBuilder[int, int, int](compare.IdentityHashKey[int]).
+		Put(1, 1).
+		Put(2, 2).
+		Hasher(func(key int) uint64 { return uint64(key) }).
+		Build()
+
+	assertContents(t, map[int]int{1: 1, 2: 2}, m)
+}
+
+func TestPersistentMapRandomSnapshots(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(7))
+	m := persistentmap.New[int, int]()
+	expected := map[int]int{}
+
+	type snapshot struct {
+		m        *persistentmap.PersistentMap[int, int, int]
+		expected map[int]int
+	}
+	snapshots := []snapshot{}
+
+	for i := 0; i < 5000; i++ {
+		key := rng.Intn(1000)
+		if rng.Intn(3) == 0 {
+			m = m.Remove(key)
+			delete(expected, key)
+		} else {
+			m = m.Put(key, i)
+			expected[key] = i
+		}
+
+		if i%500 == 0 {
+			copied := make(map[int]int, len(expected))
+			for k, v := range expected {
+				copied[k] = v
+			}
+			snapshots = append(snapshots, snapshot{m: m, expected: copied})
+		}
+	}
+
+	assertContents(t, expected, m)
+	for _, s := range snapshots {
+		assertContents(t, s.expected, s.m)
+	}
+}
+
+func TestPersistentMapEnumeration(t *testing.T) {
+	t.Parallel()
+
+	m := persistentmap.New(entry.New("a", 1), entry.New("b", 2), entry.New("c", 3))
+
+	keys := m.Keys()
+	sort.Strings(keys)
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+
+	values := m.Values()
+	sort.Ints(values)
+	assert.Equal(t, []int{1, 2, 3}, values)
+	assert.Len(t, m.Entries(), 3)
+
+	assert.True(t, m.Any(func(key string, value int) bool { return value == 2 }))
+	assert.False(t, m.Any(func(key string, value int) bool { return value == 4 }))
+	assert.True(t, m.All(func(key string, value int) bool { return value > 0 }))
+	assert.False(t, m.All(func(key string, value int) bool { return value < 3 }))
+
+	key, value, ok := m.Find(func(key string, value int) bool { return value == 3 })
+	assert.True(t, ok)
+	assert.Equal(t, "c", key)
+	assert.Equal(t, 3, value)
+
+	_, _, ok = m.Find(func(key string, value int) bool { return value == 4 })
+	assert.False(t, ok)
+}
+
+func TestPersistentMapConcurrentReaders(t *testing.T) {
+	t.Parallel()
+
+	base := persistentmap.New[string, int]()
+	for i := 0; i < 100; i++ {
+		base = base.Put(fmt.Sprint(i), i)
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// Each goroutine derives its own map from the shared one.
+			own := base.Put("own", i).Remove("0")
+			assert.Equal(t, i, own.GetOrDefault("own", -1))
+			assert.Equal(t, 100, own.Size())
+			assert.Equal(t, 100, base.Size())
+			assert.True(t, base.ContainsKey("0"))
+		}(i)
+	}
+	wg.Wait()
+}
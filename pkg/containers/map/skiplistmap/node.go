@@ -0,0 +1,72 @@
+package skiplistmap
+
+import (
+	"math/bits"
+	"math/rand"
+	"sync/atomic"
+)
+
+const maxHeight = 32
+
+// link is an immutable reference to the next node at one level, along with whether the node
+// holding it has been removed from that level. Both are replaced together with a single
+// compare-and-swap of the link, so that a node is never linked after once it is marked.
+type link[K any, V any] struct {
+	node   *node[K, V]
+	marked bool
+}
+
+// node is a node of the skip list. A node is removed from the map when its value is swapped
+// for nil, after which it is marked at each level from the top down and unlinked by whichever
+// goroutine next passes it.
+type node[K any, V any] struct {
+	key   K
+	value atomic.Pointer[V]
+	next  []atomic.Pointer[link[K, V]]
+}
+
+func newNode[K any, V any](key K, value *V, height int) *node[K, V] {
+	n := &node[K, V]{
+		key:   key,
+		value: atomic.Pointer[V]{},
+		next:  make([]atomic.Pointer[link[K, V]], height),
+	}
+	n.value.Store(value)
+
+	for level := range n.next {
+		n.next[level].Store(&link[K, V]{node: nil, marked: false})
+	}
+
+	return n
+}
+
+// mark marks the node at every level, which is safe to do more than once.
+func (n *node[K, V]) mark() {
+	for level := len(n.next) - 1; level >= 0; level-- {
+		for {
+			next := n.next[level].Load()
+			if next.marked || n.next[level].CompareAndSwap(next, &link[K, V]{node: next.node, marked: true}) {
+				break
+			}
+		}
+	}
+}
+
+// nextLive returns the first node after the node that has not been removed, or nil if there
+// is none. The next links of a removed node still lead forward, so this works from any node.
+func (n *node[K, V]) nextLive() (*node[K, V], *V) {
+	for next := n.next[0].Load().node; next != nil; next = next.next[0].Load().node {
+		if value := next.value.Load(); value != nil {
+			return next, value
+		}
+	}
+
+	return nil, nil
+}
+
+// randomHeight returns a height of at least 1 in which each extra level has half the
+// chance of the one below it.
+func randomHeight() int {
+	//nolint:gosec
+	return min(bits.TrailingZeros64(rand.Uint64())+1, maxHeight)
+}
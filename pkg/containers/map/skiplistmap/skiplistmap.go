@@ -0,0 +1,600 @@
+package skiplistmap
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/iterator"
+	"golang.org/x/exp/constraints"
+)
+
+type Builder[K any, V any] struct {
+	comparator compare.Comparator[K]
+	entries    []entry.Entry[K, V]
+}
+
+func NewBuilder[K any, V any](comparator compare.Comparator[K]) *Builder[K, V] {
+	return &Builder[K, V]{
+		comparator: comparator,
+		entries:    nil,
+	}
+}
+
+func (b *Builder[K, V]) Put(key K, value V) *Builder[K, V] {
+	b.entries = append(b.entries, entry.New(key, value))
+
+	return b
+}
+
+func (b *Builder[K, V]) PutAll(entries ...entry.Entry[K, V]) *Builder[K, V] {
+	b.entries = append(b.entries, entries...)
+
+	return b
+}
+
+func (b *Builder[K, V]) Build() *SkipListMap[K, V] {
+	m := &SkipListMap[K, V]{
+		comparator: b.comparator,
+		head:       newNode[K, V](*new(K), nil, maxHeight),
+		size:       atomic.Int64{},
+	}
+	m.PutAll(b.entries...)
+
+	return m
+}
+
+// SkipListMap is a sorted map that is safe for concurrent use without locking. It is a skip
+// list whose links are only ever changed by compare-and-swap, so goroutines never wait for
+// each other and operations on different keys rarely contend.
+// Methods that visit many entries, such as ForEach, Keys and iterators, are weakly consistent:
+// they see each entry at most once and in order, and they reflect every change made before
+// they started, but they may or may not reflect changes made while they run.
+// Likewise, Size is exact only while the map is not being modified.
+type SkipListMap[K any, V any] struct {
+	comparator compare.Comparator[K]
+	head       *node[K, V]
+	size       atomic.Int64
+}
+
+func New[K constraints.Ordered, V any](entries ...entry.Entry[K, V]) *SkipListMap[K, V] {
+	return NewBuilder[K, V](compare.OrderedComparator[K]).PutAll(entries...).Build()
+}
+
+func (m *SkipListMap[K, V]) Empty() bool {
+	next, _ := m.head.nextLive()
+
+	return next == nil
+}
+
+func (m *SkipListMap[K, V]) Size() int {
+	return int(m.size.Load())
+}
+
+// Clear removes each entry in turn, so entries put concurrently may remain.
+func (m *SkipListMap[K, V]) Clear() {
+	for n, _ := m.head.nextLive(); n != nil; n, _ = n.nextLive() {
+		m.RemoveKey(n.key)
+	}
+}
+
+func (m *SkipListMap[K, V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("SkipListMap\n")
+
+	strs := []string{}
+	m.ForEach(func(key K, value V) {
+		strs = append(strs, entry.NewRef(key, value).String())
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+// ForEach visits the entries in order of their keys.
+func (m *SkipListMap[K, V]) ForEach(op enumerable.Op[K, V]) {
+	m.walk(func(key K, value V) bool {
+		op(key, value)
+
+		return true
+	})
+}
+
+func (m *SkipListMap[K, V]) Any(predicate enumerable.Predicate[K, V]) bool {
+	_, _, found := m.Find(predicate)
+
+	return found
+}
+
+func (m *SkipListMap[K, V]) All(predicate enumerable.Predicate[K, V]) bool {
+	return m.walk(predicate)
+}
+
+// Find returns the first entry in order of the keys that satisfies the predicate.
+func (m *SkipListMap[K, V]) Find(predicate enumerable.Predicate[K, V]) (K, V, bool) {
+	foundKey, foundValue, found := *new(K), *new(V), false
+
+	m.walk(func(key K, value V) bool {
+		if predicate(key, value) {
+			foundKey, foundValue, found = key, value, true
+
+			return false
+		}
+
+		return true
+	})
+
+	return foundKey, foundValue, found
+}
+
+// Iterator returns a weakly consistent iterator over the entries in order of their keys.
+func (m *SkipListMap[K, V]) Iterator() (iterator.ForwardIterator[K, V], bool) {
+	n, value := m.head.nextLive()
+	if n == nil {
+		return nil, false
+	}
+
+	return &skipListMapIterator[K, V]{
+		current: n,
+		value:   *value,
+	}, true
+}
+
+func (m *SkipListMap[K, V]) Keys() []K {
+	keys := []K{}
+	m.ForEach(func(key K, _ V) {
+		keys = append(keys, key)
+	})
+
+	return keys
+}
+
+func (m *SkipListMap[K, V]) Values() []V {
+	values := []V{}
+	m.ForEach(func(_ K, value V) {
+		values = append(values, value)
+	})
+
+	return values
+}
+
+func (m *SkipListMap[K, V]) Entries() []entry.Entry[K, V] {
+	entries := []entry.Entry[K, V]{}
+	m.ForEach(func(key K, value V) {
+		entries = append(entries, entry.New(key, value))
+	})
+
+	return entries
+}
+
+func (m *SkipListMap[K, V]) Get(key K) (V, bool) {
+	n := m.seek(key, true)
+	if n == nil || m.comparator(n.key, key) != compare.PriorityEqual {
+		return *new(V), false
+	}
+
+	if value := n.value.Load(); value != nil {
+		return *value, true
+	}
+
+	return *new(V), false
+}
+
+func (m *SkipListMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, ok := m.Get(key); ok {
+		return value
+	}
+
+	return defaultValue
+}
+
+func (m *SkipListMap[K, V]) Put(key K, value V) {
+	m.Swap(key, value)
+}
+
+func (m *SkipListMap[K, V]) PutAll(entries ...entry.Entry[K, V]) {
+	for _, entry := range entries {
+		m.Put(entry.Key(), entry.Value())
+	}
+}
+
+func (m *SkipListMap[K, V]) PutAllFrom(other mapp.ReadOnlyMap[K, V]) {
+	m.PutAll(other.Entries()...)
+}
+
+func (m *SkipListMap[K, V]) Swap(key K, value V) (V, bool) {
+	return m.put(key, value, false)
+}
+
+// PutIfAbsent puts the value only if the map does not contain the key, as a single atomic
+// operation. Returns the existing value and true if the map already contained the key.
+func (m *SkipListMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	return m.put(key, value, true)
+}
+
+func (m *SkipListMap[K, V]) Replace(key K, value V) bool {
+	for {
+		_, _, succs, found := m.find(key)
+		if !found {
+			return false
+		}
+
+		if _, replaced := m.replace(succs[0], &value, false); replaced {
+			return true
+		}
+	}
+}
+
+func (m *SkipListMap[K, V]) RemoveKey(key K) bool {
+	for {
+		_, _, succs, found := m.find(key)
+		if !found {
+			return false
+		}
+
+		n := succs[0]
+
+		old := n.value.Load()
+		if old == nil {
+			// Another goroutine removed the node, so help unlink it and look again.
+			n.mark()
+
+			continue
+		}
+
+		if n.value.CompareAndSwap(old, nil) {
+			m.size.Add(-1)
+			n.mark()
+
+			// Unlink the node now rather than leaving it to the next goroutine to pass it.
+			m.find(key)
+
+			return true
+		}
+	}
+}
+
+func (m *SkipListMap[K, V]) RemoveAllKeys(keys ...K) int {
+	removed := 0
+
+	for _, key := range keys {
+		if m.RemoveKey(key) {
+			removed++
+		}
+	}
+
+	return removed
+}
+
+func (m *SkipListMap[K, V]) RemoveIf(predicate enumerable.Predicate[K, V]) int {
+	keys := []K{}
+	m.ForEach(func(key K, value V) {
+		if predicate(key, value) {
+			keys = append(keys, key)
+		}
+	})
+
+	return m.RemoveAllKeys(keys...)
+}
+
+func (m *SkipListMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.Get(key)
+
+	return ok
+}
+
+func (m *SkipListMap[K, V]) ContainsAllKeys(keys ...K) bool {
+	for _, key := range keys {
+		if !m.ContainsKey(key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *SkipListMap[K, V]) ContainsAnyKey(keys ...K) bool {
+	for _, key := range keys {
+		if m.ContainsKey(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// First returns the entry with the smallest key.
+func (m *SkipListMap[K, V]) First() (K, V, bool) {
+	n, value := m.head.nextLive()
+	if n == nil {
+		return *new(K), *new(V), false
+	}
+
+	return n.key, *value, true
+}
+
+// Last returns the entry with the largest key.
+func (m *SkipListMap[K, V]) Last() (K, V, bool) {
+	return m.last(nil, false)
+}
+
+// Floor returns the entry with the largest key less than or equal to the key.
+func (m *SkipListMap[K, V]) Floor(key K) (K, V, bool) {
+	return m.last(&key, true)
+}
+
+// Lower returns the entry with the largest key strictly less than the key.
+func (m *SkipListMap[K, V]) Lower(key K) (K, V, bool) {
+	return m.last(&key, false)
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to the key.
+func (m *SkipListMap[K, V]) Ceiling(key K) (K, V, bool) {
+	return m.first(key, true)
+}
+
+// Higher returns the entry with the smallest key strictly greater than the key.
+func (m *SkipListMap[K, V]) Higher(key K) (K, V, bool) {
+	return m.first(key, false)
+}
+
+// first returns the first entry with a key after the key, or equal to it if inclusive.
+func (m *SkipListMap[K, V]) first(key K, inclusive bool) (K, V, bool) {
+	n := m.seek(key, inclusive)
+	if n == nil {
+		return *new(K), *new(V), false
+	}
+
+	if value := n.value.Load(); value != nil {
+		return n.key, *value, true
+	}
+
+	if n, value := n.nextLive(); n != nil {
+		return n.key, *value, true
+	}
+
+	return *new(K), *new(V), false
+}
+
+// last returns the last entry with a key before the bound, or equal to it if inclusive.
+// With no bound, it returns the last entry.
+func (m *SkipListMap[K, V]) last(bound *K, inclusive bool) (K, V, bool) {
+	for {
+		n := m.head
+		for level := maxHeight - 1; level >= 0; level-- {
+			for {
+				next := m.nextAt(n, level)
+				if next == nil || (bound != nil && !m.before(next.key, *bound, inclusive)) {
+					break
+				}
+
+				n = next
+			}
+		}
+
+		if n == m.head {
+			return *new(K), *new(V), false
+		}
+
+		if value := n.value.Load(); value != nil {
+			return n.key, *value, true
+		}
+
+		// The node was removed while we found it, so look for the last entry before it instead.
+		key := n.key
+		bound, inclusive = &key, false
+	}
+}
+
+// seek returns the first node that has not been marked with a key after the key, or equal to
+// it if inclusive, without modifying the map. The node may still have been removed.
+func (m *SkipListMap[K, V]) seek(key K, inclusive bool) *node[K, V] {
+	n := m.head
+	for level := maxHeight - 1; level >= 0; level-- {
+		for {
+			next := m.nextAt(n, level)
+			if next == nil || !m.before(next.key, key, !inclusive) {
+				break
+			}
+
+			n = next
+		}
+	}
+
+	return m.nextAt(n, 0)
+}
+
+// nextAt returns the first node after n at the level that has not been marked at that level.
+func (m *SkipListMap[K, V]) nextAt(n *node[K, V], level int) *node[K, V] {
+	next := n.next[level].Load().node
+	for next != nil && next.next[level].Load().marked {
+		next = next.next[level].Load().node
+	}
+
+	return next
+}
+
+// before returns whether the left key is less than the right key, or equal to it if inclusive.
+func (m *SkipListMap[K, V]) before(left K, right K, inclusive bool) bool {
+	cmp := m.comparator(left, right)
+
+	return cmp == compare.PriorityRightHigher || (inclusive && cmp == compare.PriorityEqual)
+}
+
+// find returns the last node before the key and the link that follows it at each level, and
+// the node after them at each level. Marked nodes that it passes are unlinked on the way.
+// Returns whether the node after them at the bottom level has the key.
+// The links it returns are never marked, so a compare-and-swap of one of them fails once its
+// node is removed, rather than linking a node after a node that may already be unlinked.
+func (m *SkipListMap[K, V]) find(key K) (
+	preds [maxHeight]*node[K, V],
+	predLinks [maxHeight]*link[K, V],
+	succs [maxHeight]*node[K, V],
+	found bool,
+) {
+retry:
+	for {
+		pred := m.head
+		for level := maxHeight - 1; level >= 0; level-- {
+			predLink := pred.next[level].Load()
+			if predLink.marked {
+				// The predecessor was removed after it was found at the level above.
+				continue retry
+			}
+
+			for predLink.node != nil {
+				curr := predLink.node
+				currLink := curr.next[level].Load()
+
+				if currLink.marked {
+					replacement := &link[K, V]{node: currLink.node, marked: false}
+					if !pred.next[level].CompareAndSwap(predLink, replacement) {
+						// The predecessor changed or was marked itself, so start over.
+						continue retry
+					}
+
+					predLink = replacement
+
+					continue
+				}
+
+				if !m.before(curr.key, key, false) {
+					break
+				}
+
+				pred, predLink = curr, currLink
+			}
+
+			preds[level], predLinks[level], succs[level] = pred, predLink, predLink.node
+		}
+
+		found = succs[0] != nil && m.comparator(succs[0].key, key) == compare.PriorityEqual
+
+		return preds, predLinks, succs, found
+	}
+}
+
+func (m *SkipListMap[K, V]) put(key K, value V, onlyIfAbsent bool) (V, bool) {
+	boxed := &value
+
+	for {
+		preds, predLinks, succs, found := m.find(key)
+		if found {
+			if old, replaced := m.replace(succs[0], boxed, onlyIfAbsent); replaced {
+				return *old, true
+			}
+
+			continue
+		}
+
+		n := newNode(key, boxed, randomHeight())
+		for level := range n.next {
+			n.next[level].Store(&link[K, V]{node: succs[level], marked: false})
+		}
+
+		// The node is in the map once it is linked at the bottom level.
+		if !preds[0].next[0].CompareAndSwap(predLinks[0], &link[K, V]{node: n, marked: false}) {
+			continue
+		}
+
+		m.size.Add(1)
+		m.linkLevels(n, preds, predLinks, succs)
+
+		return *new(V), false
+	}
+}
+
+// linkLevels links the node at the levels above the bottom one, giving up if it is removed.
+func (m *SkipListMap[K, V]) linkLevels(
+	n *node[K, V],
+	preds [maxHeight]*node[K, V],
+	predLinks [maxHeight]*link[K, V],
+	succs [maxHeight]*node[K, V],
+) {
+	for level := 1; level < len(n.next); level++ {
+		for {
+			next := n.next[level].Load()
+			if next.marked {
+				return
+			}
+
+			if next.node != succs[level] &&
+				!n.next[level].CompareAndSwap(next, &link[K, V]{node: succs[level], marked: false}) {
+				continue
+			}
+
+			if preds[level].next[level].CompareAndSwap(predLinks[level], &link[K, V]{node: n, marked: false}) {
+				break
+			}
+
+			var found bool
+			if preds, predLinks, succs, found = m.find(n.key); !found || succs[0] != n {
+				return
+			}
+		}
+	}
+}
+
+// replace swaps the value of the node for the boxed value, or only loads it if onlyIfAbsent.
+// Returns the old value and true unless the node has been removed.
+func (m *SkipListMap[K, V]) replace(n *node[K, V], boxed *V, onlyIfAbsent bool) (*V, bool) {
+	for {
+		old := n.value.Load()
+		if old == nil {
+			// Make sure the node is marked, so that the next find unlinks it.
+			n.mark()
+
+			return nil, false
+		}
+
+		if onlyIfAbsent || n.value.CompareAndSwap(old, boxed) {
+			return old, true
+		}
+	}
+}
+
+// walk visits the entries in order of their keys, stopping early if the op returns false.
+func (m *SkipListMap[K, V]) walk(op func(key K, value V) bool) bool {
+	for n, value := m.head.nextLive(); n != nil; n, value = n.nextLive() {
+		if !op(n.key, *value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type skipListMapIterator[K any, V any] struct {
+	current *node[K, V]
+	value   V
+}
+
+func (it *skipListMapIterator[K, V]) Key() (K, bool) {
+	return it.current.key, true
+}
+
+func (it *skipListMapIterator[K, V]) Value() (V, bool) {
+	return it.value, true
+}
+
+func (it *skipListMapIterator[K, V]) Next() (iterator.ForwardIterator[K, V], bool) {
+	n, value := it.current.nextLive()
+	if n == nil {
+		return nil, false
+	}
+
+	return &skipListMapIterator[K, V]{
+		current: n,
+		value:   *value,
+	}, true
+}
+
+func (it *skipListMapIterator[K, V]) HasNext() bool {
+	n, _ := it.current.nextLive()
+
+	return n != nil
+}
@@ -0,0 +1,345 @@
+package skiplistmap_test
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/map/skiplistmap"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that SkipListMap implements Map.
+var _ mapp.Map[string, int] = skiplistmap.New[string, int]()
+
+func TestSkipListMapString(t *testing.T) {
+	t.Parallel()
+
+	m := skiplistmap.New[string, int]()
+	assert.Equal(t, "SkipListMap\n", m.String())
+
+	m.Put("b", 2)
+	m.Put("a", 1)
+	assert.Equal(t, "SkipListMap\nEntry{Key:a, Value:1},Entry{Key:b, Value:2}", m.String())
+}
+
+func TestSkipListMapOrder(t *testing.T) {
+	t.Parallel()
+
+	m := skiplistmap.NewBuilder[int, string](compare.OppositeOrderedComparator[int]).
+		Put(1, "one").
+		PutAll(entry.New(3, "three"), entry.New(2, "two"), entry.New(1, "uno")).
+		Build()
+
+	assert.Equal(t, 3, m.Size())
+	assert.Equal(t, []int{3, 2, 1}, m.Keys())
+	assert.Equal(t, []string{"three", "two", "uno"}, m.Values())
+	assert.Equal(t, entry.New(3, "three"), m.Entries()[0])
+
+	it, ok := m.Iterator()
+	keys := []int{}
+
+	for ok {
+		key, _ := it.Key()
+		keys = append(keys, key)
+		it, ok = it.Next()
+	}
+	assert.Equal(t, []int{3, 2, 1}, keys)
+}
+
+func TestSkipListMapPutGetRemove(t *testing.T) {
+	t.Parallel()
+
+	m := skiplistmap.New(entry.New("b", 2), entry.New("d", 4))
+
+	old, existed := m.Swap("b", 20)
+	assert.True(t, existed)
+	assert.Equal(t, 2, old)
+
+	existing, present := m.PutIfAbsent("b", 200)
+	assert.True(t, present)
+	assert.Equal(t, 20, existing)
+	assert.Equal(t, 20, m.GetOrDefault("b", 0))
+
+	_, present = m.PutIfAbsent("c", 3)
+	assert.False(t, present)
+	assert.Equal(t, 3, m.GetOrDefault("c", 0))
+
+	assert.True(t, m.Replace("d", 40))
+	assert.False(t, m.Replace("e", 50))
+	assert.False(t, m.ContainsKey("e"))
+	assert.True(t, m.ContainsAllKeys("b", "c", "d"))
+	assert.True(t, m.ContainsAnyKey("a", "d"))
+
+	assert.True(t, m.RemoveKey("c"))
+	assert.False(t, m.RemoveKey("c"))
+	assert.Equal(t, 1, m.RemoveAllKeys("a", "b"))
+	assert.Equal(t, []string{"d"}, m.Keys())
+
+	m.PutAllFrom(skiplistmap.New(entry.New("a", 1), entry.New("d", 4)))
+	assert.Equal(t, 1, m.RemoveIf(func(_ string, value int) bool { return value > 1 }))
+	assert.Equal(t, []string{"a"}, m.Keys())
+
+	m.Clear()
+	assert.True(t, m.Empty())
+	assert.Equal(t, 0, m.Size())
+
+	_, ok := m.Iterator()
+	assert.False(t, ok)
+}
+
+func TestSkipListMapNavigation(t *testing.T) {
+	t.Parallel()
+
+	m := skiplistmap.New(entry.New(10, "a"), entry.New(20, "b"), entry.New(30, "c"))
+
+	tests := []struct {
+		name     string
+		navigate func(key int) (int, string, bool)
+		key      int
+		expected int
+		found    bool
+	}{
+		{name: "floor of a key", navigate: m.Floor, key: 20, expected: 20, found: true},
+		{name: "floor between keys", navigate: m.Floor, key: 25, expected: 20, found: true},
+		{name: "floor before all keys", navigate: m.Floor, key: 5, expected: 0, found: false},
+		{name: "lower of a key", navigate: m.Lower, key: 20, expected: 10, found: true},
+		{name: "lower after all keys", navigate: m.Lower, key: 100, expected: 30, found: true},
+		{name: "ceiling of a key", navigate: m.Ceiling, key: 20, expected: 20, found: true},
+		{name: "ceiling between keys", navigate: m.Ceiling, key: 15, expected: 20, found: true},
+		{name: "ceiling after all keys", navigate: m.Ceiling, key: 35, expected: 0, found: false},
+		{name: "higher of a key", navigate: m.Higher, key: 20, expected: 30, found: true},
+		{name: "higher of the last key", navigate: m.Higher, key: 30, expected: 0, found: false},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, _, found := testCase.navigate(testCase.key)
+			assert.Equal(t, testCase.found, found)
+			assert.Equal(t, testCase.expected, key)
+		})
+	}
+
+	first, _, _ := m.First()
+	last, value, _ := m.Last()
+	assert.Equal(t, 10, first)
+	assert.Equal(t, 30, last)
+	assert.Equal(t, "c", value)
+
+	_, _, found := skiplistmap.New[int, int]().Last()
+	assert.False(t, found)
+}
+
+func TestSkipListMapEnumeration(t *testing.T) {
+	t.Parallel()
+
+	m := skiplistmap.New(entry.New(3, 30), entry.New(1, 10), entry.New(2, 20))
+
+	key, value, found := m.Find(func(_ int, value int) bool { return value > 10 })
+	assert.True(t, found)
+	assert.Equal(t, 2, key)
+	assert.Equal(t, 20, value)
+
+	assert.True(t, m.Any(func(key int, _ int) bool { return key == 3 }))
+	assert.True(t, m.All(func(key int, value int) bool { return value == key*10 }))
+	assert.False(t, m.All(func(key int, _ int) bool { return key < 3 }))
+}
+
+func TestSkipListMapIteratorWeaklyConsistent(t *testing.T) {
+	t.Parallel()
+
+	m := skiplistmap.New(entry.New(1, 1), entry.New(2, 2), entry.New(3, 3), entry.New(5, 5))
+	it, _ := m.Iterator()
+
+	// The iterator keeps working when its own entry and the next one are removed,
+	// and sees entries put ahead of it.
+	m.RemoveKey(1)
+	m.RemoveKey(2)
+	m.Put(4, 4)
+
+	keys := []int{}
+	for ok := true; ok; it, ok = it.Next() {
+		key, _ := it.Key()
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []int{1, 3, 4, 5}, keys)
+}
+
+func TestSkipListMapRandomOperations(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(42))
+	expected := map[int]int{}
+	m := skiplistmap.New[int, int]()
+
+	for i := 0; i < 5000; i++ {
+		key := rng.Intn(500)
+
+		if rng.Intn(3) == 0 {
+			_, existed := expected[key]
+			delete(expected, key)
+			assert.Equal(t, existed, m.RemoveKey(key))
+		} else {
+			expected[key] = i
+			m.Put(key, i)
+		}
+	}
+
+	keys := make([]int, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+
+	assert.Equal(t, len(expected), m.Size())
+	assert.Equal(t, keys, m.Keys())
+
+	for key, value := range expected {
+		assert.Equal(t, value, m.GetOrDefault(key, -1))
+	}
+}
+
+func TestSkipListMapPutWhilePredecessorRemoved(t *testing.T) {
+	t.Parallel()
+
+	// The comparator removes the predecessor of the key being put just after the put has
+	// reached it, which injects a concurrent removal at a fixed point of the put. Whenever the
+	// predecessor is linked at more than one level, the put then descends from a removed node.
+	var (
+		m        *skiplistmap.SkipListMap[int, int]
+		putting  = -1
+		removing = -1
+	)
+
+	comparator := func(left int, right int) compare.Priority {
+		if right == putting && removing < 0 && left == putting-5 {
+			removing = left
+		} else if right == putting && removing >= 0 && left > putting {
+			removed := removing
+			putting, removing = -1, -1
+			m.RemoveKey(removed)
+		}
+
+		return compare.OrderedComparator(left, right)
+	}
+
+	builder := skiplistmap.NewBuilder[int, int](comparator)
+	for key := 0; key < 1000; key += 10 {
+		builder.Put(key, key)
+	}
+	m = builder.Build()
+
+	for key := 5; key < 985; key += 10 {
+		putting = key
+		m.Put(key, key)
+
+		value, ok := m.Get(key)
+		assert.True(t, ok, "key %d", key)
+		assert.Equal(t, key, value)
+		assert.False(t, m.ContainsKey(key-5))
+	}
+
+	expected := []int{}
+	for key := 5; key < 985; key += 10 {
+		expected = append(expected, key)
+	}
+	expected = append(expected, 980, 990)
+
+	assert.Equal(t, expected, m.Keys())
+	assert.Equal(t, len(expected), m.Size())
+}
+
+func TestSkipListMapConcurrentPutRemove(t *testing.T) {
+	t.Parallel()
+
+	const goroutines, keysPerGoroutine = 8, 500
+
+	m := skiplistmap.New[int, int]()
+	wg := sync.WaitGroup{}
+
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+
+		go func(g int) {
+			defer wg.Done()
+
+			// Each goroutine owns the keys equal to g modulo goroutines, and keeps the even ones.
+			for i := 0; i < keysPerGoroutine; i++ {
+				m.Put(i*goroutines+g, g)
+			}
+
+			for i := 1; i < keysPerGoroutine; i += 2 {
+				assert.True(t, m.RemoveKey(i*goroutines+g))
+			}
+		}(g)
+	}
+
+	wg.Wait()
+
+	keys := m.Keys()
+	assert.Len(t, keys, goroutines*keysPerGoroutine/2)
+	assert.Equal(t, len(keys), m.Size())
+	assert.True(t, sort.IntsAreSorted(keys))
+
+	for _, key := range keys {
+		assert.Equal(t, 0, (key/goroutines)%2)
+		assert.Equal(t, key%goroutines, m.GetOrDefault(key, -1))
+	}
+}
+
+func TestSkipListMapConcurrentContention(t *testing.T) {
+	t.Parallel()
+
+	const goroutines, operations, keys = 8, 2000, 16
+
+	m := skiplistmap.New[int, int]()
+	wg := sync.WaitGroup{}
+	added := make([]int, goroutines)
+	removed := make([]int, goroutines)
+
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+
+		go func(g int) {
+			defer wg.Done()
+
+			// Every goroutine fights over the same few keys, counting what it added and removed.
+			rng := rand.New(rand.NewSource(int64(g)))
+			for i := 0; i < operations; i++ {
+				key := rng.Intn(keys)
+
+				switch rng.Intn(3) {
+				case 0:
+					if _, present := m.PutIfAbsent(key, g); !present {
+						added[g]++
+					}
+				case 1:
+					if m.RemoveKey(key) {
+						removed[g]++
+					}
+				default:
+					m.Floor(key)
+					m.Ceiling(key)
+					m.Keys()
+				}
+			}
+		}(g)
+	}
+
+	wg.Wait()
+
+	total := 0
+	for g := 0; g < goroutines; g++ {
+		total += added[g] - removed[g]
+	}
+
+	assert.Equal(t, total, m.Size())
+	assert.Len(t, m.Keys(), total)
+	assert.True(t, sort.IntsAreSorted(m.Keys()))
+}
@@ -0,0 +1,233 @@
+package listmultimap
+
+import (
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/map/hashmap"
+)
+
+type Builder[K any, HK comparable, V any, HV comparable] struct {
+	keyHashKey   compare.HashKey[K, HK]
+	valueHashKey compare.HashKey[V, HV]
+	entries      []entry.Entry[K, V]
+}
+
+// NewBuilder creates a builder for a ListMultiMap. The value hash key is only used to find
+// values to remove or check for, values do not need to be distinct.
+func NewBuilder[K any, HK comparable, V any, HV comparable](
+	keyHashKey compare.HashKey[K, HK],
+	valueHashKey compare.HashKey[V, HV],
+) *Builder[K, HK, V, HV] {
+	return &Builder[K, HK, V, HV]{
+		keyHashKey:   keyHashKey,
+		valueHashKey: valueHashKey,
+		entries:      []entry.Entry[K, V]{},
+	}
+}
+
+func (b *Builder[K, HK, V, HV]) Put(key K, value V) *Builder[K, HK, V, HV] {
+	b.entries = append(b.entries, entry.New(key, value))
+
+	return b
+}
+
+func (b *Builder[K, HK, V, HV]) PutAll(entries ...entry.Entry[K, V]) *Builder[K, HK, V, HV] {
+	b.entries = append(b.entries, entries...)
+
+	return b
+}
+
+func (b *Builder[K, HK, V, HV]) Build() *ListMultiMap[K, HK, V, HV] {
+	m := &ListMultiMap[K, HK, V, HV]{
+		valueHashKey: b.valueHashKey,
+		values:       hashmap.NewBuilder[K, HK, []V](b.keyHashKey).Build(),
+		size:         0,
+	}
+
+	for _, e := range b.entries {
+		m.Put(e.Key(), e.Value())
+	}
+
+	return m
+}
+
+// ListMultiMap is a MultiMap that keeps the values of each key in a list, in the order they
+// were put. The same value can be put for a key more than once.
+type ListMultiMap[K any, HK comparable, V any, HV comparable] struct {
+	valueHashKey compare.HashKey[V, HV]
+	values       *hashmap.HashMap[K, HK, []V]
+	size         int
+}
+
+func New[K comparable, V comparable](entries ...entry.Entry[K, V]) *ListMultiMap[K, K, V, V] {
+	return NewBuilder[K, K, V, V](compare.IdentityHashKey[K], compare.IdentityHashKey[V]).
+		PutAll(entries...).
+		Build()
+}
+
+func (m *ListMultiMap[K, HK, V, HV]) Empty() bool {
+	return m.Size() == 0
+}
+
+func (m *ListMultiMap[K, HK, V, HV]) Size() int {
+	return m.size
+}
+
+func (m *ListMultiMap[K, HK, V, HV]) Clear() {
+	m.values.Clear()
+	m.size = 0
+}
+
+func (m *ListMultiMap[K, HK, V, HV]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("ListMultiMap\n")
+
+	strs := []string{}
+	m.ForEach(func(key K, value V) {
+		strs = append(strs, entry.NewRef(key, value).String())
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (m *ListMultiMap[K, HK, V, HV]) ForEach(op enumerable.Op[K, V]) {
+	m.values.ForEach(func(key K, values []V) {
+		for _, value := range values {
+			op(key, value)
+		}
+	})
+}
+
+func (m *ListMultiMap[K, HK, V, HV]) Any(predicate enumerable.Predicate[K, V]) bool {
+	_, _, found := m.Find(predicate)
+
+	return found
+}
+
+func (m *ListMultiMap[K, HK, V, HV]) All(predicate enumerable.Predicate[K, V]) bool {
+	return m.values.All(func(key K, values []V) bool {
+		for _, value := range values {
+			if !predicate(key, value) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func (m *ListMultiMap[K, HK, V, HV]) Find(predicate enumerable.Predicate[K, V]) (K, V, bool) {
+	foundValue := *new(V)
+
+	foundKey, _, found := m.values.Find(func(key K, values []V) bool {
+		for _, value := range values {
+			if predicate(key, value) {
+				foundValue = value
+
+				return true
+			}
+		}
+
+		return false
+	})
+	if !found {
+		return *new(K), *new(V), false
+	}
+
+	return foundKey, foundValue, true
+}
+
+// Get returns a copy of the values of the key in the order they were put,
+// or an empty slice if the key has no values.
+func (m *ListMultiMap[K, HK, V, HV]) Get(key K) []V {
+	values, _ := m.values.Get(key)
+
+	return append([]V{}, values...)
+}
+
+func (m *ListMultiMap[K, HK, V, HV]) Put(key K, value V) {
+	m.PutAll(key, value)
+}
+
+func (m *ListMultiMap[K, HK, V, HV]) PutAll(key K, values ...V) {
+	if len(values) == 0 {
+		return
+	}
+
+	existing, _ := m.values.Get(key)
+	m.values.Put(key, append(existing, values...))
+	m.size += len(values)
+}
+
+// RemoveValue removes the first occurrence of the value from the values of the key.
+func (m *ListMultiMap[K, HK, V, HV]) RemoveValue(key K, value V) bool {
+	values, ok := m.values.Get(key)
+	if !ok {
+		return false
+	}
+
+	index := m.indexOf(values, value)
+	if index < 0 {
+		return false
+	}
+
+	if len(values) == 1 {
+		m.values.RemoveKey(key)
+	} else {
+		m.values.Put(key, append(values[:index:index], values[index+1:]...))
+	}
+	m.size--
+
+	return true
+}
+
+// RemoveKey removes all of the values of the key and returns how many there were.
+func (m *ListMultiMap[K, HK, V, HV]) RemoveKey(key K) int {
+	values, ok := m.values.Get(key)
+	if !ok {
+		return 0
+	}
+
+	m.values.RemoveKey(key)
+	m.size -= len(values)
+
+	return len(values)
+}
+
+func (m *ListMultiMap[K, HK, V, HV]) ContainsKey(key K) bool {
+	return m.values.ContainsKey(key)
+}
+
+func (m *ListMultiMap[K, HK, V, HV]) ContainsEntry(key K, value V) bool {
+	values, _ := m.values.Get(key)
+
+	return m.indexOf(values, value) >= 0
+}
+
+// KeyCount returns the number of distinct keys that have at least one value.
+func (m *ListMultiMap[K, HK, V, HV]) KeyCount() int {
+	return m.values.Size()
+}
+
+// ValueCount returns the number of values of the key.
+func (m *ListMultiMap[K, HK, V, HV]) ValueCount(key K) int {
+	values, _ := m.values.Get(key)
+
+	return len(values)
+}
+
+func (m *ListMultiMap[K, HK, V, HV]) indexOf(values []V, value V) int {
+	hashedValue := m.valueHashKey(value)
+	for i, v := range values {
+		if m.valueHashKey(v) == hashedValue {
+			return i
+		}
+	}
+
+	return -1
+}
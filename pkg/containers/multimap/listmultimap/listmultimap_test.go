@@ -0,0 +1,57 @@
+package listmultimap_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/multimap"
+	"github.com/kaschnit/go-ds/pkg/containers/multimap/listmultimap"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that ListMultiMap implements MultiMap.
+var _ multimap.MultiMap[string, int] = &listmultimap.ListMultiMap[string, string, int, int]{}
+
+func TestListMultiMapString(t *testing.T) {
+	t.Parallel()
+
+	m := listmultimap.New[string, int]()
+	assert.Equal(t, "ListMultiMap\n", m.String())
+
+	m.PutAll("a", 1, 1)
+	assert.Equal(t, "ListMultiMap\nEntry{Key:a, Value:1},Entry{Key:a, Value:1}", m.String())
+}
+
+func TestListMultiMapDuplicateValues(t *testing.T) {
+	t.Parallel()
+
+	m := listmultimap.New(entry.New("a", 1), entry.New("a", 2), entry.New("a", 1))
+	assert.Equal(t, 3, m.Size())
+	assert.Equal(t, 3, m.ValueCount("a"))
+
+	// Only the first occurrence is removed.
+	assert.True(t, m.RemoveValue("a", 1))
+	assert.Equal(t, []int{2, 1}, m.Get("a"))
+	assert.True(t, m.RemoveValue("a", 1))
+	assert.False(t, m.RemoveValue("a", 1))
+	assert.Equal(t, []int{2}, m.Get("a"))
+}
+
+func TestListMultiMapHashKey(t *testing.T) {
+	t.Parallel()
+
+	// Keys are slices and values are compared case-insensitively.
+	m := listmultimap.NewBuilder[[]string, string, string, string](
+		func(key []string) string { return strings.Join(key, "/") },
+		strings.ToLower,
+	).
+		Put([]string{"tags", "go"}, "Generics").
+		Build()
+	m.Put([]string{"tags", "go"}, "Maps")
+
+	assert.Equal(t, []string{"Generics", "Maps"}, m.Get([]string{"tags", "go"}))
+	assert.True(t, m.ContainsEntry([]string{"tags", "go"}, "maps"))
+	assert.True(t, m.RemoveValue([]string{"tags", "go"}, "GENERICS"))
+	assert.Equal(t, []string{"Maps"}, m.Get([]string{"tags", "go"}))
+}
@@ -0,0 +1,24 @@
+package multimap
+
+import (
+	"github.com/kaschnit/go-ds/pkg/containers/container"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+)
+
+// MultiMap maps each key to any number of values. Enumerating a MultiMap visits every
+// key-value pair, so a key with several values is visited once per value.
+// The Size of a MultiMap is the number of key-value pairs in it.
+type MultiMap[K any, V any] interface {
+	container.Container
+	enumerable.Enumerable[K, V]
+
+	Get(key K) []V
+	Put(key K, value V)
+	PutAll(key K, values ...V)
+	RemoveValue(key K, value V) bool
+	RemoveKey(key K) int
+	ContainsKey(key K) bool
+	ContainsEntry(key K, value V) bool
+	KeyCount() int
+	ValueCount(key K) int
+}
@@ -0,0 +1,196 @@
+package multimap_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/multimap"
+	"github.com/kaschnit/go-ds/pkg/containers/multimap/listmultimap"
+	"github.com/kaschnit/go-ds/pkg/containers/multimap/setmultimap"
+	"github.com/stretchr/testify/assert"
+)
+
+func getMultiMapsForTest[K comparable, V comparable](entries ...entry.Entry[K, V]) []multimap.MultiMap[K, V] {
+	return []multimap.MultiMap[K, V]{
+		listmultimap.New(entries...),
+		setmultimap.New(entries...),
+	}
+}
+
+func TestPutGet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		initial  []entry.Entry[string, int]
+		key      string
+		expected []int
+	}{
+		{
+			name:     "missing key",
+			initial:  []entry.Entry[string, int]{entry.New("a", 1)},
+			key:      "b",
+			expected: []int{},
+		},
+		{
+			name:     "one value",
+			initial:  []entry.Entry[string, int]{entry.New("a", 1), entry.New("b", 2)},
+			key:      "a",
+			expected: []int{1},
+		},
+		{
+			name: "values in put order",
+			initial: []entry.Entry[string, int]{
+				entry.New("a", 3),
+				entry.New("b", 2),
+				entry.New("a", 1),
+				entry.New("a", 2),
+			},
+			key:      "a",
+			expected: []int{3, 1, 2},
+		},
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			multimaps := getMultiMapsForTest(testCase.initial...)
+			for i := range multimaps {
+				m := multimaps[i]
+				t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+					t.Parallel()
+
+					assert.Equal(t, testCase.expected, m.Get(testCase.key))
+					assert.Equal(t, len(testCase.expected), m.ValueCount(testCase.key))
+					assert.Equal(t, len(testCase.expected) > 0, m.ContainsKey(testCase.key))
+				})
+			}
+		})
+	}
+}
+
+func TestGetReturnsCopy(t *testing.T) {
+	t.Parallel()
+
+	multimaps := getMultiMapsForTest(entry.New("a", 1), entry.New("a", 2))
+	for i := range multimaps {
+		m := multimaps[i]
+		t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+			t.Parallel()
+
+			values := m.Get("a")
+			values[0] = 100
+			assert.Equal(t, []int{1, 2}, m.Get("a"))
+		})
+	}
+}
+
+func TestCounts(t *testing.T) {
+	t.Parallel()
+
+	multimaps := getMultiMapsForTest[string, int]()
+	for i := range multimaps {
+		m := multimaps[i]
+		t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+			t.Parallel()
+
+			assert.True(t, m.Empty())
+			assert.Equal(t, 0, m.KeyCount())
+
+			m.PutAll("a", 1, 2, 3)
+			m.Put("b", 1)
+			m.PutAll("c")
+			assert.False(t, m.Empty())
+			assert.Equal(t, 4, m.Size())
+			assert.Equal(t, 2, m.KeyCount())
+			assert.False(t, m.ContainsKey("c"))
+
+			m.Clear()
+			assert.True(t, m.Empty())
+			assert.Equal(t, 0, m.KeyCount())
+			assert.Equal(t, []int{}, m.Get("a"))
+		})
+	}
+}
+
+func TestRemoveValue(t *testing.T) {
+	t.Parallel()
+
+	multimaps := getMultiMapsForTest(entry.New("a", 1), entry.New("a", 2), entry.New("b", 3))
+	for i := range multimaps {
+		m := multimaps[i]
+		t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+			t.Parallel()
+
+			assert.False(t, m.RemoveValue("a", 3))
+			assert.False(t, m.RemoveValue("c", 1))
+
+			assert.True(t, m.ContainsEntry("a", 1))
+			assert.True(t, m.RemoveValue("a", 1))
+			assert.False(t, m.ContainsEntry("a", 1))
+			assert.Equal(t, []int{2}, m.Get("a"))
+			assert.Equal(t, 2, m.Size())
+
+			// Removing the last value of a key removes the key.
+			assert.True(t, m.RemoveValue("b", 3))
+			assert.False(t, m.ContainsKey("b"))
+			assert.Equal(t, 1, m.KeyCount())
+			assert.Equal(t, 1, m.Size())
+		})
+	}
+}
+
+func TestRemoveKey(t *testing.T) {
+	t.Parallel()
+
+	multimaps := getMultiMapsForTest(entry.New("a", 1), entry.New("a", 2), entry.New("b", 3))
+	for i := range multimaps {
+		m := multimaps[i]
+		t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, 0, m.RemoveKey("c"))
+			assert.Equal(t, 2, m.RemoveKey("a"))
+			assert.Equal(t, 0, m.RemoveKey("a"))
+			assert.False(t, m.ContainsKey("a"))
+			assert.Equal(t, 1, m.Size())
+			assert.Equal(t, 1, m.KeyCount())
+		})
+	}
+}
+
+func TestEnumeration(t *testing.T) {
+	t.Parallel()
+
+	multimaps := getMultiMapsForTest(entry.New("a", 1), entry.New("a", 2), entry.New("b", 3))
+	for i := range multimaps {
+		m := multimaps[i]
+		t.Run(fmt.Sprintf("%T", m), func(t *testing.T) {
+			t.Parallel()
+
+			pairs := []string{}
+			m.ForEach(func(key string, value int) {
+				pairs = append(pairs, fmt.Sprintf("%s=%d", key, value))
+			})
+			sort.Strings(pairs)
+			assert.Equal(t, []string{"a=1", "a=2", "b=3"}, pairs)
+
+			assert.True(t, m.Any(func(key string, value int) bool { return key == "a" && value == 2 }))
+			assert.False(t, m.Any(func(key string, value int) bool { return key == "b" && value == 2 }))
+			assert.True(t, m.All(func(key string, value int) bool { return value > 0 }))
+			assert.False(t, m.All(func(key string, value int) bool { return key == "a" }))
+
+			key, value, ok := m.Find(func(key string, value int) bool { return value == 2 })
+			assert.True(t, ok)
+			assert.Equal(t, "a", key)
+			assert.Equal(t, 2, value)
+
+			_, _, ok = m.Find(func(key string, value int) bool { return value == 4 })
+			assert.False(t, ok)
+		})
+	}
+}
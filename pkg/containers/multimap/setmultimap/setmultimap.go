@@ -0,0 +1,225 @@
+package setmultimap
+
+import (
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/map/hashmap"
+	"github.com/kaschnit/go-ds/pkg/containers/map/linkedhashmap"
+)
+
+type Builder[K any, HK comparable, V any, HV comparable] struct {
+	keyHashKey   compare.HashKey[K, HK]
+	valueHashKey compare.HashKey[V, HV]
+	entries      []entry.Entry[K, V]
+}
+
+func NewBuilder[K any, HK comparable, V any, HV comparable](
+	keyHashKey compare.HashKey[K, HK],
+	valueHashKey compare.HashKey[V, HV],
+) *Builder[K, HK, V, HV] {
+	return &Builder[K, HK, V, HV]{
+		keyHashKey:   keyHashKey,
+		valueHashKey: valueHashKey,
+		entries:      []entry.Entry[K, V]{},
+	}
+}
+
+func (b *Builder[K, HK, V, HV]) Put(key K, value V) *Builder[K, HK, V, HV] {
+	b.entries = append(b.entries, entry.New(key, value))
+
+	return b
+}
+
+func (b *Builder[K, HK, V, HV]) PutAll(entries ...entry.Entry[K, V]) *Builder[K, HK, V, HV] {
+	b.entries = append(b.entries, entries...)
+
+	return b
+}
+
+func (b *Builder[K, HK, V, HV]) Build() *SetMultiMap[K, HK, V, HV] {
+	m := &SetMultiMap[K, HK, V, HV]{
+		valueHashKey: b.valueHashKey,
+		values:       hashmap.NewBuilder[K, HK, *linkedhashmap.LinkedHashMap[V, HV, struct{}]](b.keyHashKey).Build(),
+		size:         0,
+	}
+
+	for _, e := range b.entries {
+		m.Put(e.Key(), e.Value())
+	}
+
+	return m
+}
+
+// SetMultiMap is a MultiMap that keeps the values of each key in a set, so putting a value
+// that the key already has does nothing. The values of a key are kept in the order they were
+// first put.
+type SetMultiMap[K any, HK comparable, V any, HV comparable] struct {
+	valueHashKey compare.HashKey[V, HV]
+	values       *hashmap.HashMap[K, HK, *linkedhashmap.LinkedHashMap[V, HV, struct{}]]
+	size         int
+}
+
+func New[K comparable, V comparable](entries ...entry.Entry[K, V]) *SetMultiMap[K, K, V, V] {
+	return NewBuilder[K, K, V, V](compare.IdentityHashKey[K], compare.IdentityHashKey[V]).
+		PutAll(entries...).
+		Build()
+}
+
+func (m *SetMultiMap[K, HK, V, HV]) Empty() bool {
+	return m.Size() == 0
+}
+
+func (m *SetMultiMap[K, HK, V, HV]) Size() int {
+	return m.size
+}
+
+func (m *SetMultiMap[K, HK, V, HV]) Clear() {
+	m.values.Clear()
+	m.size = 0
+}
+
+func (m *SetMultiMap[K, HK, V, HV]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("SetMultiMap\n")
+
+	strs := []string{}
+	m.ForEach(func(key K, value V) {
+		strs = append(strs, entry.NewRef(key, value).String())
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (m *SetMultiMap[K, HK, V, HV]) ForEach(op enumerable.Op[K, V]) {
+	m.values.ForEach(func(key K, values *linkedhashmap.LinkedHashMap[V, HV, struct{}]) {
+		values.ForEach(func(value V, _ struct{}) {
+			op(key, value)
+		})
+	})
+}
+
+func (m *SetMultiMap[K, HK, V, HV]) Any(predicate enumerable.Predicate[K, V]) bool {
+	_, _, found := m.Find(predicate)
+
+	return found
+}
+
+func (m *SetMultiMap[K, HK, V, HV]) All(predicate enumerable.Predicate[K, V]) bool {
+	return m.values.All(func(key K, values *linkedhashmap.LinkedHashMap[V, HV, struct{}]) bool {
+		return values.All(func(value V, _ struct{}) bool {
+			return predicate(key, value)
+		})
+	})
+}
+
+func (m *SetMultiMap[K, HK, V, HV]) Find(predicate enumerable.Predicate[K, V]) (K, V, bool) {
+	foundValue := *new(V)
+
+	foundKey, _, found := m.values.Find(func(key K, values *linkedhashmap.LinkedHashMap[V, HV, struct{}]) bool {
+		value, _, ok := values.Find(func(value V, _ struct{}) bool {
+			return predicate(key, value)
+		})
+		foundValue = value
+
+		return ok
+	})
+	if !found {
+		return *new(K), *new(V), false
+	}
+
+	return foundKey, foundValue, true
+}
+
+// Get returns the values of the key in the order they were first put,
+// or an empty slice if the key has no values.
+func (m *SetMultiMap[K, HK, V, HV]) Get(key K) []V {
+	result := []V{}
+
+	if values, ok := m.values.Get(key); ok {
+		values.ForEach(func(value V, _ struct{}) {
+			result = append(result, value)
+		})
+	}
+
+	return result
+}
+
+func (m *SetMultiMap[K, HK, V, HV]) Put(key K, value V) {
+	m.PutAll(key, value)
+}
+
+func (m *SetMultiMap[K, HK, V, HV]) PutAll(key K, values ...V) {
+	if len(values) == 0 {
+		return
+	}
+
+	existing, ok := m.values.Get(key)
+	if !ok {
+		existing = linkedhashmap.NewBuilder[V, HV, struct{}](m.valueHashKey).Build()
+		m.values.Put(key, existing)
+	}
+
+	for _, value := range values {
+		if !existing.ContainsKey(value) {
+			existing.Put(value, struct{}{})
+			m.size++
+		}
+	}
+}
+
+func (m *SetMultiMap[K, HK, V, HV]) RemoveValue(key K, value V) bool {
+	values, ok := m.values.Get(key)
+	if !ok || !values.RemoveKey(value) {
+		return false
+	}
+
+	if values.Empty() {
+		m.values.RemoveKey(key)
+	}
+	m.size--
+
+	return true
+}
+
+// RemoveKey removes all of the values of the key and returns how many there were.
+func (m *SetMultiMap[K, HK, V, HV]) RemoveKey(key K) int {
+	values, ok := m.values.Get(key)
+	if !ok {
+		return 0
+	}
+
+	m.values.RemoveKey(key)
+	m.size -= values.Size()
+
+	return values.Size()
+}
+
+func (m *SetMultiMap[K, HK, V, HV]) ContainsKey(key K) bool {
+	return m.values.ContainsKey(key)
+}
+
+func (m *SetMultiMap[K, HK, V, HV]) ContainsEntry(key K, value V) bool {
+	values, ok := m.values.Get(key)
+
+	return ok && values.ContainsKey(value)
+}
+
+// KeyCount returns the number of distinct keys that have at least one value.
+func (m *SetMultiMap[K, HK, V, HV]) KeyCount() int {
+	return m.values.Size()
+}
+
+// ValueCount returns the number of values of the key.
+func (m *SetMultiMap[K, HK, V, HV]) ValueCount(key K) int {
+	values, ok := m.values.Get(key)
+	if !ok {
+		return 0
+	}
+
+	return values.Size()
+}
@@ -0,0 +1,57 @@
+package setmultimap_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/multimap"
+	"github.com/kaschnit/go-ds/pkg/containers/multimap/setmultimap"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that SetMultiMap implements MultiMap.
+var _ multimap.MultiMap[string, int] = &setmultimap.SetMultiMap[string, string, int, int]{}
+
+func TestSetMultiMapString(t *testing.T) {
+	t.Parallel()
+
+	m := setmultimap.New[string, int]()
+	assert.Equal(t, "SetMultiMap\n", m.String())
+
+	m.PutAll("a", 2, 1, 2)
+	assert.Equal(t, "SetMultiMap\nEntry{Key:a, Value:2},Entry{Key:a, Value:1}", m.String())
+}
+
+func TestSetMultiMapDuplicateValues(t *testing.T) {
+	t.Parallel()
+
+	m := setmultimap.New(entry.New("a", 1), entry.New("a", 2), entry.New("a", 1))
+	assert.Equal(t, 2, m.Size())
+	assert.Equal(t, 2, m.ValueCount("a"))
+	assert.Equal(t, []int{1, 2}, m.Get("a"))
+
+	assert.True(t, m.RemoveValue("a", 1))
+	assert.False(t, m.RemoveValue("a", 1))
+	assert.Equal(t, []int{2}, m.Get("a"))
+}
+
+func TestSetMultiMapHashKey(t *testing.T) {
+	t.Parallel()
+
+	// Keys are slices and values are compared case-insensitively.
+	m := setmultimap.NewBuilder[[]string, string, string, string](
+		func(key []string) string { return strings.Join(key, "/") },
+		strings.ToLower,
+	).
+		Put([]string{"tags", "go"}, "Generics").
+		Build()
+	m.Put([]string{"tags", "go"}, "GENERICS")
+	m.Put([]string{"tags", "go"}, "Maps")
+
+	assert.Equal(t, []string{"Generics", "Maps"}, m.Get([]string{"tags", "go"}))
+	assert.Equal(t, 2, m.Size())
+	assert.True(t, m.ContainsEntry([]string{"tags", "go"}, "maps"))
+	assert.Equal(t, 2, m.RemoveKey([]string{"tags", "go"}))
+	assert.True(t, m.Empty())
+}
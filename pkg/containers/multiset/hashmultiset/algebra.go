@@ -0,0 +1,95 @@
+package hashmultiset
+
+import (
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/multiset"
+)
+
+// Union returns a new multiset in which each value occurs as many times as it does in whichever
+// multiset has more of it.
+func Union[T comparable](left multiset.Multiset[T], right multiset.Multiset[T]) *HashMultiset[T, T] {
+	return UnionKeyed(compare.IdentityHashKey[T], left, right)
+}
+
+// Intersection returns a new multiset in which each value occurs as many times as it does in
+// whichever multiset has less of it.
+func Intersection[T comparable](left multiset.Multiset[T], right multiset.Multiset[T]) *HashMultiset[T, T] {
+	return IntersectionKeyed(compare.IdentityHashKey[T], left, right)
+}
+
+// Sum returns a new multiset in which each value occurs as many times as it does in both
+// multisets together.
+func Sum[T comparable](left multiset.Multiset[T], right multiset.Multiset[T]) *HashMultiset[T, T] {
+	return SumKeyed(compare.IdentityHashKey[T], left, right)
+}
+
+// Difference returns a new multiset in which each value occurs as many times as it does in the
+// left multiset, less the number of times it does in the right multiset.
+func Difference[T comparable](left multiset.Multiset[T], right multiset.Multiset[T]) *HashMultiset[T, T] {
+	return DifferenceKeyed(compare.IdentityHashKey[T], left, right)
+}
+
+// UnionKeyed is Union for values that are keyed by the hashkey.
+func UnionKeyed[T any, H comparable](
+	hashkey compare.HashKey[T, H], left multiset.Multiset[T], right multiset.Multiset[T],
+) *HashMultiset[T, H] {
+	result := NewBuilder(hashkey).Build()
+	left.ForEach(func(value T, count int) {
+		result.SetCount(value, count)
+	})
+	right.ForEach(func(value T, count int) {
+		if count > result.Count(value) {
+			result.SetCount(value, count)
+		}
+	})
+
+	return result
+}
+
+// IntersectionKeyed is Intersection for values that are keyed by the hashkey.
+func IntersectionKeyed[T any, H comparable](
+	hashkey compare.HashKey[T, H], left multiset.Multiset[T], right multiset.Multiset[T],
+) *HashMultiset[T, H] {
+	smaller, larger := left, right
+	if right.DistinctCount() < left.DistinctCount() {
+		smaller, larger = right, left
+	}
+
+	result := NewBuilder(hashkey).Build()
+	smaller.ForEach(func(value T, count int) {
+		if other := larger.Count(value); other < count {
+			result.SetCount(value, other)
+		} else {
+			result.SetCount(value, count)
+		}
+	})
+
+	return result
+}
+
+// SumKeyed is Sum for values that are keyed by the hashkey.
+func SumKeyed[T any, H comparable](
+	hashkey compare.HashKey[T, H], left multiset.Multiset[T], right multiset.Multiset[T],
+) *HashMultiset[T, H] {
+	result := NewBuilder(hashkey).Build()
+	left.ForEach(func(value T, count int) {
+		result.AddCount(value, count)
+	})
+	right.ForEach(func(value T, count int) {
+		result.AddCount(value, count)
+	})
+
+	return result
+}
+
+// DifferenceKeyed is Difference for values that are keyed by the hashkey.
+func DifferenceKeyed[T any, H comparable](
+	hashkey compare.HashKey[T, H], left multiset.Multiset[T], right multiset.Multiset[T],
+) *HashMultiset[T, H] {
+	result := NewBuilder(hashkey).Build()
+	left.ForEach(func(value T, count int) {
+		result.SetCount(value, count-right.Count(value))
+	})
+
+	return result
+}
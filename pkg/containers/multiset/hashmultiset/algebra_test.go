@@ -0,0 +1,116 @@
+package hashmultiset_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/multiset/hashmultiset"
+	"github.com/stretchr/testify/assert"
+)
+
+func countsOf(m *hashmultiset.HashMultiset[string, string]) map[string]int {
+	counts := map[string]int{}
+	m.ForEach(func(value string, count int) {
+		counts[value] = count
+	})
+
+	return counts
+}
+
+func TestMultisetAlgebra(t *testing.T) {
+	t.Parallel()
+
+	left := hashmultiset.New("a", "a", "a", "b", "c")
+	right := hashmultiset.New("a", "b", "b", "d")
+
+	tests := []struct {
+		name     string
+		result   *hashmultiset.HashMultiset[string, string]
+		expected map[string]int
+	}{
+		{
+			name:     "union",
+			result:   hashmultiset.Union[string](left, right),
+			expected: map[string]int{"a": 3, "b": 2, "c": 1, "d": 1},
+		},
+		{
+			name:     "intersection",
+			result:   hashmultiset.Intersection[string](left, right),
+			expected: map[string]int{"a": 1, "b": 1},
+		},
+		{
+			name:     "sum",
+			result:   hashmultiset.Sum[string](left, right),
+			expected: map[string]int{"a": 4, "b": 3, "c": 1, "d": 1},
+		},
+		{
+			name:     "difference",
+			result:   hashmultiset.Difference[string](left, right),
+			expected: map[string]int{"a": 2, "c": 1},
+		},
+		{
+			name:     "intersection with an empty multiset",
+			result:   hashmultiset.Intersection[string](left, hashmultiset.New[string]()),
+			expected: map[string]int{},
+		},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expected, countsOf(testCase.result))
+
+			total := 0
+			for _, count := range testCase.expected {
+				total += count
+			}
+			assert.Equal(t, total, testCase.result.Size())
+		})
+	}
+
+	// The operands are unchanged.
+	assert.Equal(t, map[string]int{"a": 3, "b": 1, "c": 1}, countsOf(left))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "d": 1}, countsOf(right))
+}
+
+func TestMultisetAlgebraKeyed(t *testing.T) {
+	t.Parallel()
+
+	// Words are counted regardless of case.
+	newMultiset := func(values ...string) *hashmultiset.HashMultiset[string, string] {
+		return hashmultiset.NewBuilder(strings.ToLower).AddAll(values...).Build()
+	}
+	lowerCountsOf := func(m *hashmultiset.HashMultiset[string, string]) map[string]int {
+		counts := map[string]int{}
+		m.ForEach(func(value string, count int) {
+			counts[strings.ToLower(value)] += count
+		})
+
+		return counts
+	}
+
+	left := newMultiset("Go", "go", "Rust")
+	right := newMultiset("GO", "rust", "rust", "Zig")
+
+	assert.Equal(
+		t,
+		map[string]int{"go": 2, "rust": 2, "zig": 1},
+		lowerCountsOf(hashmultiset.UnionKeyed[string](strings.ToLower, left, right)),
+	)
+	assert.Equal(
+		t,
+		map[string]int{"go": 1, "rust": 1},
+		lowerCountsOf(hashmultiset.IntersectionKeyed[string](strings.ToLower, left, right)),
+	)
+	assert.Equal(
+		t,
+		map[string]int{"go": 3, "rust": 3, "zig": 1},
+		lowerCountsOf(hashmultiset.SumKeyed[string](strings.ToLower, left, right)),
+	)
+	assert.Equal(
+		t,
+		map[string]int{"go": 1},
+		lowerCountsOf(hashmultiset.DifferenceKeyed[string](strings.ToLower, left, right)),
+	)
+}
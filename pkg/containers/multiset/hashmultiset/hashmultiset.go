@@ -0,0 +1,274 @@
+package hashmultiset
+
+import (
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/queue/priorityqueue/heappq"
+	"github.com/kaschnit/go-ds/pkg/containers/set"
+	"github.com/kaschnit/go-ds/pkg/containers/set/hashset"
+)
+
+type Builder[T any, H comparable] struct {
+	multiset *HashMultiset[T, H]
+}
+
+func NewBuilder[T any, H comparable](hashkey compare.HashKey[T, H]) *Builder[T, H] {
+	return &Builder[T, H]{
+		multiset: &HashMultiset[T, H]{
+			hashkey: hashkey,
+			counts:  make(map[H]*counted[T]),
+			size:    0,
+		},
+	}
+}
+
+func (b *Builder[T, H]) Add(value T) *Builder[T, H] {
+	b.multiset.Add(value)
+
+	return b
+}
+
+func (b *Builder[T, H]) AddAll(values ...T) *Builder[T, H] {
+	for _, value := range values {
+		b.multiset.Add(value)
+	}
+
+	return b
+}
+
+func (b *Builder[T, H]) AddCount(value T, count int) *Builder[T, H] {
+	b.multiset.AddCount(value, count)
+
+	return b
+}
+
+func (b *Builder[T, H]) Build() *HashMultiset[T, H] {
+	result := b.multiset
+
+	// The builder must not modify the multiset it has built.
+	b.multiset = NewBuilder[T, H](result.hashkey).multiset
+
+	return result
+}
+
+type counted[T any] struct {
+	value T
+	count int
+}
+
+// HashMultiset is a multiset that keeps the count of each distinct value in a hash map,
+// replacing the usual map[T]int. Distinct values are enumerated in no particular order.
+type HashMultiset[T any, H comparable] struct {
+	hashkey compare.HashKey[T, H]
+	counts  map[H]*counted[T]
+	size    int
+}
+
+func New[T comparable](values ...T) *HashMultiset[T, T] {
+	return NewBuilder[T, T](compare.IdentityHashKey[T]).AddAll(values...).Build()
+}
+
+func (m *HashMultiset[T, H]) Empty() bool {
+	return m.Size() == 0
+}
+
+// Size returns the total number of occurrences in the multiset.
+func (m *HashMultiset[T, H]) Size() int {
+	return m.size
+}
+
+func (m *HashMultiset[T, H]) Clear() {
+	m.counts = make(map[H]*counted[T])
+	m.size = 0
+}
+
+func (m *HashMultiset[T, H]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("HashMultiset\n")
+
+	strs := make([]string, 0, len(m.counts))
+	m.ForEach(func(value T, count int) {
+		strs = append(strs, entry.NewRef(value, count).String())
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+// ForEach visits each distinct value along with its count.
+func (m *HashMultiset[T, H]) ForEach(op enumerable.Op[T, int]) {
+	for _, c := range m.counts {
+		op(c.value, c.count)
+	}
+}
+
+func (m *HashMultiset[T, H]) Any(predicate enumerable.Predicate[T, int]) bool {
+	_, _, found := m.Find(predicate)
+
+	return found
+}
+
+func (m *HashMultiset[T, H]) All(predicate enumerable.Predicate[T, int]) bool {
+	for _, c := range m.counts {
+		if !predicate(c.value, c.count) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *HashMultiset[T, H]) Find(predicate enumerable.Predicate[T, int]) (T, int, bool) {
+	for _, c := range m.counts {
+		if predicate(c.value, c.count) {
+			return c.value, c.count, true
+		}
+	}
+
+	return *new(T), 0, false
+}
+
+// Add adds one occurrence of the value.
+func (m *HashMultiset[T, H]) Add(value T) {
+	m.AddCount(value, 1)
+}
+
+func (m *HashMultiset[T, H]) AddAll(values ...T) {
+	for _, value := range values {
+		m.Add(value)
+	}
+}
+
+// AddCount adds occurrences of the value and returns its count before.
+// A negative count removes occurrences instead.
+func (m *HashMultiset[T, H]) AddCount(value T, count int) int {
+	key := m.hashkey(value)
+	c := m.counts[key]
+
+	previous := 0
+	if c != nil {
+		previous = c.count
+	}
+
+	return m.setCount(key, value, c, previous+count)
+}
+
+// Remove removes one occurrence of the value. Returns false if the multiset does not contain it.
+func (m *HashMultiset[T, H]) Remove(value T) bool {
+	return m.RemoveCount(value, 1) > 0
+}
+
+// RemoveCount removes up to count occurrences of the value and returns its count before.
+// A negative count removes nothing, rather than adding occurrences.
+func (m *HashMultiset[T, H]) RemoveCount(value T, count int) int {
+	if count < 0 {
+		return m.Count(value)
+	}
+
+	return m.AddCount(value, -count)
+}
+
+// SetCount sets the number of occurrences of the value and returns its count before.
+// A count of zero or less removes the value.
+func (m *HashMultiset[T, H]) SetCount(value T, count int) int {
+	key := m.hashkey(value)
+
+	return m.setCount(key, value, m.counts[key], count)
+}
+
+// setCount sets the number of occurrences of the value with the hashed key and returns its count
+// before. c is the value's entry, or nil if the multiset does not contain the value.
+func (m *HashMultiset[T, H]) setCount(key H, value T, c *counted[T], count int) int {
+	if c == nil {
+		if count > 0 {
+			m.counts[key] = &counted[T]{value: value, count: count}
+			m.size += count
+		}
+
+		return 0
+	}
+
+	previous := c.count
+
+	if count <= 0 {
+		delete(m.counts, key)
+		m.size -= previous
+
+		return previous
+	}
+
+	c.count = count
+	m.size += count - previous
+
+	return previous
+}
+
+func (m *HashMultiset[T, H]) Count(value T) int {
+	if c, ok := m.counts[m.hashkey(value)]; ok {
+		return c.count
+	}
+
+	return 0
+}
+
+func (m *HashMultiset[T, H]) Contains(value T) bool {
+	return m.Count(value) > 0
+}
+
+// DistinctCount returns the number of distinct values in the multiset.
+func (m *HashMultiset[T, H]) DistinctCount() int {
+	return len(m.counts)
+}
+
+// Distinct returns a new set of the distinct values in the multiset.
+func (m *HashMultiset[T, H]) Distinct() set.Set[T] {
+	b := hashset.NewBuilder[T, H](m.hashkey)
+	for _, c := range m.counts {
+		b.Add(c.value)
+	}
+
+	return b.Build()
+}
+
+// Entries returns each distinct value along with its count.
+func (m *HashMultiset[T, H]) Entries() []entry.Entry[T, int] {
+	entries := make([]entry.Entry[T, int], 0, len(m.counts))
+	m.ForEach(func(value T, count int) {
+		entries = append(entries, entry.New(value, count))
+	})
+
+	return entries
+}
+
+// MostCommon returns the n values with the highest counts along with their counts, from the
+// most common to the least. Values with equal counts are returned in no particular order.
+func (m *HashMultiset[T, H]) MostCommon(n int) []entry.Entry[T, int] {
+	if n <= 0 {
+		return []entry.Entry[T, int]{}
+	}
+
+	// Keep the n most common values seen so far, with the least common of them on top
+	// so that it can be dropped when a more common value is seen.
+	leastCommonFirst := heappq.NewBuilder(func(left entry.Entry[T, int], right entry.Entry[T, int]) compare.Priority {
+		return compare.OppositeOrderedComparator(left.Value(), right.Value())
+	}).Build()
+
+	for _, c := range m.counts {
+		leastCommonFirst.Push(entry.New(c.value, c.count))
+
+		if leastCommonFirst.Size() > n {
+			leastCommonFirst.Pop()
+		}
+	}
+
+	result := make([]entry.Entry[T, int], leastCommonFirst.Size())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i], _ = leastCommonFirst.Pop()
+	}
+
+	return result
+}
@@ -0,0 +1,174 @@
+package hashmultiset_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/multiset"
+	"github.com/kaschnit/go-ds/pkg/containers/multiset/hashmultiset"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that HashMultiset implements Multiset.
+var _ multiset.Multiset[int] = hashmultiset.New[int]()
+
+func TestHashMultisetString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "HashMultiset\n", hashmultiset.New[string]().String())
+	assert.Equal(t, "HashMultiset\nEntry{Key:a, Value:3}", hashmultiset.New("a", "a", "a").String())
+
+	lines := strings.Split(hashmultiset.New("a", "b", "b").String(), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[1], "Entry{Key:a, Value:1}")
+	assert.Contains(t, lines[1], "Entry{Key:b, Value:2}")
+}
+
+func TestHashMultisetCounts(t *testing.T) {
+	t.Parallel()
+
+	m := hashmultiset.New("a", "b", "a")
+	assert.Equal(t, 3, m.Size())
+	assert.Equal(t, 2, m.DistinctCount())
+	assert.Equal(t, 2, m.Count("a"))
+	assert.Equal(t, 0, m.Count("z"))
+	assert.True(t, m.Contains("b"))
+	assert.False(t, m.Contains("z"))
+
+	m.Add("c")
+	assert.Equal(t, 0, m.AddCount("d", 5))
+	assert.Equal(t, 5, m.AddCount("d", 2))
+	assert.Equal(t, 11, m.Size())
+
+	assert.True(t, m.Remove("a"))
+	assert.Equal(t, 1, m.Count("a"))
+	assert.True(t, m.Remove("a"))
+	assert.False(t, m.Remove("a"))
+	assert.False(t, m.Contains("a"))
+
+	// Removing more occurrences than there are removes the value.
+	assert.Equal(t, 7, m.RemoveCount("d", 3))
+	assert.Equal(t, 4, m.RemoveCount("d", 10))
+	assert.Equal(t, 0, m.RemoveCount("d", 1))
+	assert.Equal(t, 2, m.Size())
+
+	// Removing a negative count of occurrences removes nothing, rather than adding them.
+	assert.Equal(t, 1, m.RemoveCount("b", -2))
+	assert.Equal(t, 0, m.RemoveCount("e", -1))
+	assert.Equal(t, 1, m.Count("b"))
+	assert.False(t, m.Contains("e"))
+	assert.Equal(t, 2, m.Size())
+
+	assert.Equal(t, 1, m.SetCount("b", 4))
+	assert.Equal(t, 4, m.SetCount("b", 0))
+	assert.Equal(t, 0, m.SetCount("e", -1))
+	assert.Equal(t, []entry.Entry[string, int]{entry.New("c", 1)}, m.Entries())
+
+	m.Clear()
+	assert.True(t, m.Empty())
+	assert.Equal(t, 0, m.DistinctCount())
+}
+
+//nolint:paralleltest // Allocations from parallel tests would be counted too.
+func TestHashMultisetAddExistingDoesNotAllocate(t *testing.T) {
+	m := hashmultiset.New("a")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		m.Add("a")
+		m.AddCount("a", 2)
+		m.SetCount("a", 5)
+		m.RemoveCount("a", 1)
+	})
+
+	assert.Zero(t, allocs)
+	assert.Equal(t, 4, m.Count("a"))
+	assert.Equal(t, 4, m.Size())
+}
+
+func TestHashMultisetBuilderHashKey(t *testing.T) {
+	t.Parallel()
+
+	b := hashmultiset.NewBuilder[string, string](strings.ToLower).
+		Add("Error").
+		AddAll("error", "WARN").
+		AddCount("warn", 2)
+
+	m := b.Build()
+	assert.Equal(t, 2, m.Count("ERROR"))
+	assert.Equal(t, 3, m.Count("Warn"))
+	assert.Equal(t, 5, m.Size())
+
+	// Building again does not share anything with the first multiset.
+	other := b.Add("info").Build()
+	assert.Equal(t, 1, other.Size())
+	assert.False(t, m.Contains("info"))
+
+	distinct := m.Distinct()
+	assert.Equal(t, 2, distinct.Size())
+	assert.True(t, distinct.Contains("ERROR"))
+}
+
+func TestHashMultisetMostCommon(t *testing.T) {
+	t.Parallel()
+
+	m := hashmultiset.New[int]()
+	for value := 1; value <= 6; value++ {
+		m.AddCount(value, value*10)
+	}
+
+	tests := []struct {
+		name     string
+		n        int
+		expected []entry.Entry[int, int]
+	}{
+		{name: "none", n: 0, expected: []entry.Entry[int, int]{}},
+		{name: "negative", n: -1, expected: []entry.Entry[int, int]{}},
+		{name: "one", n: 1, expected: []entry.Entry[int, int]{entry.New(6, 60)}},
+		{
+			name:     "some",
+			n:        3,
+			expected: []entry.Entry[int, int]{entry.New(6, 60), entry.New(5, 50), entry.New(4, 40)},
+		},
+		{
+			name: "more than there are",
+			n:    10,
+			expected: []entry.Entry[int, int]{
+				entry.New(6, 60), entry.New(5, 50), entry.New(4, 40),
+				entry.New(3, 30), entry.New(2, 20), entry.New(1, 10),
+			},
+		},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expected, m.MostCommon(testCase.n))
+		})
+	}
+}
+
+func TestHashMultisetEnumeration(t *testing.T) {
+	t.Parallel()
+
+	m := hashmultiset.New("a", "b", "b", "c", "c", "c")
+
+	visited := map[string]int{}
+	m.ForEach(func(value string, count int) {
+		visited[value] = count
+	})
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, visited)
+
+	value, count, found := m.Find(func(_ string, count int) bool { return count == 2 })
+	assert.True(t, found)
+	assert.Equal(t, "b", value)
+	assert.Equal(t, 2, count)
+
+	_, _, found = m.Find(func(_ string, count int) bool { return count > 3 })
+	assert.False(t, found)
+
+	assert.True(t, m.Any(func(value string, _ int) bool { return value == "c" }))
+	assert.True(t, m.All(func(_ string, count int) bool { return count > 0 }))
+	assert.False(t, m.All(func(_ string, count int) bool { return count > 1 }))
+}
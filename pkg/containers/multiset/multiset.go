@@ -0,0 +1,31 @@
+package multiset
+
+import (
+	"github.com/kaschnit/go-ds/pkg/containers/container"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/set"
+)
+
+// Multiset is a set that counts how many times each value occurs in it, also known as a bag.
+// Enumerating a Multiset visits each distinct value once along with its count.
+// The Size of a Multiset is the total number of occurrences in it.
+//
+//nolint:interfacebloat
+type Multiset[T any] interface {
+	container.Container
+	enumerable.Enumerable[T, int]
+
+	Add(value T)
+	AddAll(values ...T)
+	AddCount(value T, count int) (previous int)
+	Remove(value T) bool
+	RemoveCount(value T, count int) (previous int)
+	SetCount(value T, count int) (previous int)
+	Count(value T) int
+	Contains(value T) bool
+	DistinctCount() int
+	Distinct() set.Set[T]
+	Entries() []entry.Entry[T, int]
+	MostCommon(n int) []entry.Entry[T, int]
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
 	"github.com/kaschnit/go-ds/pkg/containers/list/linkedlist"
+	"github.com/kaschnit/go-ds/pkg/iterator"
 )
 
 type Builder[T any] struct {
@@ -39,6 +41,8 @@ func (b *Builder[T]) Build() *BlockingQueue[T] {
 }
 
 type BlockingQueue[T any] struct {
+	// The front of the list is the front of the queue, so the list is in the same order
+	// that items will be popped.
 	linkedList *linkedlist.DoubleLinkedList[T]
 	bufSize    int
 	sem        chan struct{}
@@ -60,19 +64,41 @@ func (q *BlockingQueue[T]) String() string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("BlockingQueue[capacity=%d]\n", q.bufSize))
 
+	// Items are listed from the most recently pushed to the least recently pushed.
 	strs := make([]string, 0, q.Size())
-	q.linkedList.ForEach(func(_ int, value T) {
+	for itr, ok := q.linkedList.IteratorReverse(); ok; itr, ok = itr.Next() {
+		value, _ := itr.Value()
 		strs = append(strs, fmt.Sprintf("%v", value))
-	})
+	}
 
 	sb.WriteString(strings.Join(strs, ","))
 
 	return sb.String()
 }
 
+func (q *BlockingQueue[T]) ForEach(op enumerable.Op[int, T]) {
+	q.linkedList.ForEach(op)
+}
+
+func (q *BlockingQueue[T]) Any(predicate enumerable.Predicate[int, T]) bool {
+	return q.linkedList.Any(predicate)
+}
+
+func (q *BlockingQueue[T]) All(predicate enumerable.Predicate[int, T]) bool {
+	return q.linkedList.All(predicate)
+}
+
+func (q *BlockingQueue[T]) Find(predicate enumerable.Predicate[int, T]) (int, T, bool) {
+	return q.linkedList.Find(predicate)
+}
+
+func (q *BlockingQueue[T]) Iterator() (iterator.ForwardIterator[int, T], bool) {
+	return q.linkedList.Iterator()
+}
+
 func (q *BlockingQueue[T]) Push(value T) {
 	q.sem <- struct{}{}
-	q.linkedList.Prepend(value)
+	q.linkedList.Append(value)
 }
 
 func (q *BlockingQueue[T]) PushAll(values ...T) {
@@ -84,9 +110,9 @@ func (q *BlockingQueue[T]) PushAll(values ...T) {
 func (q *BlockingQueue[T]) Pop() (T, bool) {
 	<-q.sem
 
-	return q.linkedList.PopBack()
+	return q.linkedList.PopFront()
 }
 
 func (q *BlockingQueue[T]) Peek() (T, bool) {
-	return q.linkedList.GetBack()
+	return q.linkedList.GetFront()
 }
@@ -301,3 +301,32 @@ func TestAddItems_MoreThanCapacity(t *testing.T) {
 		})
 	}
 }
+
+func TestForEachAndIteration(t *testing.T) {
+	t.Parallel()
+
+	q := blockingqueue.NewBuilder[int](5).AddItems(9, 8, 7).Build()
+
+	actual := make([]int, 0, q.Size())
+	q.ForEach(func(key int, value int) {
+		assert.Equal(t, len(actual), key)
+		actual = append(actual, value)
+	})
+	assert.Equal(t, []int{9, 8, 7}, actual)
+
+	actual = make([]int, 0, q.Size())
+	for itr, ok := q.Iterator(); ok; itr, ok = itr.Next() {
+		value, _ := itr.Value()
+		actual = append(actual, value)
+	}
+	assert.Equal(t, []int{9, 8, 7}, actual)
+
+	key, value, ok := q.Find(func(_ int, value int) bool { return value < 9 })
+	assert.True(t, ok)
+	assert.Equal(t, 1, key)
+	assert.Equal(t, 8, value)
+
+	assert.True(t, q.All(func(_ int, value int) bool { return value >= 7 }))
+	assert.False(t, q.Any(func(_ int, value int) bool { return value > 9 }))
+	assert.Equal(t, 3, q.Size())
+}
@@ -4,7 +4,10 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/list/arraylist"
 	"github.com/kaschnit/go-ds/pkg/containers/queue"
+	"github.com/kaschnit/go-ds/pkg/iterator"
 )
 
 func MakeThreadSafe[T any](otherQueue queue.Queue[T]) *ConcurrentQueue[T] {
@@ -55,6 +58,47 @@ func (q *ConcurrentQueue[T]) String() string {
 	return sb.String()
 }
 
+func (q *ConcurrentQueue[T]) ForEach(op enumerable.Op[int, T]) {
+	q.rwlock.RLock()
+	defer q.rwlock.RUnlock()
+
+	q.inner.ForEach(op)
+}
+
+func (q *ConcurrentQueue[T]) Any(predicate enumerable.Predicate[int, T]) bool {
+	q.rwlock.RLock()
+	defer q.rwlock.RUnlock()
+
+	return q.inner.Any(predicate)
+}
+
+func (q *ConcurrentQueue[T]) All(predicate enumerable.Predicate[int, T]) bool {
+	q.rwlock.RLock()
+	defer q.rwlock.RUnlock()
+
+	return q.inner.All(predicate)
+}
+
+func (q *ConcurrentQueue[T]) Find(predicate enumerable.Predicate[int, T]) (int, T, bool) {
+	q.rwlock.RLock()
+	defer q.rwlock.RUnlock()
+
+	return q.inner.Find(predicate)
+}
+
+// Iterator returns an iterator over a snapshot of the queue, so the queue can be modified
+// while the iterator is in use.
+func (q *ConcurrentQueue[T]) Iterator() (iterator.ForwardIterator[int, T], bool) {
+	q.rwlock.RLock()
+	values := make([]T, 0, q.inner.Size())
+	q.inner.ForEach(func(_ int, value T) {
+		values = append(values, value)
+	})
+	q.rwlock.RUnlock()
+
+	return arraylist.New(values...).Iterator()
+}
+
 func (q *ConcurrentQueue[T]) Push(value T) {
 	q.rwlock.Lock()
 	defer q.rwlock.Unlock()
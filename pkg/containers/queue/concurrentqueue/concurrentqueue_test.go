@@ -46,3 +46,36 @@ func TestConcurrentListString(t *testing.T) {
 		})
 	}
 }
+
+func TestConcurrentQueueIterator(t *testing.T) {
+	t.Parallel()
+
+	q := concurrentqueue.MakeThreadSafe[int](linkedqueue.New(1, 2, 3))
+
+	itr, ok := q.Iterator()
+	assert.True(t, ok)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 100; i++ {
+			q.Push(i)
+			q.Pop()
+		}
+	}()
+
+	// The iterator is over a snapshot, so it is unaffected by the concurrent modifications.
+	values := []int{}
+	for ; ok; itr, ok = itr.Next() {
+		value, _ := itr.Value()
+		values = append(values, value)
+	}
+
+	<-done
+	assert.Equal(t, []int{1, 2, 3}, values)
+
+	q.Clear()
+	_, ok = q.Iterator()
+	assert.False(t, ok)
+}
@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
 	"github.com/kaschnit/go-ds/pkg/containers/list/linkedlist"
+	"github.com/kaschnit/go-ds/pkg/iterator"
 )
 
 type LinkedQueue[T any] struct {
+	// The front of the list is the front of the queue, so the list is in the same order
+	// that items will be popped.
 	linkedList *linkedlist.DoubleLinkedList[T]
 }
 
@@ -36,18 +40,40 @@ func (q *LinkedQueue[T]) String() string {
 	sb := strings.Builder{}
 	sb.WriteString("LinkedQueue\n")
 
+	// Items are listed from the most recently pushed to the least recently pushed.
 	strs := make([]string, 0, q.Size())
-	q.linkedList.ForEach(func(_ int, value T) {
+	for itr, ok := q.linkedList.IteratorReverse(); ok; itr, ok = itr.Next() {
+		value, _ := itr.Value()
 		strs = append(strs, fmt.Sprintf("%v", value))
-	})
+	}
 
 	sb.WriteString(strings.Join(strs, ","))
 
 	return sb.String()
 }
 
+func (q *LinkedQueue[T]) ForEach(op enumerable.Op[int, T]) {
+	q.linkedList.ForEach(op)
+}
+
+func (q *LinkedQueue[T]) Any(predicate enumerable.Predicate[int, T]) bool {
+	return q.linkedList.Any(predicate)
+}
+
+func (q *LinkedQueue[T]) All(predicate enumerable.Predicate[int, T]) bool {
+	return q.linkedList.All(predicate)
+}
+
+func (q *LinkedQueue[T]) Find(predicate enumerable.Predicate[int, T]) (int, T, bool) {
+	return q.linkedList.Find(predicate)
+}
+
+func (q *LinkedQueue[T]) Iterator() (iterator.ForwardIterator[int, T], bool) {
+	return q.linkedList.Iterator()
+}
+
 func (q *LinkedQueue[T]) Push(value T) {
-	q.linkedList.Prepend(value)
+	q.linkedList.Append(value)
 }
 
 func (q *LinkedQueue[T]) PushAll(values ...T) {
@@ -57,9 +83,9 @@ func (q *LinkedQueue[T]) PushAll(values ...T) {
 }
 
 func (q *LinkedQueue[T]) Pop() (T, bool) {
-	return q.linkedList.PopBack()
+	return q.linkedList.PopFront()
 }
 
 func (q *LinkedQueue[T]) Peek() (T, bool) {
-	return q.linkedList.GetBack()
+	return q.linkedList.GetFront()
 }
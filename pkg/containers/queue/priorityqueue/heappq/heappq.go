@@ -5,9 +5,73 @@ import (
 	"strings"
 
 	compare "github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/iterator"
 	"golang.org/x/exp/constraints"
 )
 
+// heapPQIterator visits the items of a heap in priority order without modifying the heap.
+// It keeps the frontier, a heap of indices into the heap's items that holds the children of
+// every item visited so far. The next item in priority order is always at the root of the
+// frontier, so each step costs O(log n) and the frontier only grows as far as the iteration
+// actually goes. The frontier is persistent, so advancing an iterator never changes it and
+// every iterator can be advanced any number of times.
+// Modifying the heap invalidates the iterator.
+type heapPQIterator[T any] struct {
+	position int
+	index    int
+	heap     *HeapPQ[T]
+	frontier *frontier
+}
+
+func (a *heapPQIterator[T]) Key() (int, bool) {
+	return a.position, true
+}
+
+func (a *heapPQIterator[T]) Value() (T, bool) {
+	return a.heap.items[a.index], true
+}
+
+func (a *heapPQIterator[T]) Next() (iterator.ForwardIterator[int, T], bool) {
+	if !a.HasNext() {
+		return nil, false
+	}
+
+	nextIndex := a.frontier.index
+	rest := a.heap.mergeFrontiers(a.frontier.left, a.frontier.right)
+
+	return &heapPQIterator[T]{
+		position: a.position + 1,
+		index:    nextIndex,
+		heap:     a.heap,
+		frontier: a.heap.expandFrontier(rest, nextIndex),
+	}, true
+}
+
+func (a *heapPQIterator[T]) HasNext() bool {
+	return a.frontier != nil
+}
+
+// frontier is a node of a persistent leftist heap of indices into the items of a heap.
+// Nodes are never modified, so iterators can share them.
+type frontier struct {
+	index int
+	left  *frontier
+	right *frontier
+
+	// rank is the length of the path down the right children to a missing node.
+	// It is never larger for the left child than for the right one.
+	rank int
+}
+
+func (f *frontier) getRank() int {
+	if f == nil {
+		return 0
+	}
+
+	return f.rank
+}
+
 type Builder[T any] struct {
 	comparator compare.Comparator[T]
 	items      []T
@@ -57,20 +121,81 @@ func (q *HeapPQ[T]) Clear() {
 }
 
 func (q *HeapPQ[T]) String() string {
-	qCpy := q.Copy()
 	sb := strings.Builder{}
 	sb.WriteString("HeapPQ\n")
 
-	strs := make([]string, 0, qCpy.Size())
-	for item, ok := qCpy.Pop(); ok; item, ok = qCpy.Pop() {
+	strs := make([]string, 0, q.Size())
+	q.ForEach(func(_ int, item T) {
 		strs = append(strs, fmt.Sprintf("%v", item))
-	}
+	})
 
 	sb.WriteString(strings.Join(strs, ","))
 
 	return sb.String()
 }
 
+func (q *HeapPQ[T]) ForEach(op enumerable.Op[int, T]) {
+	for itr, ok := q.Iterator(); ok; itr, ok = itr.Next() {
+		key, _ := itr.Key()
+		value, _ := itr.Value()
+		op(key, value)
+	}
+}
+
+func (q *HeapPQ[T]) Any(predicate enumerable.Predicate[int, T]) bool {
+	for itr, ok := q.Iterator(); ok; itr, ok = itr.Next() {
+		key, _ := itr.Key()
+		value, _ := itr.Value()
+
+		if predicate(key, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (q *HeapPQ[T]) All(predicate enumerable.Predicate[int, T]) bool {
+	for itr, ok := q.Iterator(); ok; itr, ok = itr.Next() {
+		key, _ := itr.Key()
+		value, _ := itr.Value()
+
+		if !predicate(key, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (q *HeapPQ[T]) Find(predicate enumerable.Predicate[int, T]) (int, T, bool) {
+	for itr, ok := q.Iterator(); ok; itr, ok = itr.Next() {
+		key, _ := itr.Key()
+		value, _ := itr.Value()
+
+		if predicate(key, value) {
+			return key, value, true
+		}
+	}
+
+	return 0, *new(T), false
+}
+
+// Iterator returns an iterator over the items in the order they would be popped.
+// The heap is not modified by iteration.
+func (q *HeapPQ[T]) Iterator() (iterator.ForwardIterator[int, T], bool) {
+	if q.Empty() {
+		return nil, false
+	}
+
+	return &heapPQIterator[T]{
+		position: 0,
+		index:    1,
+		heap:     q,
+		frontier: q.expandFrontier(nil, 1),
+	}, true
+}
+
 func (q *HeapPQ[T]) Push(value T) {
 	// Push onto the end
 	q.items = append(q.items, value)
@@ -172,6 +297,45 @@ func (q *HeapPQ[T]) percolateDown() {
 	}
 }
 
+// expandFrontier returns the frontier with the children of the item at index added.
+func (q *HeapPQ[T]) expandFrontier(f *frontier, index int) *frontier {
+	for _, childIndex := range [...]int{leftChild(index), rightChild(index)} {
+		if childIndex < len(q.items) {
+			f = q.mergeFrontiers(f, &frontier{index: childIndex, left: nil, right: nil, rank: 1})
+		}
+	}
+
+	return f
+}
+
+// mergeFrontiers returns a frontier holding the indices of both frontiers, copying only the
+// nodes along the right paths and leaving both frontiers unchanged.
+func (q *HeapPQ[T]) mergeFrontiers(a *frontier, b *frontier) *frontier {
+	if a == nil {
+		return b
+	}
+
+	if b == nil {
+		return a
+	}
+
+	if q.comparator(q.items[b.index], q.items[a.index]) == compare.PriorityLeftHigher {
+		a, b = b, a
+	}
+
+	left, right := a.left, q.mergeFrontiers(a.right, b)
+	if left.getRank() < right.getRank() {
+		left, right = right, left
+	}
+
+	return &frontier{
+		index: a.index,
+		left:  left,
+		right: right,
+		rank:  right.getRank() + 1,
+	}
+}
+
 //nolint:gomnd
 func parent(index int) int {
 	return index / 2
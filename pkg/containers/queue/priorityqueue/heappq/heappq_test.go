@@ -1,8 +1,11 @@
 package heappq_test
 
 import (
+	"math/rand"
+	"sort"
 	"testing"
 
+	"github.com/kaschnit/go-ds/pkg/compare"
 	"github.com/kaschnit/go-ds/pkg/containers/queue"
 	"github.com/kaschnit/go-ds/pkg/containers/queue/priorityqueue/heappq"
 	"github.com/stretchr/testify/assert"
@@ -61,8 +64,7 @@ func TestHeapPQString(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
 
-			// Assert twice because HeapPQ.String() makes a copy of the heap, want
-			// to make sure the copy is not sharing data.
+			// Assert twice to make sure HeapPQ.String() does not modify the heap.
 			assert.Equal(t, testCase.expected, testCase.queue.String())
 			assert.Equal(t, testCase.expected, testCase.queue.String())
 		})
@@ -183,3 +185,134 @@ func TestHeapPQKitchenSink(t *testing.T) {
 	value, _ = q.Pop()
 	assert.Equal(t, -10, value)
 }
+
+func TestHeapPQIteration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		values     []int
+		comparator compare.Comparator[int]
+	}{
+		{
+			name:       "a few items",
+			values:     []int{100, 1145, -202, 5, 6, 7},
+			comparator: compare.OrderedComparator[int],
+		},
+		{
+			name:       "duplicate items",
+			values:     []int{3, 1, 3, 2, 1, 3, 2},
+			comparator: compare.OrderedComparator[int],
+		},
+		{
+			name:       "many random items",
+			values:     rand.Perm(500),
+			comparator: compare.OrderedComparator[int],
+		},
+		{
+			name:       "many random items with opposite comparator",
+			values:     rand.Perm(500),
+			comparator: compare.OppositeOrderedComparator[int],
+		},
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := heappq.NewBuilder(testCase.comparator).AddItems(testCase.values...).Build()
+
+			expected := make([]int, len(testCase.values))
+			copy(expected, testCase.values)
+			sort.Slice(expected, func(i, j int) bool {
+				return testCase.comparator(expected[i], expected[j]) == compare.PriorityLeftHigher
+			})
+
+			actual := make([]int, 0, len(expected))
+			expectedKey := 0
+
+			for itr, ok := q.Iterator(); ok; itr, ok = itr.Next() {
+				key, ok := itr.Key()
+				assert.True(t, ok)
+				assert.Equal(t, expectedKey, key)
+
+				value, ok := itr.Value()
+				assert.True(t, ok)
+
+				actual = append(actual, value)
+				expectedKey++
+			}
+
+			assert.Equal(t, expected, actual)
+
+			// Iteration does not modify the heap.
+			assert.Equal(t, len(testCase.values), q.Size())
+
+			for _, value := range expected {
+				popped, ok := q.Pop()
+				assert.True(t, ok)
+				assert.Equal(t, value, popped)
+			}
+		})
+	}
+}
+
+func TestHeapPQIteration_Partial(t *testing.T) {
+	t.Parallel()
+
+	q := heappq.New(rand.Perm(1000)...)
+
+	// Any short-circuits, so only the first few items of the heap are visited.
+	visited := 0
+	found := q.Any(func(_ int, value int) bool {
+		visited++
+
+		return value == 997
+	})
+	assert.True(t, found)
+	assert.Equal(t, 3, visited)
+
+	key, value, ok := q.Find(func(_ int, value int) bool {
+		return value < 990
+	})
+	assert.True(t, ok)
+	assert.Equal(t, 10, key)
+	assert.Equal(t, 989, value)
+	assert.Equal(t, 1000, q.Size())
+}
+
+func TestHeapPQIteration_Persistent(t *testing.T) {
+	t.Parallel()
+
+	q := heappq.New(5, 3, 8, 1, 9, 2)
+
+	first, ok := q.Iterator()
+	assert.True(t, ok)
+
+	second, ok := first.Next()
+	assert.True(t, ok)
+
+	// Advancing the same iterator again gives the same result.
+	again, ok := first.Next()
+	assert.True(t, ok)
+
+	secondValue, _ := second.Value()
+	againValue, _ := again.Value()
+	assert.Equal(t, 8, secondValue)
+	assert.Equal(t, 8, againValue)
+
+	// Advancing later iterators does not affect earlier ones.
+	itr := second
+	for ok = true; ok; itr, ok = itr.Next() {
+	}
+	assert.True(t, first.HasNext())
+	assert.True(t, second.HasNext())
+
+	values := []int{}
+	for itr, ok := again.Next(); ok; itr, ok = itr.Next() {
+		value, _ := itr.Value()
+		values = append(values, value)
+	}
+	assert.Equal(t, []int{5, 3, 2, 1}, values)
+}
@@ -0,0 +1,151 @@
+package topk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/queue/priorityqueue/heappq"
+	"golang.org/x/exp/constraints"
+)
+
+type Builder[T any] struct {
+	capacity   int
+	comparator compare.Comparator[T]
+	items      []T
+}
+
+func NewBuilder[T any](capacity int, comparator compare.Comparator[T]) *Builder[T] {
+	return &Builder[T]{
+		capacity:   capacity,
+		comparator: comparator,
+	}
+}
+
+func (b *Builder[T]) AddItems(items ...T) *Builder[T] {
+	b.items = append(b.items, items...)
+
+	return b
+}
+
+func (b *Builder[T]) Build() *TopK[T] {
+	t := &TopK[T]{
+		capacity:   b.capacity,
+		comparator: b.comparator,
+		// The heap is ordered so that the worst item kept so far is at the root,
+		// which makes it cheap to find and evict when a better item is offered.
+		heap: heappq.NewBuilder(compare.Opposite(b.comparator)).Build(),
+	}
+	t.OfferAll(b.items...)
+
+	return t
+}
+
+// TopK is a bounded collector that keeps the K highest priority items offered to it,
+// according to a comparator. Offering an item is O(log K) regardless of how many items
+// have been offered in total.
+type TopK[T any] struct {
+	capacity   int
+	comparator compare.Comparator[T]
+	heap       *heappq.HeapPQ[T]
+}
+
+func New[T constraints.Ordered](capacity int, values ...T) *TopK[T] {
+	return NewBuilder(capacity, compare.OrderedComparator[T]).AddItems(values...).Build()
+}
+
+func (t *TopK[T]) Empty() bool {
+	return t.Size() == 0
+}
+
+func (t *TopK[T]) Size() int {
+	return t.heap.Size()
+}
+
+func (t *TopK[T]) Clear() {
+	t.heap.Clear()
+}
+
+func (t *TopK[T]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("TopK[capacity=%d]\n", t.capacity))
+
+	sorted := t.Sorted()
+	strs := make([]string, 0, len(sorted))
+
+	for _, item := range sorted {
+		strs = append(strs, fmt.Sprintf("%v", item))
+	}
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+// Capacity returns the maximum number of items kept.
+func (t *TopK[T]) Capacity() int {
+	return t.capacity
+}
+
+// Full returns true if the collector holds as many items as its capacity.
+func (t *TopK[T]) Full() bool {
+	return t.Size() >= t.capacity
+}
+
+// Offer considers the value for inclusion in the top K. If the collector is full, the value
+// is only kept if it is higher priority than the current worst item, which is evicted.
+// Returns true if the value was kept.
+func (t *TopK[T]) Offer(value T) bool {
+	if t.capacity <= 0 {
+		return false
+	}
+
+	if !t.Full() {
+		t.heap.Push(value)
+
+		return true
+	}
+
+	worst, _ := t.heap.Peek()
+	if t.comparator(value, worst) != compare.PriorityLeftHigher {
+		return false
+	}
+
+	t.heap.Pop()
+	t.heap.Push(value)
+
+	return true
+}
+
+// OfferAll offers each of the values in order, returning the number of values that were kept
+// at the time they were offered.
+func (t *TopK[T]) OfferAll(values ...T) int {
+	kept := 0
+
+	for _, value := range values {
+		if t.Offer(value) {
+			kept++
+		}
+	}
+
+	return kept
+}
+
+// Threshold returns the lowest priority item currently kept, which is the item a new value
+// has to beat once the collector is full.
+func (t *TopK[T]) Threshold() (T, bool) {
+	return t.heap.Peek()
+}
+
+// Sorted returns the kept items ordered from highest to lowest priority.
+// This does not modify the collector.
+func (t *TopK[T]) Sorted() []T {
+	result := make([]T, t.heap.Size())
+
+	// The heap yields the items from worst to best, so fill the result from the back.
+	t.heap.ForEach(func(position int, item T) {
+		result[len(result)-1-position] = item
+	})
+
+	return result
+}
@@ -0,0 +1,148 @@
+package topk_test
+
+import (
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/container"
+	"github.com/kaschnit/go-ds/pkg/containers/queue/priorityqueue/topk"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that TopK implements Container.
+var _ container.Container = &topk.TopK[int]{}
+
+func TestTopKString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		topK     *topk.TopK[int]
+		expected string
+	}{
+		{
+			name:     "empty collector",
+			topK:     topk.New[int](3),
+			expected: "TopK[capacity=3]\n",
+		},
+		{
+			name:     "collector with 1 item",
+			topK:     topk.New(3, 987654321),
+			expected: "TopK[capacity=3]\n987654321",
+		},
+		{
+			name:     "collector with more items than capacity",
+			topK:     topk.New(3, 100, 1145, -202, 5, 6, 7),
+			expected: "TopK[capacity=3]\n1145,100,7",
+		},
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Assert twice because String() must not drain the collector.
+			assert.Equal(t, testCase.expected, testCase.topK.String())
+			assert.Equal(t, testCase.expected, testCase.topK.String())
+		})
+	}
+}
+
+func TestTopKSorted(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		capacity   int
+		comparator compare.Comparator[int]
+		values     []int
+		expected   []int
+	}{
+		{
+			name:       "fewer values than capacity",
+			capacity:   5,
+			comparator: compare.OrderedComparator[int],
+			values:     []int{3, 1, 2},
+			expected:   []int{3, 2, 1},
+		},
+		{
+			name:       "more values than capacity",
+			capacity:   3,
+			comparator: compare.OrderedComparator[int],
+			values:     []int{5, 1, 9, 3, 7, 2, 8},
+			expected:   []int{9, 8, 7},
+		},
+		{
+			name:       "smallest values with opposite comparator",
+			capacity:   3,
+			comparator: compare.OppositeOrderedComparator[int],
+			values:     []int{5, 1, 9, 3, 7, 2, 8},
+			expected:   []int{1, 2, 3},
+		},
+		{
+			name:       "duplicate values",
+			capacity:   4,
+			comparator: compare.OrderedComparator[int],
+			values:     []int{4, 4, 1, 4, 2, 4},
+			expected:   []int{4, 4, 4, 4},
+		},
+		{
+			name:       "zero capacity",
+			capacity:   0,
+			comparator: compare.OrderedComparator[int],
+			values:     []int{1, 2, 3},
+			expected:   []int{},
+		},
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			topK := topk.NewBuilder(testCase.capacity, testCase.comparator).AddItems(testCase.values...).Build()
+			assert.Equal(t, testCase.expected, topK.Sorted())
+			assert.Equal(t, len(testCase.expected), topK.Size())
+		})
+	}
+}
+
+func TestTopKOffer(t *testing.T) {
+	t.Parallel()
+
+	topK := topk.New[int](2)
+	assert.True(t, topK.Empty())
+	assert.False(t, topK.Full())
+	assert.Equal(t, 2, topK.Capacity())
+
+	_, ok := topK.Threshold()
+	assert.False(t, ok)
+
+	assert.True(t, topK.Offer(10))
+	assert.True(t, topK.Offer(5))
+	assert.True(t, topK.Full())
+
+	threshold, ok := topK.Threshold()
+	assert.True(t, ok)
+	assert.Equal(t, 5, threshold)
+
+	// Not better than the threshold
+	assert.False(t, topK.Offer(5))
+	assert.False(t, topK.Offer(1))
+
+	// Better than the threshold, evicts 5
+	assert.True(t, topK.Offer(20))
+
+	threshold, ok = topK.Threshold()
+	assert.True(t, ok)
+	assert.Equal(t, 10, threshold)
+	assert.Equal(t, []int{20, 10}, topK.Sorted())
+
+	assert.Equal(t, 1, topK.OfferAll(3, 15, 4))
+	assert.Equal(t, []int{20, 15}, topK.Sorted())
+
+	topK.Clear()
+	assert.True(t, topK.Empty())
+	assert.Equal(t, []int{}, topK.Sorted())
+}
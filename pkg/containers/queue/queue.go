@@ -1,9 +1,18 @@
 package queue
 
-import "github.com/kaschnit/go-ds/pkg/containers/container"
+import (
+	"github.com/kaschnit/go-ds/pkg/containers/container"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/iterable"
+)
 
+// Queue is a container from which items are popped in a well-defined order.
+// Enumeration and iteration visit the items in the order they would be popped,
+// keyed by their position in that order, without modifying the queue.
 type Queue[T any] interface {
 	container.Container
+	enumerable.Enumerable[int, T]
+	iterable.ForwardIterable[int, T]
 
 	Push(value T)
 	PushAll(values ...T)
@@ -198,3 +198,128 @@ func TestClearEmpty(t *testing.T) {
 		})
 	}
 }
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	// Values are pushed in priority order so that every queue pops them in the same order.
+	values := []int{500, 200, 100, 50}
+
+	queues := getQueuesForTest(values...)
+	for i := range queues {
+		q := queues[i]
+		t.Run(fmt.Sprintf("%T", q), func(t *testing.T) {
+			t.Parallel()
+
+			actual := make([]int, 0, len(values))
+			q.ForEach(func(key int, value int) {
+				assert.Equal(t, len(actual), key)
+				actual = append(actual, value)
+			})
+			assert.Equal(t, values, actual)
+			assert.Equal(t, len(values), q.Size())
+		})
+	}
+}
+
+func TestAnyAllFind(t *testing.T) {
+	t.Parallel()
+
+	values := []int{500, 200, 100, 50}
+
+	queues := getQueuesForTest(values...)
+	for i := range queues {
+		q := queues[i]
+		t.Run(fmt.Sprintf("%T", q), func(t *testing.T) {
+			t.Parallel()
+
+			assert.True(t, q.Any(func(_ int, value int) bool { return value == 100 }))
+			assert.False(t, q.Any(func(_ int, value int) bool { return value == 101 }))
+			assert.True(t, q.All(func(_ int, value int) bool { return value >= 50 }))
+			assert.False(t, q.All(func(_ int, value int) bool { return value > 50 }))
+
+			key, value, ok := q.Find(func(_ int, value int) bool { return value < 300 })
+			assert.True(t, ok)
+			assert.Equal(t, 1, key)
+			assert.Equal(t, 200, value)
+
+			_, _, ok = q.Find(func(_ int, value int) bool { return value > 1000 })
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestIteration(t *testing.T) {
+	t.Parallel()
+
+	values := []int{500, 200, 100}
+
+	queues := getQueuesForTest(values...)
+	for i := range queues {
+		q := queues[i]
+		t.Run(fmt.Sprintf("%T", q), func(t *testing.T) {
+			t.Parallel()
+
+			// Iterator to the front of the queue
+			itr, ok := q.Iterator()
+			assert.True(t, ok)
+			assert.True(t, itr.HasNext())
+
+			key, ok := itr.Key()
+			assert.True(t, ok)
+			assert.Equal(t, 0, key)
+
+			val, ok := itr.Value()
+			assert.True(t, ok)
+			assert.Equal(t, 500, val)
+
+			// Iterator to the second item
+			itr, ok = itr.Next()
+			assert.True(t, ok)
+			assert.True(t, itr.HasNext())
+
+			key, ok = itr.Key()
+			assert.True(t, ok)
+			assert.Equal(t, 1, key)
+
+			val, ok = itr.Value()
+			assert.True(t, ok)
+			assert.Equal(t, 200, val)
+
+			// Iterator to the back of the queue
+			itr, ok = itr.Next()
+			assert.True(t, ok)
+			assert.False(t, itr.HasNext())
+
+			key, ok = itr.Key()
+			assert.True(t, ok)
+			assert.Equal(t, 2, key)
+
+			val, ok = itr.Value()
+			assert.True(t, ok)
+			assert.Equal(t, 100, val)
+
+			// Invalid iterator
+			_, ok = itr.Next()
+			assert.False(t, ok)
+
+			// Iteration does not modify the queue
+			assert.Equal(t, len(values), q.Size())
+		})
+	}
+}
+
+func TestIteration_Empty(t *testing.T) {
+	t.Parallel()
+
+	queues := getQueuesForTest[int]()
+	for i := range queues {
+		q := queues[i]
+		t.Run(fmt.Sprintf("%T - Iterator()", q), func(t *testing.T) {
+			t.Parallel()
+
+			_, ok := q.Iterator()
+			assert.False(t, ok)
+		})
+	}
+}
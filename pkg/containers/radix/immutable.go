@@ -0,0 +1,65 @@
+package radix
+
+import (
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+)
+
+// ImmutableTree is a radix tree that never changes. Put and Remove return a new tree that
+// shares all of its nodes with the old one except for those on the path to the changed key,
+// so readers can keep using a tree while a writer builds its successor.
+// To make many changes at once, modify the Tree returned by ToTree and take a Snapshot of it.
+// Since it never changes, an ImmutableTree is safe to share between goroutines.
+type ImmutableTree[V any] struct {
+	view[V]
+}
+
+func NewImmutable[V any](entries ...entry.Entry[[]byte, V]) *ImmutableTree[V] {
+	return New(entries...).Snapshot()
+}
+
+// ToTree returns a mutable tree that starts out with the entries of the tree.
+// Modifying the returned tree does not modify this one.
+func (t *ImmutableTree[V]) ToTree() *Tree[V] {
+	return &Tree[V]{
+		view: t.view,
+		edit: &editToken{},
+	}
+}
+
+func (t *ImmutableTree[V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("ImmutableRadixTree\n")
+	sb.WriteString(strings.Join(t.entryStrings(), ","))
+
+	return sb.String()
+}
+
+// Put returns a tree with the key set to the value.
+func (t *ImmutableTree[V]) Put(key []byte, value V) *ImmutableTree[V] {
+	key = append(make([]byte, 0, len(key)), key...)
+
+	root, old := t.root.put(nil, key, &leaf[V]{key: key, value: value})
+
+	size := t.size
+	if old == nil {
+		size++
+	}
+
+	return &ImmutableTree[V]{
+		view: view[V]{root: root, size: size},
+	}
+}
+
+// Remove returns a tree without the key, or this tree if it does not contain the key.
+func (t *ImmutableTree[V]) Remove(key []byte) *ImmutableTree[V] {
+	root, removed := t.root.remove(nil, key, true)
+	if removed == nil {
+		return t
+	}
+
+	return &ImmutableTree[V]{
+		view: view[V]{root: root, size: t.size - 1},
+	}
+}
@@ -0,0 +1,102 @@
+package radix_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/radix"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that ImmutableTree implements ReadOnlyMap.
+var _ mapp.ReadOnlyMap[[]byte, int] = radix.NewImmutable[int]()
+
+func TestImmutableTreeString(t *testing.T) {
+	t.Parallel()
+
+	tr := radix.NewImmutable(entry.New([]byte("b"), 2), entry.New([]byte("a"), 1))
+	assert.Equal(t, "ImmutableRadixTree\nEntry{Key:a, Value:1},Entry{Key:b, Value:2}", tr.String())
+	assert.Equal(t, "ImmutableRadixTree\n", radix.NewImmutable[int]().String())
+}
+
+func TestImmutableTreePutRemove(t *testing.T) {
+	t.Parallel()
+
+	empty := radix.NewImmutable[int]()
+	one := empty.Put([]byte("romane"), 1)
+	two := one.Put([]byte("romanus"), 2)
+	three := two.Put([]byte("rom"), 3)
+	replaced := three.Put([]byte("romane"), 10)
+
+	assert.True(t, empty.Empty())
+	assert.Equal(t, keysOf("romane"), one.Keys())
+	assert.Equal(t, keysOf("romane", "romanus"), two.Keys())
+	assert.Equal(t, keysOf("rom", "romane", "romanus"), three.Keys())
+	assert.Equal(t, 3, replaced.Size())
+	assert.Equal(t, 1, three.GetOrDefault([]byte("romane"), 0))
+	assert.Equal(t, 10, replaced.GetOrDefault([]byte("romane"), 0))
+
+	removed := replaced.Remove([]byte("rom"))
+	assert.Equal(t, keysOf("romane", "romanus"), removed.Keys())
+	assert.Equal(t, keysOf("rom", "romane", "romanus"), replaced.Keys())
+	assert.Same(t, removed, removed.Remove([]byte("rom")))
+
+	key, value, found := removed.LongestPrefixMatch([]byte("romanesque"))
+	assert.True(t, found)
+	assert.Equal(t, []byte("romane"), key)
+	assert.Equal(t, 10, value)
+}
+
+func TestTreeSnapshot(t *testing.T) {
+	t.Parallel()
+
+	tr := radix.New(entry.New([]byte("romane"), 1), entry.New([]byte("romanus"), 2))
+	snapshot := tr.Snapshot()
+
+	// Modifying the tree after a snapshot copies the shared nodes instead of changing them.
+	tr.Put([]byte("romane"), 10)
+	tr.Put([]byte("rom"), 3)
+	tr.RemoveKey([]byte("romanus"))
+
+	assert.Equal(t, keysOf("rom", "romane"), tr.Keys())
+	assert.Equal(t, 10, tr.GetOrDefault([]byte("romane"), 0))
+	assert.Equal(t, keysOf("romane", "romanus"), snapshot.Keys())
+	assert.Equal(t, 1, snapshot.GetOrDefault([]byte("romane"), 0))
+
+	// The same holds in the other direction.
+	copied := snapshot.ToTree()
+	copied.Clear()
+	copied.Put([]byte("x"), 0)
+	assert.Equal(t, 2, snapshot.Size())
+	assert.Equal(t, keysOf("x"), copied.Keys())
+}
+
+func TestImmutableTreeConcurrentReads(t *testing.T) {
+	t.Parallel()
+
+	tr := radix.New[int]()
+	snapshot := tr.Snapshot()
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 100; i++ {
+		tr.Put([]byte(fmt.Sprintf("key%d", i)), i)
+		current := tr.Snapshot()
+
+		// Readers use each snapshot while the writer goes on to the next one.
+		wg.Add(1)
+		go func(i int, current *radix.ImmutableTree[int]) {
+			defer wg.Done()
+
+			assert.Equal(t, i+1, current.Size())
+			assert.Len(t, current.Keys(), i+1)
+			assert.Equal(t, i, current.GetOrDefault([]byte(fmt.Sprintf("key%d", i)), -1))
+		}(i, current)
+	}
+
+	wg.Wait()
+	assert.True(t, snapshot.Empty())
+	assert.Equal(t, 100, tr.Size())
+}
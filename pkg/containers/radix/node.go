@@ -0,0 +1,262 @@
+package radix
+
+import (
+	"bytes"
+	"sort"
+)
+
+// editToken marks the nodes that a mutable tree created and may therefore modify in place.
+// It must not be zero-sized, so that every token has a distinct address.
+type editToken struct {
+	_ byte
+}
+
+type leaf[V any] struct {
+	key   []byte
+	value V
+}
+
+// node is a node of the tree. The prefix is the part of the key on the edge into the node,
+// which is empty only for the root. Edges are sorted by the first byte of their prefix,
+// which no two edges share.
+type node[V any] struct {
+	prefix []byte
+	leaf   *leaf[V]
+	edges  []*node[V]
+	edit   *editToken
+}
+
+func newRoot[V any](edit *editToken) *node[V] {
+	return &node[V]{
+		prefix: nil,
+		leaf:   nil,
+		edges:  nil,
+		edit:   edit,
+	}
+}
+
+// editable returns the node if the edit token owns it, otherwise a copy that it owns.
+func (n *node[V]) editable(edit *editToken) *node[V] {
+	if edit != nil && n.edit == edit {
+		return n
+	}
+
+	return &node[V]{
+		prefix: n.prefix,
+		leaf:   n.leaf,
+		edges:  append([]*node[V](nil), n.edges...),
+		edit:   edit,
+	}
+}
+
+// edge returns the edge whose prefix starts with the label, and where it is or would be
+// inserted in the sorted edges.
+func (n *node[V]) edge(label byte) (*node[V], int) {
+	i := sort.Search(len(n.edges), func(i int) bool {
+		return n.edges[i].prefix[0] >= label
+	})
+
+	if i < len(n.edges) && n.edges[i].prefix[0] == label {
+		return n.edges[i], i
+	}
+
+	return nil, i
+}
+
+func (n *node[V]) get(key []byte) *leaf[V] {
+	search := key
+	for {
+		if len(search) == 0 {
+			return n.leaf
+		}
+
+		child, _ := n.edge(search[0])
+		if child == nil || !bytes.HasPrefix(search, child.prefix) {
+			return nil
+		}
+
+		search = search[len(child.prefix):]
+		n = child
+	}
+}
+
+// put returns the node with the key set to the value below it, and the leaf it replaced.
+func (n *node[V]) put(edit *editToken, search []byte, entry *leaf[V]) (*node[V], *leaf[V]) {
+	if len(search) == 0 {
+		result := n.editable(edit)
+		old := result.leaf
+		result.leaf = entry
+
+		return result, old
+	}
+
+	child, i := n.edge(search[0])
+	if child == nil {
+		result := n.editable(edit)
+		result.edges = insertAt(result.edges, i, &node[V]{
+			prefix: search,
+			leaf:   entry,
+			edges:  nil,
+			edit:   edit,
+		})
+
+		return result, nil
+	}
+
+	common := commonPrefixLength(search, child.prefix)
+	if common == len(child.prefix) {
+		newChild, old := child.put(edit, search[common:], entry)
+		if newChild == child {
+			return n, old
+		}
+
+		result := n.editable(edit)
+		result.edges[i] = newChild
+
+		return result, old
+	}
+
+	// The key leaves the edge part way along it, so split the edge where they differ.
+	split := &node[V]{
+		prefix: search[:common],
+		leaf:   nil,
+		edges:  nil,
+		edit:   edit,
+	}
+
+	rest := child.editable(edit)
+	rest.prefix = child.prefix[common:]
+	split.edges = []*node[V]{rest}
+
+	if common == len(search) {
+		split.leaf = entry
+	} else {
+		split.edges = insertAt(split.edges, sortedIndex(split.edges, search[common]), &node[V]{
+			prefix: search[common:],
+			leaf:   entry,
+			edges:  nil,
+			edit:   edit,
+		})
+	}
+
+	result := n.editable(edit)
+	result.edges[i] = split
+
+	return result, nil
+}
+
+// remove returns the node with the key removed from below it, and the leaf that was removed.
+// Returns a nil node if nothing is left below a node that is not the root.
+func (n *node[V]) remove(edit *editToken, search []byte, isRoot bool) (*node[V], *leaf[V]) {
+	if len(search) == 0 {
+		if n.leaf == nil {
+			return n, nil
+		}
+
+		removed := n.leaf
+		result := n.editable(edit)
+		result.leaf = nil
+
+		return result.compact(edit, isRoot), removed
+	}
+
+	child, i := n.edge(search[0])
+	if child == nil || !bytes.HasPrefix(search, child.prefix) {
+		return n, nil
+	}
+
+	newChild, removed := child.remove(edit, search[len(child.prefix):], false)
+	if removed == nil {
+		return n, nil
+	}
+
+	result := n.editable(edit)
+	if newChild == nil {
+		result.edges = removeAt(result.edges, i)
+	} else {
+		result.edges[i] = newChild
+	}
+
+	return result.compact(edit, isRoot), removed
+}
+
+// compact removes a node that no longer holds a key and has at most one edge, either dropping
+// it or merging it into its only child, so that every edge stays as long as possible.
+// The node must be owned by the edit token.
+func (n *node[V]) compact(edit *editToken, isRoot bool) *node[V] {
+	if isRoot || n.leaf != nil || len(n.edges) > 1 {
+		return n
+	}
+
+	if len(n.edges) == 0 {
+		return nil
+	}
+
+	child := n.edges[0].editable(edit)
+	child.prefix = concat(n.prefix, child.prefix)
+
+	return child
+}
+
+// walk visits the keys at and below the node in order, stopping early if the op returns false.
+func (n *node[V]) walk(op func(l *leaf[V]) bool) bool {
+	if n.leaf != nil && !op(n.leaf) {
+		return false
+	}
+
+	for _, child := range n.edges {
+		if !child.walk(op) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (n *node[V]) count() int {
+	count := 0
+	n.walk(func(*leaf[V]) bool {
+		count++
+
+		return true
+	})
+
+	return count
+}
+
+func commonPrefixLength(a []byte, b []byte) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+
+	return i
+}
+
+func concat(a []byte, b []byte) []byte {
+	result := make([]byte, 0, len(a)+len(b))
+	result = append(result, a...)
+
+	return append(result, b...)
+}
+
+func sortedIndex[V any](edges []*node[V], label byte) int {
+	return sort.Search(len(edges), func(i int) bool {
+		return edges[i].prefix[0] >= label
+	})
+}
+
+func insertAt[T any](values []T, i int, value T) []T {
+	values = append(values, *new(T))
+	copy(values[i+1:], values[i:])
+	values[i] = value
+
+	return values
+}
+
+func removeAt[T any](values []T, i int) []T {
+	copy(values[i:], values[i+1:])
+	values[len(values)-1] = *new(T)
+
+	return values[:len(values)-1]
+}
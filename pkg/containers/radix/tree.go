@@ -0,0 +1,148 @@
+package radix
+
+import (
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+)
+
+// Tree is a map from byte strings to values stored as a radix tree, in which each edge holds
+// the longest run of bytes that its keys share, so lookups visit one node per branching point
+// rather than one per byte. This makes it well suited to longest-prefix matching on keys such
+// as IP prefixes and paths. Keys are enumerated in lexicographic order.
+// Keys are copied when they are put, but the keys passed to callbacks and returned by the tree
+// are shared with it and must not be modified.
+type Tree[V any] struct {
+	view[V]
+	edit *editToken
+}
+
+func New[V any](entries ...entry.Entry[[]byte, V]) *Tree[V] {
+	edit := &editToken{}
+
+	t := &Tree[V]{
+		view: view[V]{
+			root: newRoot[V](edit),
+			size: 0,
+		},
+		edit: edit,
+	}
+	t.PutAll(entries...)
+
+	return t
+}
+
+// Snapshot returns an immutable copy of the tree. Taking a snapshot does not copy the tree,
+// instead the tree copies the nodes it shares with the snapshot when it next modifies them.
+func (t *Tree[V]) Snapshot() *ImmutableTree[V] {
+	snapshot := &ImmutableTree[V]{
+		view: t.view,
+	}
+
+	// The snapshot now shares the tree's nodes, so the tree must copy them from here on.
+	t.edit = &editToken{}
+
+	return snapshot
+}
+
+func (t *Tree[V]) Clear() {
+	t.root = newRoot[V](t.edit)
+	t.size = 0
+}
+
+func (t *Tree[V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("RadixTree\n")
+	sb.WriteString(strings.Join(t.entryStrings(), ","))
+
+	return sb.String()
+}
+
+func (t *Tree[V]) Put(key []byte, value V) {
+	t.Swap(key, value)
+}
+
+func (t *Tree[V]) PutAll(entries ...entry.Entry[[]byte, V]) {
+	for _, entry := range entries {
+		t.Put(entry.Key(), entry.Value())
+	}
+}
+
+func (t *Tree[V]) PutAllFrom(other mapp.ReadOnlyMap[[]byte, V]) {
+	t.PutAll(other.Entries()...)
+}
+
+func (t *Tree[V]) Swap(key []byte, value V) (V, bool) {
+	key = append(make([]byte, 0, len(key)), key...)
+
+	root, old := t.root.put(t.edit, key, &leaf[V]{key: key, value: value})
+
+	t.root = root
+	if old == nil {
+		t.size++
+
+		return *new(V), false
+	}
+
+	return old.value, true
+}
+
+func (t *Tree[V]) Replace(key []byte, value V) bool {
+	if !t.ContainsKey(key) {
+		return false
+	}
+
+	t.Put(key, value)
+
+	return true
+}
+
+func (t *Tree[V]) RemoveKey(key []byte) bool {
+	root, removed := t.root.remove(t.edit, key, true)
+
+	t.root = root
+	if removed == nil {
+		return false
+	}
+
+	t.size--
+
+	return true
+}
+
+func (t *Tree[V]) RemoveAllKeys(keys ...[]byte) int {
+	removed := 0
+
+	for _, key := range keys {
+		if t.RemoveKey(key) {
+			removed++
+		}
+	}
+
+	return removed
+}
+
+func (t *Tree[V]) RemoveIf(predicate enumerable.Predicate[[]byte, V]) int {
+	keys := [][]byte{}
+	t.ForEach(func(key []byte, value V) {
+		if predicate(key, value) {
+			keys = append(keys, key)
+		}
+	})
+
+	return t.RemoveAllKeys(keys...)
+}
+
+// RemovePrefix removes the keys that start with the prefix and returns how many were removed.
+func (t *Tree[V]) RemovePrefix(prefix []byte) int {
+	keys := [][]byte{}
+	t.WalkPrefix(prefix, func(key []byte, _ V) bool {
+		keys = append(keys, key)
+
+		return true
+	})
+
+	return t.RemoveAllKeys(keys...)
+}
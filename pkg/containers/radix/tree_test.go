@@ -0,0 +1,306 @@
+package radix_test
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/radix"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that Tree implements Map and Enumerable.
+var (
+	_ mapp.Map[[]byte, int]              = radix.New[int]()
+	_ enumerable.Enumerable[[]byte, int] = radix.New[int]()
+)
+
+func keysOf(strs ...string) [][]byte {
+	keys := make([][]byte, 0, len(strs))
+	for _, s := range strs {
+		keys = append(keys, []byte(s))
+	}
+
+	return keys
+}
+
+func TestTreeString(t *testing.T) {
+	t.Parallel()
+
+	tr := radix.New[int]()
+	assert.Equal(t, "RadixTree\n", tr.String())
+
+	tr.Put([]byte("b"), 2)
+	tr.Put([]byte("a"), 1)
+	assert.Equal(t, "RadixTree\nEntry{Key:a, Value:1},Entry{Key:b, Value:2}", tr.String())
+}
+
+func TestTreeLexicographicOrder(t *testing.T) {
+	t.Parallel()
+
+	strs := []string{"romane", "romanus", "", "romulus", "rubens", "ruber", "rubicon", "rubicundus", "r", "\xff", "Z"}
+	tr := radix.New[int]()
+	for i, s := range strs {
+		tr.Put([]byte(s), i)
+	}
+
+	sorted := append([]string{}, strs...)
+	sort.Strings(sorted)
+	assert.Equal(t, keysOf(sorted...), tr.Keys())
+
+	visited := []string{}
+	tr.ForEach(func(key []byte, value int) {
+		visited = append(visited, string(key))
+		assert.Equal(t, strs[value], string(key))
+	})
+	assert.Equal(t, sorted, visited)
+	assert.Len(t, tr.Values(), len(strs))
+	assert.Equal(t, entry.New([]byte{}, 2), tr.Entries()[0])
+
+	it, ok := tr.Iterator()
+	assert.True(t, ok)
+
+	iterated := []string{}
+	for ok {
+		key, _ := it.Key()
+		iterated = append(iterated, string(key))
+		it, ok = it.Next()
+	}
+	assert.Equal(t, sorted, iterated)
+
+	minKey, _, _ := tr.Minimum()
+	maxKey, _, _ := tr.Maximum()
+	assert.Equal(t, []byte{}, minKey)
+	assert.Equal(t, []byte("\xff"), maxKey)
+}
+
+func TestTreePutGetRemove(t *testing.T) {
+	t.Parallel()
+
+	tr := radix.New(entry.New([]byte("romane"), 1), entry.New([]byte("romanus"), 2))
+	assert.Equal(t, 2, tr.Size())
+
+	// The split point of two keys is not a key itself.
+	assert.False(t, tr.ContainsKey([]byte("roman")))
+	assert.False(t, tr.RemoveKey([]byte("roman")))
+	assert.False(t, tr.ContainsKey([]byte("romanes")))
+	assert.True(t, tr.ContainsAllKeys([]byte("romane"), []byte("romanus")))
+
+	old, existed := tr.Swap([]byte("romane"), 10)
+	assert.True(t, existed)
+	assert.Equal(t, 1, old)
+	assert.True(t, tr.Replace([]byte("romanus"), 20))
+	assert.False(t, tr.Replace([]byte("rom"), 0))
+
+	// A key inside an edge splits it.
+	tr.Put([]byte("rom"), 3)
+	assert.Equal(t, 3, tr.GetOrDefault([]byte("rom"), 0))
+	assert.Equal(t, 10, tr.GetOrDefault([]byte("romane"), 0))
+
+	// Changing a key after putting it does not change the tree.
+	key := []byte("rubens")
+	tr.Put(key, 4)
+	key[0] = 'x'
+	assert.True(t, tr.ContainsKey([]byte("rubens")))
+	assert.False(t, tr.ContainsKey(key))
+
+	assert.True(t, tr.RemoveKey([]byte("rom")))
+	assert.False(t, tr.RemoveKey([]byte("rom")))
+	assert.Equal(t, 2, tr.RemoveAllKeys([]byte("romane"), []byte("romanus"), []byte("roma")))
+	assert.Equal(t, keysOf("rubens"), tr.Keys())
+
+	tr.Clear()
+	assert.True(t, tr.Empty())
+	_, ok := tr.Iterator()
+	assert.False(t, ok)
+}
+
+func TestTreeLongestPrefixMatch(t *testing.T) {
+	t.Parallel()
+
+	routes := radix.New(
+		entry.New([]byte("/"), "root"),
+		entry.New([]byte("/api/"), "api"),
+		entry.New([]byte("/api/v1/"), "v1"),
+		entry.New([]byte("/api/v1/users"), "users"),
+		entry.New([]byte("/static/"), "static"),
+	)
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+		value    string
+		found    bool
+	}{
+		{name: "exact match", path: "/api/v1/", expected: "/api/v1/", value: "v1", found: true},
+		{name: "longer path", path: "/api/v1/users/42", expected: "/api/v1/users", value: "users", found: true},
+		{name: "path inside an edge", path: "/api/v", expected: "/api/", value: "api", found: true},
+		{name: "path that leaves an edge", path: "/stat", expected: "/", value: "root", found: true},
+		{name: "no match", path: "api", expected: "", value: "", found: false},
+		{name: "empty path", path: "", expected: "", value: "", found: false},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, value, found := routes.LongestPrefixMatch([]byte(testCase.path))
+			assert.Equal(t, testCase.found, found)
+			assert.Equal(t, testCase.value, value)
+			assert.Equal(t, testCase.expected, string(key))
+		})
+	}
+}
+
+func TestTreeWalkPrefix(t *testing.T) {
+	t.Parallel()
+
+	tr := radix.New[int]()
+	for i, s := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon"} {
+		tr.Put([]byte(s), i)
+	}
+
+	tests := []struct {
+		name     string
+		prefix   string
+		expected []string
+	}{
+		{name: "empty prefix", prefix: "", expected: []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon"}},
+		{name: "prefix ending at a node", prefix: "rom", expected: []string{"romane", "romanus", "romulus"}},
+		{name: "prefix inside an edge", prefix: "romu", expected: []string{"romulus"}},
+		{name: "prefix that is a key", prefix: "ruber", expected: []string{"ruber"}},
+		{name: "prefix that leaves an edge", prefix: "romx", expected: []string{}},
+		{name: "prefix longer than any key", prefix: "rubicons", expected: []string{}},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			visited := []string{}
+			tr.WalkPrefix([]byte(testCase.prefix), func(key []byte, _ int) bool {
+				visited = append(visited, string(key))
+
+				return true
+			})
+			assert.Equal(t, testCase.expected, visited)
+		})
+	}
+
+}
+
+func TestTreeWalkPrefixStop(t *testing.T) {
+	t.Parallel()
+
+	tr := radix.New[int]()
+	for i, s := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon"} {
+		tr.Put([]byte(s), i)
+	}
+
+	// Returning false stops the walk.
+	visited := 0
+	tr.WalkPrefix([]byte("r"), func([]byte, int) bool {
+		visited++
+
+		return visited < 2
+	})
+	assert.Equal(t, 2, visited)
+
+	assert.Equal(t, 3, tr.RemovePrefix([]byte("rub")))
+	assert.Equal(t, keysOf("romane", "romanus", "romulus"), tr.Keys())
+}
+
+func TestTreeWalkPath(t *testing.T) {
+	t.Parallel()
+
+	tr := radix.New(
+		entry.New([]byte(""), 0),
+		entry.New([]byte("a"), 1),
+		entry.New([]byte("abc"), 3),
+		entry.New([]byte("abcde"), 5),
+		entry.New([]byte("abd"), 4),
+	)
+
+	visited := []string{}
+	tr.WalkPath([]byte("abcdef"), func(key []byte, value int) bool {
+		visited = append(visited, string(key))
+		assert.Equal(t, len(key), value)
+
+		return true
+	})
+	assert.Equal(t, []string{"", "a", "abc", "abcde"}, visited)
+
+	visited = []string{}
+	tr.WalkPath([]byte("abcdef"), func(key []byte, _ int) bool {
+		visited = append(visited, string(key))
+
+		return len(key) < 1
+	})
+	assert.Equal(t, []string{"", "a"}, visited)
+}
+
+func TestTreeEnumeration(t *testing.T) {
+	t.Parallel()
+
+	tr := radix.New(entry.New([]byte("b"), 2), entry.New([]byte("a"), 1), entry.New([]byte("c"), 3))
+
+	key, value, found := tr.Find(func(_ []byte, value int) bool { return value > 1 })
+	assert.True(t, found)
+	assert.Equal(t, []byte("b"), key)
+	assert.Equal(t, 2, value)
+
+	_, _, found = tr.Find(func(_ []byte, value int) bool { return value > 3 })
+	assert.False(t, found)
+
+	assert.True(t, tr.Any(func(key []byte, _ int) bool { return bytes.Equal(key, []byte("c")) }))
+	assert.True(t, tr.All(func(_ []byte, value int) bool { return value > 0 }))
+	assert.False(t, tr.All(func(_ []byte, value int) bool { return value > 1 }))
+
+	assert.Equal(t, 2, tr.RemoveIf(func(_ []byte, value int) bool { return value%2 == 1 }))
+	assert.Equal(t, keysOf("b"), tr.Keys())
+}
+
+func TestTreeRandomOperations(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(42))
+	expected := map[string]int{}
+	tr := radix.New[int]()
+
+	for i := 0; i < 5000; i++ {
+		// Short keys over a small alphabet share many prefixes, which splits and merges edges.
+		key := make([]byte, rng.Intn(6))
+		for j := range key {
+			key[j] = "abc"[rng.Intn(3)]
+		}
+
+		if rng.Intn(3) == 0 {
+			_, existed := expected[string(key)]
+			delete(expected, string(key))
+			assert.Equal(t, existed, tr.RemoveKey(key))
+		} else {
+			expected[string(key)] = i
+			tr.Put(key, i)
+		}
+	}
+
+	sorted := make([]string, 0, len(expected))
+	for key := range expected {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	assert.Equal(t, len(expected), tr.Size())
+	assert.Equal(t, keysOf(sorted...), tr.Keys())
+
+	for key, value := range expected {
+		actual, ok := tr.Get([]byte(key))
+		assert.True(t, ok)
+		assert.Equal(t, value, actual)
+	}
+}
@@ -0,0 +1,307 @@
+package radix
+
+import (
+	"bytes"
+
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/iterator"
+)
+
+// WalkFn is called for the entries visited by a walk. Return false to stop walking.
+type WalkFn[V any] func(key []byte, value V) bool
+
+// view implements the read operations shared by Tree and ImmutableTree.
+type view[V any] struct {
+	root *node[V]
+	size int
+}
+
+func (v *view[V]) Empty() bool {
+	return v.Size() == 0
+}
+
+func (v *view[V]) Size() int {
+	return v.size
+}
+
+// ForEach visits the entries in lexicographic order of their keys.
+func (v *view[V]) ForEach(op enumerable.Op[[]byte, V]) {
+	v.root.walk(func(l *leaf[V]) bool {
+		op(l.key, l.value)
+
+		return true
+	})
+}
+
+func (v *view[V]) Any(predicate enumerable.Predicate[[]byte, V]) bool {
+	_, _, found := v.Find(predicate)
+
+	return found
+}
+
+func (v *view[V]) All(predicate enumerable.Predicate[[]byte, V]) bool {
+	return v.root.walk(func(l *leaf[V]) bool {
+		return predicate(l.key, l.value)
+	})
+}
+
+// Find returns the first entry in lexicographic order that satisfies the predicate.
+func (v *view[V]) Find(predicate enumerable.Predicate[[]byte, V]) ([]byte, V, bool) {
+	var found *leaf[V]
+
+	v.root.walk(func(l *leaf[V]) bool {
+		if predicate(l.key, l.value) {
+			found = l
+
+			return false
+		}
+
+		return true
+	})
+
+	if found == nil {
+		return nil, *new(V), false
+	}
+
+	return found.key, found.value, true
+}
+
+// Iterator returns an iterator over the entries in lexicographic order of their keys.
+func (v *view[V]) Iterator() (iterator.ForwardIterator[[]byte, V], bool) {
+	if v.Empty() {
+		return nil, false
+	}
+
+	return newIterator(&frame[V]{n: v.root, next: nil}), true
+}
+
+func (v *view[V]) Keys() [][]byte {
+	keys := make([][]byte, 0, v.Size())
+	v.ForEach(func(key []byte, _ V) {
+		keys = append(keys, key)
+	})
+
+	return keys
+}
+
+func (v *view[V]) Values() []V {
+	values := make([]V, 0, v.Size())
+	v.ForEach(func(_ []byte, value V) {
+		values = append(values, value)
+	})
+
+	return values
+}
+
+func (v *view[V]) Entries() []entry.Entry[[]byte, V] {
+	entries := make([]entry.Entry[[]byte, V], 0, v.Size())
+	v.ForEach(func(key []byte, value V) {
+		entries = append(entries, entry.New(key, value))
+	})
+
+	return entries
+}
+
+func (v *view[V]) Get(key []byte) (V, bool) {
+	l := v.root.get(key)
+	if l == nil {
+		return *new(V), false
+	}
+
+	return l.value, true
+}
+
+func (v *view[V]) GetOrDefault(key []byte, defaultValue V) V {
+	if value, ok := v.Get(key); ok {
+		return value
+	}
+
+	return defaultValue
+}
+
+func (v *view[V]) ContainsKey(key []byte) bool {
+	return v.root.get(key) != nil
+}
+
+func (v *view[V]) ContainsAllKeys(keys ...[]byte) bool {
+	for _, key := range keys {
+		if !v.ContainsKey(key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (v *view[V]) ContainsAnyKey(keys ...[]byte) bool {
+	for _, key := range keys {
+		if v.ContainsKey(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LongestPrefixMatch returns the longest key that is a prefix of s, along with its value.
+// Returns false if no key is a prefix of s.
+func (v *view[V]) LongestPrefixMatch(s []byte) ([]byte, V, bool) {
+	var longest *leaf[V]
+
+	v.WalkPath(s, func(key []byte, value V) bool {
+		longest = &leaf[V]{key: key, value: value}
+
+		return true
+	})
+
+	if longest == nil {
+		return nil, *new(V), false
+	}
+
+	return longest.key, longest.value, true
+}
+
+// WalkPrefix visits the entries whose keys start with the prefix
+// in lexicographic order of their keys.
+func (v *view[V]) WalkPrefix(prefix []byte, fn WalkFn[V]) {
+	n := v.root
+	search := prefix
+
+	for len(search) > 0 {
+		child, _ := n.edge(search[0])
+		if child == nil {
+			return
+		}
+
+		// The prefix may end part way along the edge, in which case every key below the edge
+		// starts with it.
+		if len(search) <= len(child.prefix) {
+			if !bytes.HasPrefix(child.prefix, search) {
+				return
+			}
+
+			n = child
+
+			break
+		}
+
+		if !bytes.HasPrefix(search, child.prefix) {
+			return
+		}
+
+		search = search[len(child.prefix):]
+		n = child
+	}
+
+	n.walk(func(l *leaf[V]) bool {
+		return fn(l.key, l.value)
+	})
+}
+
+// WalkPath visits the entries whose keys are prefixes of the path, from the shortest key
+// to the longest.
+func (v *view[V]) WalkPath(path []byte, fn WalkFn[V]) {
+	n := v.root
+	search := path
+
+	for {
+		if n.leaf != nil && !fn(n.leaf.key, n.leaf.value) {
+			return
+		}
+
+		if len(search) == 0 {
+			return
+		}
+
+		child, _ := n.edge(search[0])
+		if child == nil || !bytes.HasPrefix(search, child.prefix) {
+			return
+		}
+
+		search = search[len(child.prefix):]
+		n = child
+	}
+}
+
+// Minimum returns the entry with the smallest key.
+func (v *view[V]) Minimum() ([]byte, V, bool) {
+	return v.Find(func([]byte, V) bool { return true })
+}
+
+// Maximum returns the entry with the largest key.
+func (v *view[V]) Maximum() ([]byte, V, bool) {
+	n := v.root
+	for len(n.edges) > 0 {
+		n = n.edges[len(n.edges)-1]
+	}
+
+	if n.leaf == nil {
+		return nil, *new(V), false
+	}
+
+	return n.leaf.key, n.leaf.value, true
+}
+
+func (v *view[V]) entryStrings() []string {
+	strs := make([]string, 0, v.Size())
+	v.ForEach(func(key []byte, value V) {
+		strs = append(strs, entry.NewRef(string(key), value).String())
+	})
+
+	return strs
+}
+
+// frame is an immutable stack of the nodes an iterator has yet to visit, so that advancing
+// an iterator does not affect the iterators it was advanced from.
+type frame[V any] struct {
+	n    *node[V]
+	next *frame[V]
+}
+
+type radixIterator[V any] struct {
+	current *leaf[V]
+	pending *frame[V]
+}
+
+// newIterator returns an iterator at the first key below the pending nodes,
+// which must have at least one key below them.
+func newIterator[V any](pending *frame[V]) *radixIterator[V] {
+	for {
+		n := pending.n
+		pending = pending.next
+
+		for i := len(n.edges) - 1; i >= 0; i-- {
+			pending = &frame[V]{n: n.edges[i], next: pending}
+		}
+
+		if n.leaf != nil {
+			return &radixIterator[V]{
+				current: n.leaf,
+				pending: pending,
+			}
+		}
+	}
+}
+
+func (it *radixIterator[V]) Key() ([]byte, bool) {
+	return it.current.key, true
+}
+
+func (it *radixIterator[V]) Value() (V, bool) {
+	return it.current.value, true
+}
+
+func (it *radixIterator[V]) Next() (iterator.ForwardIterator[[]byte, V], bool) {
+	if !it.HasNext() {
+		return nil, false
+	}
+
+	return newIterator(it.pending), true
+}
+
+// HasNext reports whether there is another key, which holds while nodes are pending since
+// every node other than the root has a key at or below it.
+func (it *radixIterator[V]) HasNext() bool {
+	return it.pending != nil
+}
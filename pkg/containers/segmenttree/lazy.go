@@ -0,0 +1,296 @@
+package segmenttree
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+)
+
+// Apply returns the aggregate of a range of length values after the update is applied to each
+// of them, given their aggregate before the update. For example, adding u to each value of a
+// range adds u*length to its sum, but only u to its max.
+type Apply[T any, U any] func(aggregate T, update U, length int) T
+
+// Compose returns a single update that has the same effect as applying the older update and
+// then the newer one. For example, two additions compose to their sum, and two assignments
+// compose to the newer one.
+type Compose[U any] func(older U, newer U) U
+
+type LazyBuilder[T any, U any] struct {
+	combine  Combine[T]
+	identity T
+	apply    Apply[T, U]
+	compose  Compose[U]
+	values   []T
+}
+
+// NewLazyBuilder returns a builder for a lazy segment tree that aggregates values with the
+// combine function and updates ranges of them with the apply and compose functions.
+// Applying an update must distribute over combine, so that updating two ranges and combining
+// them gives the same aggregate as combining the ranges and then updating the result.
+func NewLazyBuilder[T any, U any](
+	combine Combine[T],
+	identity T,
+	apply Apply[T, U],
+	compose Compose[U],
+) *LazyBuilder[T, U] {
+	return &LazyBuilder[T, U]{
+		combine:  combine,
+		identity: identity,
+		apply:    apply,
+		compose:  compose,
+		values:   nil,
+	}
+}
+
+func (b *LazyBuilder[T, U]) Values(values ...T) *LazyBuilder[T, U] {
+	b.values = append(b.values, values...)
+
+	return b
+}
+
+func (b *LazyBuilder[T, U]) Build() *LazySegmentTree[T, U] {
+	base := NewBuilder(b.combine, b.identity).Values(b.values...).Build()
+
+	return &LazySegmentTree[T, U]{
+		combine:    b.combine,
+		identity:   b.identity,
+		apply:      b.apply,
+		compose:    b.compose,
+		size:       base.size,
+		leaves:     base.leaves,
+		tree:       base.tree,
+		pending:    make([]U, base.leaves),
+		hasPending: make([]bool, base.leaves),
+	}
+}
+
+// LazySegmentTree is a segment tree that can also apply an update to every value of a range in
+// logarithmic time. An update to a range is recorded on the few nodes that cover it and only
+// pushed down to their children when a later update or Set needs to go below them.
+// Ranges are half open, so a range [start, end) holds the values from start up to but not
+// including end.
+type LazySegmentTree[T any, U any] struct {
+	combine  Combine[T]
+	identity T
+	apply    Apply[T, U]
+	compose  Compose[U]
+	size     int
+
+	// tree is laid out as in SegmentTree. The aggregate of a node includes its pending update,
+	// which is yet to be applied to its children.
+	leaves     int
+	tree       []T
+	pending    []U
+	hasPending []bool
+}
+
+func (t *LazySegmentTree[T, U]) Empty() bool {
+	return t.Size() == 0
+}
+
+func (t *LazySegmentTree[T, U]) Size() int {
+	return t.size
+}
+
+func (t *LazySegmentTree[T, U]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("LazySegmentTree\n")
+
+	strs := make([]string, 0, t.Size())
+	t.ForEach(func(_ int, value T) {
+		strs = append(strs, fmt.Sprintf("%v", value))
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (t *LazySegmentTree[T, U]) ForEach(op enumerable.Op[int, T]) {
+	for i := 0; i < t.size; i++ {
+		value, _ := t.Get(i)
+		op(i, value)
+	}
+}
+
+func (t *LazySegmentTree[T, U]) Any(predicate enumerable.Predicate[int, T]) bool {
+	_, _, found := t.Find(predicate)
+
+	return found
+}
+
+func (t *LazySegmentTree[T, U]) All(predicate enumerable.Predicate[int, T]) bool {
+	for i := 0; i < t.size; i++ {
+		if value, _ := t.Get(i); !predicate(i, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *LazySegmentTree[T, U]) Find(predicate enumerable.Predicate[int, T]) (int, T, bool) {
+	for i := 0; i < t.size; i++ {
+		if value, _ := t.Get(i); predicate(i, value) {
+			return i, value, true
+		}
+	}
+
+	return -1, *new(T), false
+}
+
+func (t *LazySegmentTree[T, U]) Values() []T {
+	values := make([]T, 0, t.size)
+	t.ForEach(func(_ int, value T) {
+		values = append(values, value)
+	})
+
+	return values
+}
+
+func (t *LazySegmentTree[T, U]) Get(index int) (T, bool) {
+	if index < 0 || index >= t.size {
+		return *new(T), false
+	}
+
+	return t.Query(index, index+1)
+}
+
+// Set replaces the value at the index. Returns false if the index is out of range.
+func (t *LazySegmentTree[T, U]) Set(index int, value T) bool {
+	if index < 0 || index >= t.size {
+		return false
+	}
+
+	t.set(1, 0, t.leaves, index, value)
+
+	return true
+}
+
+// Update applies the update to each value in [start, end). Returns false if the range is out
+// of bounds or start is after end.
+func (t *LazySegmentTree[T, U]) Update(start int, end int, update U) bool {
+	if start < 0 || end > t.size || start > end {
+		return false
+	}
+
+	t.update(1, 0, t.leaves, start, end, update)
+
+	return true
+}
+
+// Query returns the aggregate of the values in [start, end), which is the identity for an
+// empty range. Returns false if the range is out of bounds or start is after end.
+// Queries do not modify the tree, so they are safe to run concurrently with each other.
+func (t *LazySegmentTree[T, U]) Query(start int, end int) (T, bool) {
+	if start < 0 || end > t.size || start > end {
+		return *new(T), false
+	}
+
+	if start == end {
+		return t.identity, true
+	}
+
+	result, _ := t.query(1, 0, t.leaves, start, end)
+
+	return result, true
+}
+
+// QueryAll returns the aggregate of all of the values.
+func (t *LazySegmentTree[T, U]) QueryAll() T {
+	return t.tree[1]
+}
+
+// query returns the aggregate of the part of [start, end) below node i, which spans [lo, hi),
+// along with the length of that part.
+func (t *LazySegmentTree[T, U]) query(i int, lo int, hi int, start int, end int) (T, int) {
+	if end <= lo || hi <= start {
+		return t.identity, 0
+	}
+
+	if start <= lo && hi <= end {
+		return t.tree[i], hi - lo
+	}
+
+	mid := (lo + hi) / 2
+	left, leftLength := t.query(2*i, lo, mid, start, end)
+	right, rightLength := t.query(2*i+1, mid, hi, start, end)
+
+	// The children do not include the pending update of the node yet. An update applied to no
+	// values need not leave the identity unchanged, as with assignment, so it is skipped.
+	result, length := t.combine(left, right), leftLength+rightLength
+	if t.hasPending[i] && length > 0 {
+		result = t.apply(result, t.pending[i], length)
+	}
+
+	return result, length
+}
+
+func (t *LazySegmentTree[T, U]) update(i int, lo int, hi int, start int, end int, update U) {
+	if end <= lo || hi <= start {
+		return
+	}
+
+	if start <= lo && hi <= end {
+		t.applyTo(i, update, hi-lo)
+
+		return
+	}
+
+	t.push(i, hi-lo)
+
+	mid := (lo + hi) / 2
+	t.update(2*i, lo, mid, start, end, update)
+	t.update(2*i+1, mid, hi, start, end, update)
+	t.tree[i] = t.combine(t.tree[2*i], t.tree[2*i+1])
+}
+
+func (t *LazySegmentTree[T, U]) set(i int, lo int, hi int, index int, value T) {
+	if hi-lo == 1 {
+		t.tree[i] = value
+
+		return
+	}
+
+	t.push(i, hi-lo)
+
+	if mid := (lo + hi) / 2; index < mid {
+		t.set(2*i, lo, mid, index, value)
+	} else {
+		t.set(2*i+1, mid, hi, index, value)
+	}
+
+	t.tree[i] = t.combine(t.tree[2*i], t.tree[2*i+1])
+}
+
+// applyTo applies the update to node i, which spans length values.
+func (t *LazySegmentTree[T, U]) applyTo(i int, update U, length int) {
+	t.tree[i] = t.apply(t.tree[i], update, length)
+
+	// Leaves have no children to pass the update on to.
+	if i >= t.leaves {
+		return
+	}
+
+	if t.hasPending[i] {
+		t.pending[i] = t.compose(t.pending[i], update)
+	} else {
+		t.pending[i] = update
+		t.hasPending[i] = true
+	}
+}
+
+// push passes the pending update of node i, which spans length values, on to its children.
+func (t *LazySegmentTree[T, U]) push(i int, length int) {
+	if !t.hasPending[i] {
+		return
+	}
+
+	t.applyTo(2*i, t.pending[i], length/2)
+	t.applyTo(2*i+1, t.pending[i], length/2)
+
+	t.pending[i] = *new(U)
+	t.hasPending[i] = false
+}
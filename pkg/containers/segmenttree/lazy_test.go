@@ -0,0 +1,201 @@
+package segmenttree_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/segmenttree"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that LazySegmentTree implements Enumerable.
+var _ enumerable.Enumerable[int, int] = newRangeAddSum()
+
+func newRangeAddSum(values ...int) *segmenttree.LazySegmentTree[int, int] {
+	return segmenttree.NewLazyBuilder(
+		sum,
+		0,
+		func(aggregate int, update int, length int) int { return aggregate + update*length },
+		func(older int, newer int) int { return older + newer },
+	).Values(values...).Build()
+}
+
+// assignment sets every value of a range.
+type assignment struct {
+	value int
+}
+
+func newRangeAssignMin(values ...int) *segmenttree.LazySegmentTree[int, assignment] {
+	return segmenttree.NewLazyBuilder(
+		minimum,
+		math.MaxInt,
+		func(_ int, update assignment, _ int) int { return update.value },
+		func(_ assignment, newer assignment) assignment { return newer },
+	).Values(values...).Build()
+}
+
+func TestLazySegmentTreeString(t *testing.T) {
+	t.Parallel()
+
+	tr := newRangeAddSum(1, 2, 3)
+	tr.Update(1, 3, 10)
+
+	assert.Equal(t, "LazySegmentTree\n1,12,13", tr.String())
+	assert.Equal(t, "LazySegmentTree\n", newRangeAddSum().String())
+}
+
+func TestLazySegmentTreeUpdate(t *testing.T) {
+	t.Parallel()
+
+	tr := newRangeAddSum(1, 2, 3, 4, 5)
+
+	assert.True(t, tr.Update(0, 5, 1))
+	assert.True(t, tr.Update(1, 3, 10))
+	assert.True(t, tr.Update(2, 2, 100))
+	assert.Equal(t, []int{2, 13, 14, 5, 6}, tr.Values())
+	assert.Equal(t, 40, tr.QueryAll())
+
+	result, ok := tr.Query(2, 4)
+	assert.True(t, ok)
+	assert.Equal(t, 19, result)
+
+	// Setting a value overrides the updates pending above it.
+	assert.True(t, tr.Set(2, 0))
+	assert.True(t, tr.Update(0, 3, 1))
+	assert.Equal(t, []int{3, 14, 1, 5, 6}, tr.Values())
+
+	assert.False(t, tr.Update(0, 6, 1))
+	assert.False(t, tr.Update(3, 2, 1))
+	assert.False(t, tr.Set(5, 0))
+
+	_, ok = tr.Get(-1)
+	assert.False(t, ok)
+}
+
+func TestLazySegmentTreeQueryEmptyRange(t *testing.T) {
+	t.Parallel()
+
+	// An update to the max adds to it regardless of the length of the range, so applying it to
+	// an empty range would not leave the identity.
+	maxAdd := segmenttree.NewLazyBuilder(
+		func(left int, right int) int { return max(left, right) },
+		-1000,
+		func(aggregate int, update int, _ int) int { return aggregate + update },
+		func(older int, newer int) int { return older + newer },
+	).Values(1, 2, 3, 4).Build()
+	assert.True(t, maxAdd.Update(0, 4, 5))
+
+	assignMin := newRangeAssignMin(1, 2, 3, 4, 5, 6)
+	assert.True(t, assignMin.Update(0, 6, assignment{value: 7}))
+
+	for start := 0; start <= 4; start++ {
+		result, ok := maxAdd.Query(start, start)
+		assert.True(t, ok)
+		assert.Equal(t, -1000, result)
+
+		result, ok = assignMin.Query(start, start)
+		assert.True(t, ok)
+		assert.Equal(t, math.MaxInt, result)
+	}
+
+	result, ok := maxAdd.Query(1, 3)
+	assert.True(t, ok)
+	assert.Equal(t, 8, result)
+
+	result, ok = assignMin.Query(1, 3)
+	assert.True(t, ok)
+	assert.Equal(t, 7, result)
+}
+
+func TestLazySegmentTreeEnumeration(t *testing.T) {
+	t.Parallel()
+
+	tr := newRangeAssignMin(5, 6, 7, 8)
+	tr.Update(1, 3, assignment{value: 2})
+
+	index, value, found := tr.Find(func(_ int, value int) bool { return value < 5 })
+	assert.True(t, found)
+	assert.Equal(t, 1, index)
+	assert.Equal(t, 2, value)
+
+	assert.True(t, tr.Any(func(_ int, value int) bool { return value == 8 }))
+	assert.False(t, tr.All(func(_ int, value int) bool { return value > 2 }))
+	assert.Equal(t, 2, tr.QueryAll())
+}
+
+func TestLazySegmentTreeRandomOperations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		size int
+	}{
+		{name: "power of two", size: 32},
+		{name: "not a power of two", size: 45},
+		{name: "one value", size: 1},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			rng := rand.New(rand.NewSource(42))
+			values := make([]int, testCase.size)
+			sums := newRangeAddSum(values...)
+			mins := newRangeAssignMin(values...)
+
+			for i := 0; i < 2000; i++ {
+				start := rng.Intn(len(values))
+				end := start + 1 + rng.Intn(len(values)-start)
+				value := rng.Intn(100)
+
+				switch rng.Intn(4) {
+				case 0:
+					// Apply the same change to both trees as an addition and as an assignment.
+					for j := start; j < end; j++ {
+						values[j] += value
+					}
+
+					current := make([]int, 0, end-start)
+					for j := start; j < end; j++ {
+						current = append(current, values[j])
+					}
+
+					assert.True(t, sums.Update(start, end, value))
+					for j, v := range current {
+						mins.Set(start+j, v)
+					}
+				case 1:
+					for j := start; j < end; j++ {
+						values[j] = value
+					}
+
+					assert.True(t, mins.Update(start, end, assignment{value: value}))
+					for j := start; j < end; j++ {
+						sums.Set(j, value)
+					}
+				case 2:
+					values[start] = value
+					assert.True(t, sums.Set(start, value))
+					assert.True(t, mins.Set(start, value))
+				default:
+					expectedSum, expectedMin := 0, math.MaxInt
+					for _, v := range values[start:end] {
+						expectedSum += v
+						expectedMin = minimum(expectedMin, v)
+					}
+
+					actualSum, _ := sums.Query(start, end)
+					actualMin, _ := mins.Query(start, end)
+					assert.Equal(t, expectedSum, actualSum)
+					assert.Equal(t, expectedMin, actualMin)
+				}
+			}
+
+			assert.Equal(t, values, sums.Values())
+			assert.Equal(t, values, mins.Values())
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package segmenttree
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+)
+
+// Combine combines the aggregates of two adjacent ranges into the aggregate of both.
+// It must be associative, such as addition, min, max or gcd, but it need not be commutative.
+type Combine[T any] func(left T, right T) T
+
+type Builder[T any] struct {
+	combine  Combine[T]
+	identity T
+	values   []T
+}
+
+// NewBuilder returns a builder for a segment tree that aggregates values with the combine
+// function. The identity must leave any value unchanged when combined with it, such as 0 for
+// addition or the largest possible value for min.
+func NewBuilder[T any](combine Combine[T], identity T) *Builder[T] {
+	return &Builder[T]{
+		combine:  combine,
+		identity: identity,
+		values:   nil,
+	}
+}
+
+func (b *Builder[T]) Values(values ...T) *Builder[T] {
+	b.values = append(b.values, values...)
+
+	return b
+}
+
+func (b *Builder[T]) Build() *SegmentTree[T] {
+	leaves := leafCount(len(b.values))
+
+	tree := make([]T, 2*leaves)
+	for i := range tree {
+		tree[i] = b.identity
+	}
+
+	copy(tree[leaves:], b.values)
+
+	t := &SegmentTree[T]{
+		combine:  b.combine,
+		identity: b.identity,
+		size:     len(b.values),
+		leaves:   leaves,
+		tree:     tree,
+	}
+
+	for i := leaves - 1; i > 0; i-- {
+		t.pull(i)
+	}
+
+	return t
+}
+
+// SegmentTree holds a fixed number of values and answers queries for the aggregate of any
+// range of them, updating a value and querying a range each in logarithmic time.
+// Ranges are half open, so a range [start, end) holds the values from start up to but not
+// including end.
+type SegmentTree[T any] struct {
+	combine  Combine[T]
+	identity T
+	size     int
+
+	// tree is a complete binary tree stored in an array, in which node i has children 2i and
+	// 2i+1 and the leaves start at index leaves. Leaves past size hold the identity.
+	leaves int
+	tree   []T
+}
+
+func (t *SegmentTree[T]) Empty() bool {
+	return t.Size() == 0
+}
+
+func (t *SegmentTree[T]) Size() int {
+	return t.size
+}
+
+func (t *SegmentTree[T]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("SegmentTree\n")
+
+	strs := make([]string, 0, t.Size())
+	t.ForEach(func(_ int, value T) {
+		strs = append(strs, fmt.Sprintf("%v", value))
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (t *SegmentTree[T]) ForEach(op enumerable.Op[int, T]) {
+	for i, value := range t.tree[t.leaves : t.leaves+t.size] {
+		op(i, value)
+	}
+}
+
+func (t *SegmentTree[T]) Any(predicate enumerable.Predicate[int, T]) bool {
+	_, _, found := t.Find(predicate)
+
+	return found
+}
+
+func (t *SegmentTree[T]) All(predicate enumerable.Predicate[int, T]) bool {
+	for i, value := range t.tree[t.leaves : t.leaves+t.size] {
+		if !predicate(i, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *SegmentTree[T]) Find(predicate enumerable.Predicate[int, T]) (int, T, bool) {
+	for i, value := range t.tree[t.leaves : t.leaves+t.size] {
+		if predicate(i, value) {
+			return i, value, true
+		}
+	}
+
+	return -1, *new(T), false
+}
+
+func (t *SegmentTree[T]) Values() []T {
+	return append([]T{}, t.tree[t.leaves:t.leaves+t.size]...)
+}
+
+func (t *SegmentTree[T]) Get(index int) (T, bool) {
+	if index < 0 || index >= t.size {
+		return *new(T), false
+	}
+
+	return t.tree[t.leaves+index], true
+}
+
+// Set replaces the value at the index. Returns false if the index is out of range.
+func (t *SegmentTree[T]) Set(index int, value T) bool {
+	if index < 0 || index >= t.size {
+		return false
+	}
+
+	i := t.leaves + index
+	t.tree[i] = value
+
+	for i /= 2; i > 0; i /= 2 {
+		t.pull(i)
+	}
+
+	return true
+}
+
+// Query returns the aggregate of the values in [start, end), which is the identity for an
+// empty range. Returns false if the range is out of bounds or start is after end.
+func (t *SegmentTree[T]) Query(start int, end int) (T, bool) {
+	if start < 0 || end > t.size || start > end {
+		return *new(T), false
+	}
+
+	// Climb from both ends of the range, keeping the left and right aggregates separate
+	// so that the values are combined in order.
+	left, right := t.identity, t.identity
+	for lo, hi := start+t.leaves, end+t.leaves; lo < hi; lo, hi = lo/2, hi/2 {
+		if lo%2 == 1 {
+			left = t.combine(left, t.tree[lo])
+			lo++
+		}
+
+		if hi%2 == 1 {
+			hi--
+			right = t.combine(t.tree[hi], right)
+		}
+	}
+
+	return t.combine(left, right), true
+}
+
+// QueryAll returns the aggregate of all of the values.
+func (t *SegmentTree[T]) QueryAll() T {
+	return t.tree[1]
+}
+
+func (t *SegmentTree[T]) pull(i int) {
+	t.tree[i] = t.combine(t.tree[2*i], t.tree[2*i+1])
+}
+
+// leafCount returns the smallest power of two that is at least size, and at least 1.
+func leafCount(size int) int {
+	leaves := 1
+	for leaves < size {
+		leaves *= 2
+	}
+
+	return leaves
+}
@@ -0,0 +1,171 @@
+package segmenttree_test
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/segmenttree"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that SegmentTree implements Enumerable.
+var _ enumerable.Enumerable[int, int] = segmenttree.NewBuilder(sum, 0).Build()
+
+func sum(left int, right int) int {
+	return left + right
+}
+
+func minimum(left int, right int) int {
+	if left < right {
+		return left
+	}
+
+	return right
+}
+
+func gcd(left int, right int) int {
+	for right != 0 {
+		left, right = right, left%right
+	}
+
+	return left
+}
+
+func TestSegmentTreeString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "SegmentTree\n", segmenttree.NewBuilder(sum, 0).Build().String())
+	assert.Equal(t, "SegmentTree\n3,1,2", segmenttree.NewBuilder(sum, 0).Values(3, 1, 2).Build().String())
+}
+
+func TestSegmentTreeQuery(t *testing.T) {
+	t.Parallel()
+
+	values := []int{12, 18, 6, 9, 30, 4}
+
+	tests := []struct {
+		name     string
+		combine  segmenttree.Combine[int]
+		identity int
+		start    int
+		end      int
+		expected int
+	}{
+		{name: "sum of everything", combine: sum, identity: 0, start: 0, end: 6, expected: 79},
+		{name: "sum of a range", combine: sum, identity: 0, start: 1, end: 4, expected: 33},
+		{name: "sum of an empty range", combine: sum, identity: 0, start: 3, end: 3, expected: 0},
+		{name: "min of a range", combine: minimum, identity: math.MaxInt, start: 3, end: 5, expected: 9},
+		{name: "min of one value", combine: minimum, identity: math.MaxInt, start: 5, end: 6, expected: 4},
+		{name: "gcd of a range", combine: gcd, identity: 0, start: 0, end: 3, expected: 6},
+		{name: "gcd of everything", combine: gcd, identity: 0, start: 0, end: 6, expected: 1},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr := segmenttree.NewBuilder(testCase.combine, testCase.identity).Values(values...).Build()
+
+			result, ok := tr.Query(testCase.start, testCase.end)
+			assert.True(t, ok)
+			assert.Equal(t, testCase.expected, result)
+		})
+	}
+}
+
+func TestSegmentTreeOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	tr := segmenttree.NewBuilder(sum, 0).Values(1, 2, 3).Build()
+
+	_, ok := tr.Query(-1, 2)
+	assert.False(t, ok)
+	_, ok = tr.Query(0, 4)
+	assert.False(t, ok)
+	_, ok = tr.Query(2, 1)
+	assert.False(t, ok)
+	_, ok = tr.Get(3)
+	assert.False(t, ok)
+	assert.False(t, tr.Set(3, 0))
+	assert.False(t, tr.Set(-1, 0))
+
+	empty := segmenttree.NewBuilder(sum, 0).Build()
+	assert.True(t, empty.Empty())
+	assert.Equal(t, 0, empty.QueryAll())
+}
+
+func TestSegmentTreeNonCommutative(t *testing.T) {
+	t.Parallel()
+
+	// Concatenation is associative but not commutative, so the values must be combined in order.
+	concat := func(left string, right string) string { return left + right }
+	values := strings.Split("the quick brown fox jumps", "")
+	tr := segmenttree.NewBuilder(concat, "").Values(values...).Build()
+
+	for start := 0; start <= len(values); start++ {
+		for end := start; end <= len(values); end++ {
+			result, ok := tr.Query(start, end)
+			assert.True(t, ok)
+			assert.Equal(t, strings.Join(values[start:end], ""), result)
+		}
+	}
+
+	assert.True(t, tr.Set(0, "T"))
+	assert.Equal(t, "The quick brown fox jumps", tr.QueryAll())
+}
+
+func TestSegmentTreeEnumeration(t *testing.T) {
+	t.Parallel()
+
+	tr := segmenttree.NewBuilder(sum, 0).Values(5, 6, 7).Build()
+	tr.Set(1, 8)
+
+	assert.Equal(t, []int{5, 8, 7}, tr.Values())
+
+	index, value, found := tr.Find(func(_ int, value int) bool { return value > 5 })
+	assert.True(t, found)
+	assert.Equal(t, 1, index)
+	assert.Equal(t, 8, value)
+
+	assert.True(t, tr.Any(func(_ int, value int) bool { return value == 7 }))
+	assert.True(t, tr.All(func(_ int, value int) bool { return value > 4 }))
+	assert.False(t, tr.All(func(_ int, value int) bool { return value > 5 }))
+}
+
+func TestSegmentTreeRandomOperations(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(42))
+	values := make([]int, 37)
+
+	for i := range values {
+		values[i] = rng.Intn(1000)
+	}
+
+	tr := segmenttree.NewBuilder(minimum, math.MaxInt).Values(values...).Build()
+
+	for i := 0; i < 2000; i++ {
+		if rng.Intn(2) == 0 {
+			index, value := rng.Intn(len(values)), rng.Intn(1000)
+			values[index] = value
+			assert.True(t, tr.Set(index, value))
+
+			continue
+		}
+
+		start := rng.Intn(len(values))
+		end := start + 1 + rng.Intn(len(values)-start)
+
+		expected := math.MaxInt
+		for _, value := range values[start:end] {
+			expected = minimum(expected, value)
+		}
+
+		result, ok := tr.Query(start, end)
+		assert.True(t, ok)
+		assert.Equal(t, expected, result)
+	}
+}
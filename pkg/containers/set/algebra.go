@@ -0,0 +1,41 @@
+package set
+
+// IsSubsetOf returns true if every value in the set is also in the other set.
+func IsSubsetOf[T any](s Set[T], other Set[T]) bool {
+	if s.Size() > other.Size() {
+		return false
+	}
+
+	return s.All(func(value T, _ T) bool {
+		return other.Contains(value)
+	})
+}
+
+// IsSupersetOf returns true if the set contains every value in the other set.
+func IsSupersetOf[T any](s Set[T], other Set[T]) bool {
+	return IsSubsetOf(other, s)
+}
+
+// IsDisjoint returns true if the sets have no values in common.
+func IsDisjoint[T any](s Set[T], other Set[T]) bool {
+	smaller, larger := bySize(s, other)
+
+	return !smaller.Any(func(value T, _ T) bool {
+		return larger.Contains(value)
+	})
+}
+
+// Equals returns true if the sets contain the same values.
+func Equals[T any](s Set[T], other Set[T]) bool {
+	return s.Size() == other.Size() && IsSubsetOf(s, other)
+}
+
+// bySize returns the sets ordered from smaller to larger, so that operations that only need
+// to look at the values of one of the sets can look at the fewest values.
+func bySize[T any](s Set[T], other Set[T]) (Set[T], Set[T]) {
+	if other.Size() < s.Size() {
+		return other, s
+	}
+
+	return s, other
+}
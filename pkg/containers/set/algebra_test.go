@@ -0,0 +1,111 @@
+package set_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/set"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetPredicates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		left             []int
+		right            []int
+		expectedSubset   bool
+		expectedSuperset bool
+		expectedDisjoint bool
+		expectedEquals   bool
+	}{
+		{
+			name:             "both empty",
+			left:             []int{},
+			right:            []int{},
+			expectedSubset:   true,
+			expectedSuperset: true,
+			expectedDisjoint: true,
+			expectedEquals:   true,
+		},
+		{
+			name:             "empty left",
+			left:             []int{},
+			right:            []int{1, 2},
+			expectedSubset:   true,
+			expectedSuperset: false,
+			expectedDisjoint: true,
+			expectedEquals:   false,
+		},
+		{
+			name:             "proper subset",
+			left:             []int{1, 2},
+			right:            []int{3, 2, 1},
+			expectedSubset:   true,
+			expectedSuperset: false,
+			expectedDisjoint: false,
+			expectedEquals:   false,
+		},
+		{
+			name:             "proper superset",
+			left:             []int{1, 2, 3, 4},
+			right:            []int{4, 1},
+			expectedSubset:   false,
+			expectedSuperset: true,
+			expectedDisjoint: false,
+			expectedEquals:   false,
+		},
+		{
+			name:             "equal",
+			left:             []int{1, 2, 3},
+			right:            []int{3, 1, 2},
+			expectedSubset:   true,
+			expectedSuperset: true,
+			expectedDisjoint: false,
+			expectedEquals:   true,
+		},
+		{
+			name:             "overlapping",
+			left:             []int{1, 2, 3},
+			right:            []int{3, 4, 5},
+			expectedSubset:   false,
+			expectedSuperset: false,
+			expectedDisjoint: false,
+			expectedEquals:   false,
+		},
+		{
+			name:             "disjoint",
+			left:             []int{1, 2},
+			right:            []int{3, 4, 5},
+			expectedSubset:   false,
+			expectedSuperset: false,
+			expectedDisjoint: true,
+			expectedEquals:   false,
+		},
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			lefts := getSetsForTest(testCase.left...)
+			rights := getSetsForTest(testCase.right...)
+			for i := range lefts {
+				left := lefts[i]
+				right := rights[i]
+				t.Run(fmt.Sprintf("%T", left), func(t *testing.T) {
+					t.Parallel()
+
+					assert.Equal(t, testCase.expectedSubset, set.IsSubsetOf(left, right))
+					assert.Equal(t, testCase.expectedSuperset, set.IsSupersetOf(left, right))
+					assert.Equal(t, testCase.expectedDisjoint, set.IsDisjoint(left, right))
+					assert.Equal(t, testCase.expectedDisjoint, set.IsDisjoint(right, left))
+					assert.Equal(t, testCase.expectedEquals, set.Equals(left, right))
+					assert.Equal(t, testCase.expectedEquals, set.Equals(right, left))
+				})
+			}
+		})
+	}
+}
@@ -8,7 +8,7 @@ import (
 	"github.com/kaschnit/go-ds/pkg/containers/set"
 )
 
-func MakeThreadSafe[T comparable](otherSet set.Set[T]) *ConcurrentSet[T] {
+func MakeThreadSafe[T any](otherSet set.Set[T]) *ConcurrentSet[T] {
 	if c, ok := otherSet.(*ConcurrentSet[T]); ok {
 		return c
 	}
@@ -19,7 +19,7 @@ func MakeThreadSafe[T comparable](otherSet set.Set[T]) *ConcurrentSet[T] {
 	}
 }
 
-type ConcurrentSet[T comparable] struct {
+type ConcurrentSet[T any] struct {
 	inner  set.Set[T]
 	rwlock sync.RWMutex
 }
@@ -133,3 +133,50 @@ func (s *ConcurrentSet[T]) ContainsAny(values ...T) bool {
 
 	return s.inner.ContainsAny(values...)
 }
+
+// UnionWith adds all of the values of the other set to the set,
+// and returns the number of values that were added.
+// The other set is read before this set is locked, so that two concurrent sets can be combined
+// with each other from different goroutines without deadlocking.
+func (s *ConcurrentSet[T]) UnionWith(other set.Set[T]) int {
+	if s == other {
+		return 0
+	}
+
+	values := []T{}
+	other.ForEach(func(value T, _ T) {
+		values = append(values, value)
+	})
+
+	s.rwlock.Lock()
+	defer s.rwlock.Unlock()
+
+	before := s.inner.Size()
+	s.inner.AddAll(values...)
+
+	return s.inner.Size() - before
+}
+
+// RetainAll removes the values that are not in the other set from the set,
+// and returns the number of values that were removed.
+// As with UnionWith, the other set is not read while this set is locked, so values that are
+// added to this set while RetainAll is running are kept.
+func (s *ConcurrentSet[T]) RetainAll(other set.Set[T]) int {
+	if s == other {
+		return 0
+	}
+
+	values := []T{}
+	s.ForEach(func(value T, _ T) {
+		values = append(values, value)
+	})
+
+	missing := []T{}
+	for _, value := range values {
+		if !other.Contains(value) {
+			missing = append(missing, value)
+		}
+	}
+
+	return s.RemoveAll(missing...)
+}
@@ -138,3 +138,68 @@ func TestMakeThreadSafe_AlreadyThreadSafe(t *testing.T) {
 	assert.Equal(t, c1, c3)
 	assert.Equal(t, c2, c3)
 }
+
+func TestConcurrentSetHashKey(t *testing.T) {
+	t.Parallel()
+
+	s := concurrentset.MakeThreadSafe[[]string](
+		hashset.NewBuilder[[]string, string](func(value []string) string {
+			return strings.Join(value, "/")
+		}).Build(),
+	)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			s.Add([]string{"dir", fmt.Sprint(i % 5)})
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 5, s.Size())
+	assert.True(t, s.Contains([]string{"dir", "3"}))
+}
+
+func TestConcurrentSetUnionWithAndRetainAll(t *testing.T) {
+	t.Parallel()
+
+	s := concurrentset.MakeThreadSafe[int](hashset.New(1, 2, 3))
+	assert.Equal(t, 2, s.UnionWith(hashset.New(3, 4, 5)))
+	assert.Equal(t, 5, s.Size())
+	assert.Equal(t, 0, s.UnionWith(s))
+
+	assert.Equal(t, 3, s.RetainAll(hashset.New(2, 4, 6)))
+	assert.True(t, set.Equals[int](hashset.New(2, 4), s))
+	assert.Equal(t, 0, s.RetainAll(s))
+}
+
+func TestConcurrentSetCombineWithEachOther(t *testing.T) {
+	t.Parallel()
+
+	left := concurrentset.MakeThreadSafe[int](hashset.New(1, 2, 3))
+	right := concurrentset.MakeThreadSafe[int](hashset.New(3, 4, 5))
+
+	// Each set reads the other while the other may be writing, which must not deadlock.
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			left.UnionWith(right)
+			left.RetainAll(right)
+		}()
+		go func() {
+			defer wg.Done()
+			right.UnionWith(left)
+			right.RetainAll(left)
+		}()
+	}
+	wg.Wait()
+
+	universe := hashset.New(1, 2, 3, 4, 5)
+	assert.True(t, set.IsSubsetOf[int](left, universe))
+	assert.True(t, set.IsSubsetOf[int](right, universe))
+}
@@ -0,0 +1,145 @@
+package hashset
+
+import (
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/set"
+)
+
+// Union returns a new set with the values that are in either set.
+func Union[T comparable](left set.Set[T], right set.Set[T]) *HashSet[T] {
+	return &HashSet[T]{values: UnionKeyed(compare.IdentityHashKey[T], left, right)}
+}
+
+// Intersection returns a new set with the values that are in both sets.
+func Intersection[T comparable](left set.Set[T], right set.Set[T]) *HashSet[T] {
+	return &HashSet[T]{values: IntersectionKeyed(compare.IdentityHashKey[T], left, right)}
+}
+
+// Difference returns a new set with the values of the left set that are not in the right set.
+func Difference[T comparable](left set.Set[T], right set.Set[T]) *HashSet[T] {
+	return &HashSet[T]{values: DifferenceKeyed(compare.IdentityHashKey[T], left, right)}
+}
+
+// SymmetricDifference returns a new set with the values that are in exactly one of the sets.
+func SymmetricDifference[T comparable](left set.Set[T], right set.Set[T]) *HashSet[T] {
+	return &HashSet[T]{values: SymmetricDifferenceKeyed(compare.IdentityHashKey[T], left, right)}
+}
+
+// UnionKeyed is Union for values that are keyed by the hashkey.
+func UnionKeyed[T any, H comparable](
+	hashkey compare.HashKey[T, H], left set.Set[T], right set.Set[T],
+) *KeyedHashSet[T, H] {
+	result := NewBuilder(hashkey).Build()
+	result.UnionWith(left)
+	result.UnionWith(right)
+
+	return result
+}
+
+// IntersectionKeyed is Intersection for values that are keyed by the hashkey.
+func IntersectionKeyed[T any, H comparable](
+	hashkey compare.HashKey[T, H], left set.Set[T], right set.Set[T],
+) *KeyedHashSet[T, H] {
+	smaller, larger := left, right
+	if right.Size() < left.Size() {
+		smaller, larger = right, left
+	}
+
+	result := NewBuilder(hashkey).Build()
+	smaller.ForEach(func(value T, _ T) {
+		if larger.Contains(value) {
+			result.Add(value)
+		}
+	})
+
+	return result
+}
+
+// DifferenceKeyed is Difference for values that are keyed by the hashkey.
+func DifferenceKeyed[T any, H comparable](
+	hashkey compare.HashKey[T, H], left set.Set[T], right set.Set[T],
+) *KeyedHashSet[T, H] {
+	result := NewBuilder(hashkey).Build()
+	left.ForEach(func(value T, _ T) {
+		if !right.Contains(value) {
+			result.Add(value)
+		}
+	})
+
+	return result
+}
+
+// SymmetricDifferenceKeyed is SymmetricDifference for values that are keyed by the hashkey.
+func SymmetricDifferenceKeyed[T any, H comparable](
+	hashkey compare.HashKey[T, H], left set.Set[T], right set.Set[T],
+) *KeyedHashSet[T, H] {
+	result := DifferenceKeyed(hashkey, left, right)
+	right.ForEach(func(value T, _ T) {
+		if !left.Contains(value) {
+			result.Add(value)
+		}
+	})
+
+	return result
+}
+
+// UnionWith adds all of the values of the other set to the set,
+// and returns the number of values that were added.
+func (s *HashSet[T]) UnionWith(other set.Set[T]) int {
+	if otherSet, ok := other.(*HashSet[T]); ok {
+		return s.values.UnionWith(otherSet.values)
+	}
+
+	return s.values.UnionWith(other)
+}
+
+// RetainAll removes the values that are not in the other set from the set,
+// and returns the number of values that were removed.
+func (s *HashSet[T]) RetainAll(other set.Set[T]) int {
+	if otherSet, ok := other.(*HashSet[T]); ok {
+		return s.values.RetainAll(otherSet.values)
+	}
+
+	return s.values.RetainAll(other)
+}
+
+// UnionWith adds all of the values of the other set to the set,
+// and returns the number of values that were added.
+func (s *KeyedHashSet[T, H]) UnionWith(other set.Set[T]) int {
+	before := len(s.values)
+	other.ForEach(func(value T, _ T) {
+		s.Add(value)
+	})
+
+	return len(s.values) - before
+}
+
+// RetainAll removes the values that are not in the other set from the set,
+// and returns the number of values that were removed.
+func (s *KeyedHashSet[T, H]) RetainAll(other set.Set[T]) int {
+	if s == other {
+		return 0
+	}
+
+	before := len(s.values)
+
+	if other.Size() < len(s.values) {
+		// Look up the fewer values of the other set here, rather than every value here there.
+		retained := make(map[H]T, other.Size())
+		other.ForEach(func(value T, _ T) {
+			hashedValue := s.hashkey(value)
+			if existing, ok := s.values[hashedValue]; ok {
+				retained[hashedValue] = existing
+			}
+		})
+		s.values = retained
+	} else {
+		for hashedValue, value := range s.values {
+			if !other.Contains(value) {
+				delete(s.values, hashedValue)
+			}
+		}
+	}
+
+	return before - len(s.values)
+}
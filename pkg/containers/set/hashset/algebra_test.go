@@ -0,0 +1,184 @@
+package hashset_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/set"
+	"github.com/kaschnit/go-ds/pkg/containers/set/hashset"
+	"github.com/kaschnit/go-ds/pkg/containers/set/linkedhashset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOperations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                        string
+		left                        []int
+		right                       []int
+		expectedUnion               []int
+		expectedIntersection        []int
+		expectedDifference          []int
+		expectedSymmetricDifference []int
+	}{
+		{
+			name:                        "both empty",
+			left:                        []int{},
+			right:                       []int{},
+			expectedUnion:               []int{},
+			expectedIntersection:        []int{},
+			expectedDifference:          []int{},
+			expectedSymmetricDifference: []int{},
+		},
+		{
+			name:                        "overlapping",
+			left:                        []int{1, 2, 3},
+			right:                       []int{3, 4},
+			expectedUnion:               []int{1, 2, 3, 4},
+			expectedIntersection:        []int{3},
+			expectedDifference:          []int{1, 2},
+			expectedSymmetricDifference: []int{1, 2, 4},
+		},
+		{
+			name:                        "disjoint",
+			left:                        []int{1},
+			right:                       []int{2, 3},
+			expectedUnion:               []int{1, 2, 3},
+			expectedIntersection:        []int{},
+			expectedDifference:          []int{1},
+			expectedSymmetricDifference: []int{1, 2, 3},
+		},
+		{
+			name:                        "subset",
+			left:                        []int{2},
+			right:                       []int{1, 2, 3},
+			expectedUnion:               []int{1, 2, 3},
+			expectedIntersection:        []int{2},
+			expectedDifference:          []int{},
+			expectedSymmetricDifference: []int{1, 3},
+		},
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Mix set types to show that the operands can be any Set.
+			left := hashset.New(testCase.left...)
+			right := linkedhashset.New(testCase.right...)
+
+			assert.True(t, set.Equals[int](hashset.New(testCase.expectedUnion...), hashset.Union[int](left, right)))
+			assert.True(t, set.Equals[int](hashset.New(testCase.expectedIntersection...), hashset.Intersection[int](left, right)))
+			assert.True(t, set.Equals[int](hashset.New(testCase.expectedIntersection...), hashset.Intersection[int](right, left)))
+			assert.True(t, set.Equals[int](hashset.New(testCase.expectedDifference...), hashset.Difference[int](left, right)))
+			assert.True(t, set.Equals[int](hashset.New(testCase.expectedSymmetricDifference...), hashset.SymmetricDifference[int](left, right)))
+
+			// The operands are not modified.
+			assert.Equal(t, len(testCase.left), left.Size())
+			assert.Equal(t, len(testCase.right), right.Size())
+		})
+	}
+}
+
+func TestHashSetUnionWith(t *testing.T) {
+	t.Parallel()
+
+	s := hashset.New(1, 2)
+	assert.Equal(t, 2, s.UnionWith(hashset.New(2, 3, 4)))
+	assert.True(t, set.Equals[int](hashset.New(1, 2, 3, 4), s))
+	assert.Equal(t, 0, s.UnionWith(s))
+
+	// The result of combining hash sets is a hash set.
+	union := hashset.Union[int](hashset.New(1), hashset.New(2))
+	assert.IsType(t, &hashset.HashSet[int]{}, union)
+	assert.Equal(t, 1, union.UnionWith(hashset.New(2, 3)))
+	assert.True(t, set.Equals[int](hashset.New(1, 2, 3), union))
+}
+
+func TestHashSetRetainAll(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		initial         []int
+		other           []int
+		expectedRemoved int
+		expected        []int
+	}{
+		{
+			name:            "other is smaller",
+			initial:         []int{1, 2, 3, 4, 5},
+			other:           []int{2, 4, 6},
+			expectedRemoved: 3,
+			expected:        []int{2, 4},
+		},
+		{
+			name:            "other is larger",
+			initial:         []int{1, 2},
+			other:           []int{2, 3, 4, 5},
+			expectedRemoved: 1,
+			expected:        []int{2},
+		},
+		{
+			name:            "other is empty",
+			initial:         []int{1, 2},
+			other:           []int{},
+			expectedRemoved: 2,
+			expected:        []int{},
+		},
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := hashset.New(testCase.initial...)
+			assert.Equal(t, testCase.expectedRemoved, s.RetainAll(hashset.New(testCase.other...)))
+			assert.True(t, set.Equals[int](hashset.New(testCase.expected...), s))
+			assert.Equal(t, 0, s.RetainAll(s))
+		})
+	}
+}
+
+func TestKeyedSetOperations(t *testing.T) {
+	t.Parallel()
+
+	path := func(value []string) string { return strings.Join(value, "/") }
+	newSet := func(values ...[]string) *hashset.KeyedHashSet[[]string, string] {
+		return hashset.NewBuilder[[]string, string](path).AddAll(values...).Build()
+	}
+	paths := func(s set.Set[[]string]) []string {
+		result := []string{}
+		s.ForEach(func(_ []string, value []string) {
+			result = append(result, path(value))
+		})
+
+		return result
+	}
+
+	usrBin, usrLib, etc := []string{"usr", "bin"}, []string{"usr", "lib"}, []string{"etc"}
+	left := newSet(usrBin, usrLib)
+	right := newSet(usrLib, etc)
+
+	union := hashset.UnionKeyed[[]string](path, left, right)
+	assert.ElementsMatch(t, []string{"usr/bin", "usr/lib", "etc"}, paths(union))
+	assert.True(t, union.Contains([]string{"etc"}))
+
+	assert.ElementsMatch(t, []string{"usr/lib"}, paths(hashset.IntersectionKeyed[[]string](path, left, right)))
+	assert.ElementsMatch(t, []string{"usr/bin"}, paths(hashset.DifferenceKeyed[[]string](path, left, right)))
+	assert.ElementsMatch(t, []string{"usr/bin", "etc"}, paths(hashset.SymmetricDifferenceKeyed[[]string](path, left, right)))
+
+	assert.Equal(t, 1, left.UnionWith(right))
+	assert.Equal(t, 0, left.UnionWith(left))
+	assert.ElementsMatch(t, []string{"usr/bin", "usr/lib", "etc"}, paths(left))
+
+	assert.Equal(t, 1, left.RetainAll(right))
+	assert.Equal(t, 0, left.RetainAll(left))
+	assert.ElementsMatch(t, []string{"usr/lib", "etc"}, paths(left))
+
+	assert.Equal(t, 1, left.RetainAll(newSet(etc, usrBin, []string{"var"})))
+	assert.ElementsMatch(t, []string{"etc"}, paths(left))
+}
@@ -1,22 +1,19 @@
 package hashset
 
 import (
-	"fmt"
-	"strings"
-
+	"github.com/kaschnit/go-ds/pkg/compare"
 	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
 )
 
-//nolint:gochecknoglobals
-var itemExists = struct{}{}
-
+// HashSet is a hash set of comparable values, which are equal when they are equal with ==.
+// It is a KeyedHashSet that keys each value by itself.
 type HashSet[T comparable] struct {
-	values map[T]struct{}
+	values *KeyedHashSet[T, T]
 }
 
 func New[T comparable](values ...T) *HashSet[T] {
 	set := HashSet[T]{
-		values: make(map[T]struct{}),
+		values: NewBuilder[T, T](compare.IdentityHashKey[T]).Build(),
 	}
 	set.AddAll(values...)
 
@@ -28,116 +25,57 @@ func (s *HashSet[T]) Empty() bool {
 }
 
 func (s *HashSet[T]) Size() int {
-	return len(s.values)
+	return s.values.Size()
 }
 
 func (s *HashSet[T]) Clear() {
-	for k := range s.values {
-		delete(s.values, k)
-	}
+	s.values.Clear()
 }
 
 func (s *HashSet[T]) String() string {
-	sb := strings.Builder{}
-	sb.WriteString("HashSet\n")
-
-	strs := []string{}
-	for k := range s.values {
-		strs = append(strs, fmt.Sprintf("%v", k))
-	}
-
-	sb.WriteString(strings.Join(strs, ","))
-
-	return sb.String()
+	return s.values.format("HashSet")
 }
 
 func (s *HashSet[T]) ForEach(op enumerable.Op[T, T]) {
-	for value := range s.values {
-		op(value, value)
-	}
+	s.values.ForEach(op)
 }
 
 func (s *HashSet[T]) Any(predicate enumerable.Predicate[T, T]) bool {
-	for value := range s.values {
-		if predicate(value, value) {
-			return true
-		}
-	}
-
-	return false
+	return s.values.Any(predicate)
 }
 
 func (s *HashSet[T]) All(predicate enumerable.Predicate[T, T]) bool {
-	for value := range s.values {
-		if !predicate(value, value) {
-			return false
-		}
-	}
-
-	return true
+	return s.values.All(predicate)
 }
 
 func (s *HashSet[T]) Find(predicate enumerable.Predicate[T, T]) (T, T, bool) {
-	for value := range s.values {
-		if predicate(value, value) {
-			return value, value, true
-		}
-	}
-
-	return *new(T), *new(T), false
+	return s.values.Find(predicate)
 }
 
 func (s *HashSet[T]) Add(value T) {
-	s.values[value] = itemExists
+	s.values.Add(value)
 }
 
 func (s *HashSet[T]) AddAll(values ...T) {
-	for _, value := range values {
-		s.Add(value)
-	}
+	s.values.AddAll(values...)
 }
 
 func (s *HashSet[T]) Contains(value T) bool {
-	_, contains := s.values[value]
-
-	return contains
+	return s.values.Contains(value)
 }
 
 func (s *HashSet[T]) Remove(value T) bool {
-	contained := s.Contains(value)
-	delete(s.values, value)
-
-	return contained
+	return s.values.Remove(value)
 }
 
 func (s *HashSet[T]) RemoveAll(values ...T) int {
-	removed := 0
-
-	for _, value := range values {
-		if s.Remove(value) {
-			removed++
-		}
-	}
-
-	return removed
+	return s.values.RemoveAll(values...)
 }
 
 func (s *HashSet[T]) ContainsAll(values ...T) bool {
-	for _, value := range values {
-		if !s.Contains(value) {
-			return false
-		}
-	}
-
-	return true
+	return s.values.ContainsAll(values...)
 }
 
 func (s *HashSet[T]) ContainsAny(values ...T) bool {
-	for _, value := range values {
-		if s.Contains(value) {
-			return true
-		}
-	}
-
-	return false
+	return s.values.ContainsAny(values...)
 }
@@ -0,0 +1,179 @@
+package hashset
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+)
+
+type Builder[T any, H comparable] struct {
+	hashkey compare.HashKey[T, H]
+	values  map[H]T
+}
+
+func NewBuilder[T any, H comparable](hashkey compare.HashKey[T, H]) *Builder[T, H] {
+	return &Builder[T, H]{
+		hashkey: hashkey,
+		values:  make(map[H]T),
+	}
+}
+
+func (b *Builder[T, H]) Add(value T) *Builder[T, H] {
+	b.values[b.hashkey(value)] = value
+
+	return b
+}
+
+func (b *Builder[T, H]) AddAll(values ...T) *Builder[T, H] {
+	for _, value := range values {
+		b.Add(value)
+	}
+
+	return b
+}
+
+func (b *Builder[T, H]) Build() *KeyedHashSet[T, H] {
+	s := &KeyedHashSet[T, H]{
+		hashkey: b.hashkey,
+		values:  b.values,
+	}
+	b.values = make(map[H]T)
+
+	return s
+}
+
+// KeyedHashSet is a hash set of values that are not comparable, or that should be considered
+// equal by something other than ==. Values are stored by the key that the HashKey derives
+// from them, so two values with the same key are the same value of the set.
+type KeyedHashSet[T any, H comparable] struct {
+	hashkey compare.HashKey[T, H]
+	values  map[H]T
+}
+
+func (s *KeyedHashSet[T, H]) Empty() bool {
+	return s.Size() == 0
+}
+
+func (s *KeyedHashSet[T, H]) Size() int {
+	return len(s.values)
+}
+
+func (s *KeyedHashSet[T, H]) Clear() {
+	for k := range s.values {
+		delete(s.values, k)
+	}
+}
+
+func (s *KeyedHashSet[T, H]) String() string {
+	return s.format("KeyedHashSet")
+}
+
+// format formats the set with the name of its type, so that HashSet can share it.
+func (s *KeyedHashSet[T, H]) format(name string) string {
+	sb := strings.Builder{}
+	sb.WriteString(name)
+	sb.WriteString("\n")
+
+	strs := []string{}
+	for _, value := range s.values {
+		strs = append(strs, fmt.Sprintf("%v", value))
+	}
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (s *KeyedHashSet[T, H]) ForEach(op enumerable.Op[T, T]) {
+	for _, value := range s.values {
+		op(value, value)
+	}
+}
+
+func (s *KeyedHashSet[T, H]) Any(predicate enumerable.Predicate[T, T]) bool {
+	for _, value := range s.values {
+		if predicate(value, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *KeyedHashSet[T, H]) All(predicate enumerable.Predicate[T, T]) bool {
+	for _, value := range s.values {
+		if !predicate(value, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *KeyedHashSet[T, H]) Find(predicate enumerable.Predicate[T, T]) (T, T, bool) {
+	for _, value := range s.values {
+		if predicate(value, value) {
+			return value, value, true
+		}
+	}
+
+	return *new(T), *new(T), false
+}
+
+func (s *KeyedHashSet[T, H]) Add(value T) {
+	s.values[s.hashkey(value)] = value
+}
+
+func (s *KeyedHashSet[T, H]) AddAll(values ...T) {
+	for _, value := range values {
+		s.Add(value)
+	}
+}
+
+func (s *KeyedHashSet[T, H]) Contains(value T) bool {
+	_, contains := s.values[s.hashkey(value)]
+
+	return contains
+}
+
+func (s *KeyedHashSet[T, H]) Remove(value T) bool {
+	hashedValue := s.hashkey(value)
+	_, contained := s.values[hashedValue]
+	delete(s.values, hashedValue)
+
+	return contained
+}
+
+func (s *KeyedHashSet[T, H]) RemoveAll(values ...T) int {
+	removed := 0
+
+	for _, value := range values {
+		if s.Remove(value) {
+			removed++
+		}
+	}
+
+	return removed
+}
+
+func (s *KeyedHashSet[T, H]) ContainsAll(values ...T) bool {
+	for _, value := range values {
+		if !s.Contains(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *KeyedHashSet[T, H]) ContainsAny(values ...T) bool {
+	for _, value := range values {
+		if s.Contains(value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,48 @@
+package hashset_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/set"
+	"github.com/kaschnit/go-ds/pkg/containers/set/hashset"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that KeyedHashSet implements Set.
+var _ set.Set[[]int] = hashset.NewBuilder[[]int, int](func(value []int) int { return len(value) }).Build()
+
+func TestKeyedHashSetString(t *testing.T) {
+	t.Parallel()
+
+	s := hashset.NewBuilder[string, string](strings.ToLower).Add("Foo").Build()
+	assert.Equal(t, "KeyedHashSet\nFoo", s.String())
+}
+
+func TestKeyedHashSetBuilderHashKey(t *testing.T) {
+	t.Parallel()
+
+	path := func(value []string) string { return strings.Join(value, "/") }
+	s := hashset.NewBuilder[[]string, string](path).
+		Add([]string{"usr", "bin"}).
+		AddAll([]string{"usr", "lib"}, []string{"usr", "bin"}).
+		Build()
+
+	assert.Equal(t, 2, s.Size())
+	assert.True(t, s.Contains([]string{"usr", "bin"}))
+	assert.False(t, s.Contains([]string{"usr"}))
+
+	s.Add([]string{"etc"})
+	assert.True(t, s.ContainsAll([]string{"etc"}, []string{"usr", "lib"}))
+	assert.True(t, s.Remove([]string{"usr", "lib"}))
+	assert.False(t, s.Remove([]string{"usr", "lib"}))
+
+	paths := []string{}
+	s.ForEach(func(key []string, value []string) {
+		paths = append(paths, path(value))
+	})
+	assert.ElementsMatch(t, []string{"usr/bin", "etc"}, paths)
+
+	s.Clear()
+	assert.True(t, s.Empty())
+}
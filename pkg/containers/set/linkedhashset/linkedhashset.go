@@ -0,0 +1,154 @@
+package linkedhashset
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/map/linkedhashmap"
+	"github.com/kaschnit/go-ds/pkg/iterator"
+)
+
+type linkedHashSetIterator[T any] struct {
+	inner iterator.ForwardIterator[T, struct{}]
+}
+
+func (a *linkedHashSetIterator[T]) Key() (T, bool) {
+	return a.inner.Key()
+}
+
+func (a *linkedHashSetIterator[T]) Value() (T, bool) {
+	return a.inner.Key()
+}
+
+func (a *linkedHashSetIterator[T]) Next() (iterator.ForwardIterator[T, T], bool) {
+	next, ok := a.inner.Next()
+	if !ok {
+		return nil, false
+	}
+
+	return &linkedHashSetIterator[T]{inner: next}, true
+}
+
+func (a *linkedHashSetIterator[T]) HasNext() bool {
+	return a.inner.HasNext()
+}
+
+// LinkedHashSet is a hash set that remembers the order in which its values were first added,
+// so enumeration and iteration are deterministic.
+type LinkedHashSet[T comparable] struct {
+	values *linkedhashmap.LinkedHashMap[T, T, struct{}]
+}
+
+func New[T comparable](values ...T) *LinkedHashSet[T] {
+	set := LinkedHashSet[T]{
+		values: linkedhashmap.New[T, struct{}](),
+	}
+	set.AddAll(values...)
+
+	return &set
+}
+
+func (s *LinkedHashSet[T]) Empty() bool {
+	return s.Size() == 0
+}
+
+func (s *LinkedHashSet[T]) Size() int {
+	return s.values.Size()
+}
+
+func (s *LinkedHashSet[T]) Clear() {
+	s.values.Clear()
+}
+
+func (s *LinkedHashSet[T]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("LinkedHashSet\n")
+
+	strs := make([]string, 0, s.Size())
+	s.values.ForEach(func(value T, _ struct{}) {
+		strs = append(strs, fmt.Sprintf("%v", value))
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+func (s *LinkedHashSet[T]) ForEach(op enumerable.Op[T, T]) {
+	s.values.ForEach(func(value T, _ struct{}) {
+		op(value, value)
+	})
+}
+
+func (s *LinkedHashSet[T]) Any(predicate enumerable.Predicate[T, T]) bool {
+	return s.values.Any(func(value T, _ struct{}) bool {
+		return predicate(value, value)
+	})
+}
+
+func (s *LinkedHashSet[T]) All(predicate enumerable.Predicate[T, T]) bool {
+	return s.values.All(func(value T, _ struct{}) bool {
+		return predicate(value, value)
+	})
+}
+
+func (s *LinkedHashSet[T]) Find(predicate enumerable.Predicate[T, T]) (T, T, bool) {
+	value, _, ok := s.values.Find(func(value T, _ struct{}) bool {
+		return predicate(value, value)
+	})
+
+	return value, value, ok
+}
+
+func (s *LinkedHashSet[T]) Iterator() (iterator.ForwardIterator[T, T], bool) {
+	inner, ok := s.values.Iterator()
+	if !ok {
+		return nil, false
+	}
+
+	return &linkedHashSetIterator[T]{inner: inner}, true
+}
+
+func (s *LinkedHashSet[T]) IteratorReverse() (iterator.ForwardIterator[T, T], bool) {
+	inner, ok := s.values.IteratorReverse()
+	if !ok {
+		return nil, false
+	}
+
+	return &linkedHashSetIterator[T]{inner: inner}, true
+}
+
+// Add adds the value to the end of the set's order. Adding a value that is already
+// in the set does not change its position.
+func (s *LinkedHashSet[T]) Add(value T) {
+	if !s.values.ContainsKey(value) {
+		s.values.Put(value, struct{}{})
+	}
+}
+
+func (s *LinkedHashSet[T]) AddAll(values ...T) {
+	for _, value := range values {
+		s.Add(value)
+	}
+}
+
+func (s *LinkedHashSet[T]) Contains(value T) bool {
+	return s.values.ContainsKey(value)
+}
+
+func (s *LinkedHashSet[T]) Remove(value T) bool {
+	return s.values.RemoveKey(value)
+}
+
+func (s *LinkedHashSet[T]) RemoveAll(values ...T) int {
+	return s.values.RemoveAllKeys(values...)
+}
+
+func (s *LinkedHashSet[T]) ContainsAll(values ...T) bool {
+	return s.values.ContainsAllKeys(values...)
+}
+
+func (s *LinkedHashSet[T]) ContainsAny(values ...T) bool {
+	return s.values.ContainsAnyKey(values...)
+}
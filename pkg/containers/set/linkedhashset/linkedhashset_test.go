@@ -0,0 +1,84 @@
+package linkedhashset_test
+
+import (
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/iterable"
+	"github.com/kaschnit/go-ds/pkg/containers/set"
+	"github.com/kaschnit/go-ds/pkg/containers/set/linkedhashset"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that LinkedHashSet implements Set and is iterable in both directions.
+var (
+	_ set.Set[int]                       = linkedhashset.New(1)
+	_ iterable.ForwardIterable[int, int] = linkedhashset.New(1)
+	_ iterable.ReverseIterable[int, int] = linkedhashset.New(1)
+)
+
+func TestLinkedHashSetString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		set      *linkedhashset.LinkedHashSet[int]
+		expected string
+	}{
+		{
+			name:     "empty set",
+			set:      linkedhashset.New[int](),
+			expected: "LinkedHashSet\n",
+		},
+		{
+			name:     "set with 1 item",
+			set:      linkedhashset.New(987654321),
+			expected: "LinkedHashSet\n987654321",
+		},
+		{
+			name:     "set with a few items",
+			set:      linkedhashset.New(100, 1145, -202, 5, 1145, 6, 7, 100),
+			expected: "LinkedHashSet\n100,1145,-202,5,6,7",
+		},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expected, testCase.set.String())
+		})
+	}
+}
+
+func TestLinkedHashSetIteration(t *testing.T) {
+	t.Parallel()
+
+	s := linkedhashset.New("b", "c", "a")
+	s.Add("b")
+	s.Remove("c")
+	s.Add("c")
+
+	values := []string{}
+
+	for itr, ok := s.Iterator(); ok; itr, ok = itr.Next() {
+		key, _ := itr.Key()
+		value, _ := itr.Value()
+		assert.Equal(t, key, value)
+
+		values = append(values, value)
+	}
+
+	assert.Equal(t, []string{"b", "a", "c"}, values)
+
+	values = []string{}
+
+	for itr, ok := s.IteratorReverse(); ok; itr, ok = itr.Next() {
+		value, _ := itr.Value()
+		values = append(values, value)
+	}
+
+	assert.Equal(t, []string{"c", "a", "b"}, values)
+
+	_, ok := linkedhashset.New[string]().Iterator()
+	assert.False(t, ok)
+}
@@ -7,12 +7,14 @@ import (
 	"github.com/kaschnit/go-ds/pkg/containers/set"
 	"github.com/kaschnit/go-ds/pkg/containers/set/concurrentset"
 	"github.com/kaschnit/go-ds/pkg/containers/set/hashset"
+	"github.com/kaschnit/go-ds/pkg/containers/set/linkedhashset"
 	"github.com/stretchr/testify/assert"
 )
 
 func getSetsForTest[T comparable](values ...T) []set.Set[T] {
 	return []set.Set[T]{
 		hashset.New(values...),
+		linkedhashset.New(values...),
 		concurrentset.MakeThreadSafe[T](hashset.New(values...)),
 	}
 }
@@ -0,0 +1,319 @@
+package sketch
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+)
+
+const (
+	DefaultWidth = 2048
+	DefaultDepth = 4
+)
+
+type CountMinBuilder[T any] struct {
+	hasher       compare.Hasher[T]
+	width        int
+	depth        int
+	conservative bool
+	topK         int
+}
+
+func NewCountMinBuilder[T any](hasher compare.Hasher[T]) *CountMinBuilder[T] {
+	return &CountMinBuilder[T]{
+		hasher:       hasher,
+		width:        DefaultWidth,
+		depth:        DefaultDepth,
+		conservative: true,
+		topK:         0,
+	}
+}
+
+// Width sets the number of counters per row, which is rounded up to a power of two. Estimates
+// are too high by at most e/width of the total count, with the probability given by the depth.
+func (b *CountMinBuilder[T]) Width(width int) *CountMinBuilder[T] {
+	b.width = width
+
+	return b
+}
+
+// Depth sets the number of rows. An estimate is within the bound given by the width with
+// probability 1 - e^-depth.
+func (b *CountMinBuilder[T]) Depth(depth int) *CountMinBuilder[T] {
+	b.depth = depth
+
+	return b
+}
+
+// ConservativeUpdate sets whether adding a value only increments the counters that need it
+// to keep the estimate of the value correct, rather than every counter of the value.
+// This is enabled by default, and makes estimates much more accurate for skewed streams.
+func (b *CountMinBuilder[T]) ConservativeUpdate(conservative bool) *CountMinBuilder[T] {
+	b.conservative = conservative
+
+	return b
+}
+
+// TrackHeavyHitters sets the number of the most frequent values that the sketch remembers
+// so that they can be returned by HeavyHitters. Values are not remembered by default.
+func (b *CountMinBuilder[T]) TrackHeavyHitters(k int) *CountMinBuilder[T] {
+	b.topK = k
+
+	return b
+}
+
+// Build returns an empty sketch. Returns false if the width or depth are not positive,
+// or the number of heavy hitters to track is negative.
+func (b *CountMinBuilder[T]) Build() (*CountMin[T], bool) {
+	if b.width <= 0 || b.depth <= 0 || b.topK < 0 {
+		return nil, false
+	}
+
+	width := 1
+	for width < b.width {
+		width <<= 1
+	}
+
+	s := &CountMin[T]{
+		hasher:       b.hasher,
+		rows:         make([][]uint64, b.depth),
+		mask:         uint64(width - 1),
+		total:        0,
+		conservative: b.conservative,
+		topK:         b.topK,
+		candidates:   make(map[uint64]*candidate[T], b.topK),
+		minCandidate: 0,
+	}
+
+	for i := range s.rows {
+		s.rows[i] = make([]uint64, width)
+	}
+
+	return s, true
+}
+
+// candidate is a value that may be one of the most frequent values.
+type candidate[T any] struct {
+	value    T
+	estimate uint64
+}
+
+// CountMin is a count-min sketch, which estimates how many times each value has been added
+// using a fixed number of counters. Each value is counted in one counter per row, chosen by
+// its hash, and the smallest of those counters is its estimate. Values that share counters
+// make estimates too high, but never too low.
+//
+// With width w and depth d, an estimate is too high by at most e/w of the total count with
+// probability 1 - e^-d.
+type CountMin[T any] struct {
+	hasher       compare.Hasher[T]
+	rows         [][]uint64
+	mask         uint64
+	total        uint64
+	conservative bool
+
+	// topK is the number of candidates to keep, and candidates are keyed by the hash of their
+	// value. minCandidate is at most the smallest estimate of any candidate.
+	topK         int
+	candidates   map[uint64]*candidate[T]
+	minCandidate uint64
+}
+
+func NewCountMin[T comparable](width int, depth int) (*CountMin[T], bool) {
+	return NewCountMinBuilder(compare.DefaultHasher[T]).Width(width).Depth(depth).Build()
+}
+
+// Empty returns whether nothing has been added to the sketch.
+func (s *CountMin[T]) Empty() bool {
+	return s.total == 0
+}
+
+func (s *CountMin[T]) Clear() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] = 0
+		}
+	}
+
+	s.total = 0
+	s.candidates = make(map[uint64]*candidate[T], s.topK)
+	s.minCandidate = 0
+}
+
+func (s *CountMin[T]) String() string {
+	return fmt.Sprintf("CountMin\nwidth=%d, depth=%d, total=%d", len(s.rows[0]), len(s.rows), s.total)
+}
+
+// Total returns the number of times that values have been added.
+func (s *CountMin[T]) Total() uint64 {
+	return s.total
+}
+
+func (s *CountMin[T]) Add(value T) {
+	s.AddCount(value, 1)
+}
+
+func (s *CountMin[T]) AddAll(values ...T) {
+	for _, value := range values {
+		s.Add(value)
+	}
+}
+
+// AddCount records count occurrences of the value.
+func (s *CountMin[T]) AddCount(value T, count uint64) {
+	if count == 0 {
+		return
+	}
+
+	hash := s.hasher(value)
+	s.total += count
+
+	estimate := s.estimate(hash) + count
+	for i := range s.rows {
+		index := s.index(hash, i)
+
+		switch {
+		case !s.conservative:
+			s.rows[i][index] += count
+		case s.rows[i][index] < estimate:
+			// Counters that are already higher than the new estimate are not needed for it.
+			s.rows[i][index] = estimate
+		}
+	}
+
+	if !s.conservative {
+		estimate = s.estimate(hash)
+	}
+
+	s.track(hash, value, estimate)
+}
+
+// Estimate returns the estimated number of times the value has been added.
+// The estimate may be too high, but is never too low.
+func (s *CountMin[T]) Estimate(value T) uint64 {
+	return s.estimate(s.hasher(value))
+}
+
+// Merge adds the counts of the other sketch to this sketch. Both sketches must use the same
+// hasher. Returns false if their widths or depths differ.
+func (s *CountMin[T]) Merge(other *CountMin[T]) bool {
+	if len(s.rows) != len(other.rows) || s.mask != other.mask {
+		return false
+	}
+
+	for i := range s.rows {
+		for j, count := range other.rows[i] {
+			s.rows[i][j] += count
+		}
+	}
+
+	s.total += other.total
+
+	if s.topK > 0 {
+		for hash, c := range other.candidates {
+			if _, ok := s.candidates[hash]; !ok {
+				s.candidates[hash] = &candidate[T]{value: c.value, estimate: 0}
+			}
+		}
+
+		for hash, c := range s.candidates {
+			c.estimate = s.estimate(hash)
+		}
+
+		for len(s.candidates) > s.topK {
+			delete(s.candidates, s.smallestCandidate())
+		}
+
+		s.minCandidate = 0
+	}
+
+	return true
+}
+
+// HeavyHitters returns the tracked values whose estimated count is at least the fraction of
+// the total count, from most to least frequent. Only the values kept because of
+// TrackHeavyHitters are considered, so a fraction below 1/k may miss some values.
+func (s *CountMin[T]) HeavyHitters(fraction float64) []entry.Entry[T, uint64] {
+	threshold := fraction * float64(s.total)
+
+	hitters := make([]entry.Entry[T, uint64], 0, len(s.candidates))
+	for hash, c := range s.candidates {
+		if estimate := s.estimate(hash); float64(estimate) >= threshold {
+			hitters = append(hitters, entry.New(c.value, estimate))
+		}
+	}
+
+	sort.SliceStable(hitters, func(i, j int) bool {
+		return hitters[i].Value() > hitters[j].Value()
+	})
+
+	return hitters
+}
+
+func (s *CountMin[T]) estimate(hash uint64) uint64 {
+	estimate := s.rows[0][s.index(hash, 0)]
+
+	for i := 1; i < len(s.rows); i++ {
+		if count := s.rows[i][s.index(hash, i)]; count < estimate {
+			estimate = count
+		}
+	}
+
+	return estimate
+}
+
+// track updates the candidates after the value was added, replacing the least frequent
+// candidate if the value is now more frequent than it.
+func (s *CountMin[T]) track(hash uint64, value T, estimate uint64) {
+	if s.topK == 0 {
+		return
+	}
+
+	if c, ok := s.candidates[hash]; ok {
+		c.estimate = estimate
+
+		return
+	}
+
+	if len(s.candidates) == s.topK {
+		// Estimates only grow, so the smallest one is only searched for when it might be
+		// smaller than the new estimate.
+		if estimate <= s.minCandidate {
+			return
+		}
+
+		smallest := s.smallestCandidate()
+		if s.minCandidate = s.candidates[smallest].estimate; estimate <= s.minCandidate {
+			return
+		}
+
+		delete(s.candidates, smallest)
+	}
+
+	s.candidates[hash] = &candidate[T]{value: value, estimate: estimate}
+}
+
+// smallestCandidate returns the hash of the candidate with the smallest estimate.
+func (s *CountMin[T]) smallestCandidate() uint64 {
+	smallest, found := uint64(0), false
+
+	for hash, c := range s.candidates {
+		if !found || c.estimate < s.candidates[smallest].estimate {
+			smallest, found = hash, true
+		}
+	}
+
+	return smallest
+}
+
+// index derives the position of a hash in a row using double hashing.
+//
+//nolint:gomnd
+func (s *CountMin[T]) index(hash uint64, row int) uint64 {
+	h1 := hash
+	h2 := (hash >> 32) | 1
+
+	return (h1 + uint64(row)*h2) & s.mask
+}
@@ -0,0 +1,227 @@
+package sketch_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/sketch"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountMinBuild(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		builder *sketch.CountMinBuilder[int]
+		ok      bool
+	}{
+		{name: "defaults", builder: sketch.NewCountMinBuilder(compare.DefaultHasher[int]), ok: true},
+		{name: "zero width", builder: sketch.NewCountMinBuilder(compare.DefaultHasher[int]).Width(0), ok: false},
+		{name: "zero depth", builder: sketch.NewCountMinBuilder(compare.DefaultHasher[int]).Depth(0), ok: false},
+		{
+			name:    "negative heavy hitters",
+			builder: sketch.NewCountMinBuilder(compare.DefaultHasher[int]).TrackHeavyHitters(-1),
+			ok:      false,
+		},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, ok := testCase.builder.Build()
+			assert.Equal(t, testCase.ok, ok)
+			assert.Equal(t, testCase.ok, s != nil)
+		})
+	}
+}
+
+// zipfStream returns counts of the values 0..n-1 that fall off like a Zipf distribution,
+// along with the total.
+func zipfStream(n int, top int) ([]uint64, uint64) {
+	counts := make([]uint64, n)
+	total := uint64(0)
+
+	for i := range counts {
+		counts[i] = uint64(math.Max(1, float64(top)/float64(i+1)))
+		total += counts[i]
+	}
+
+	return counts, total
+}
+
+func TestCountMinEstimate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		conservative bool
+	}{
+		{name: "conservative update", conservative: true},
+		{name: "standard update", conservative: false},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, ok := sketch.NewCountMinBuilder(compare.DefaultHasher[int]).
+				Width(1024).
+				Depth(5).
+				ConservativeUpdate(testCase.conservative).
+				Build()
+			assert.True(t, ok)
+			assert.True(t, s.Empty())
+
+			counts, total := zipfStream(5000, 10000)
+			for value, count := range counts {
+				for j := uint64(0); j < count; j++ {
+					s.Add(value)
+				}
+			}
+
+			assert.False(t, s.Empty())
+			assert.Equal(t, total, s.Total())
+
+			bound := math.E / 1024 * float64(total)
+			for value, count := range counts {
+				estimate := s.Estimate(value)
+				assert.GreaterOrEqual(t, estimate, count)
+				assert.LessOrEqual(t, float64(estimate-count), bound)
+			}
+		})
+	}
+}
+
+func TestCountMinConservativeUpdate(t *testing.T) {
+	t.Parallel()
+
+	build := func(conservative bool) *sketch.CountMin[int] {
+		s, _ := sketch.NewCountMinBuilder(compare.DefaultHasher[int]).
+			Width(64).
+			Depth(4).
+			ConservativeUpdate(conservative).
+			Build()
+
+		return s
+	}
+
+	conservative, standard := build(true), build(false)
+	counts, _ := zipfStream(2000, 1000)
+	for value, count := range counts {
+		conservative.AddCount(value, count)
+		standard.AddCount(value, count)
+	}
+
+	conservativeError, standardError := uint64(0), uint64(0)
+	for value, count := range counts {
+		conservativeError += conservative.Estimate(value) - count
+		standardError += standard.Estimate(value) - count
+	}
+
+	assert.Less(t, conservativeError, standardError)
+}
+
+func TestCountMinMerge(t *testing.T) {
+	t.Parallel()
+
+	a, _ := sketch.NewCountMin[string](256, 4)
+	b, _ := sketch.NewCountMin[string](256, 4)
+	a.AddCount("a", 5)
+	a.Add("b")
+	b.AddCount("b", 3)
+	b.AddCount("c", 0)
+
+	assert.True(t, a.Merge(b))
+	assert.Equal(t, uint64(9), a.Total())
+	assert.Equal(t, uint64(5), a.Estimate("a"))
+	assert.Equal(t, uint64(4), a.Estimate("b"))
+	assert.Equal(t, uint64(0), a.Estimate("c"))
+	assert.Equal(t, uint64(3), b.Estimate("b"))
+
+	wider, _ := sketch.NewCountMin[string](512, 4)
+	assert.False(t, a.Merge(wider))
+
+	deeper, _ := sketch.NewCountMin[string](256, 5)
+	assert.False(t, a.Merge(deeper))
+
+	a.Clear()
+	assert.True(t, a.Empty())
+	assert.Equal(t, uint64(0), a.Estimate("a"))
+}
+
+func TestCountMinHeavyHitters(t *testing.T) {
+	t.Parallel()
+
+	s, _ := sketch.NewCountMinBuilder(compare.StringHasher).TrackHeavyHitters(5).Build()
+
+	// Interleave the frequent keys with many rare ones so they have to displace rare candidates.
+	for i := 0; i < 10000; i++ {
+		s.Add(fmt.Sprintf("rare-%d", i))
+
+		switch {
+		case i%5 == 0:
+			s.Add("a")
+		case i%7 == 0:
+			s.Add("b")
+		case i%11 == 0:
+			s.Add("c")
+		}
+	}
+
+	hitters := s.HeavyHitters(0.05)
+	keys := make([]string, 0, len(hitters))
+	for _, hitter := range hitters {
+		keys = append(keys, hitter.Key())
+	}
+
+	assert.Equal(t, []string{"a", "b"}, keys)
+	assert.Equal(t, s.Estimate("a"), hitters[0].Value())
+	assert.GreaterOrEqual(t, hitters[0].Value(), uint64(2000))
+
+	assert.Len(t, s.HeavyHitters(0), 5)
+	assert.Equal(t, "c", s.HeavyHitters(0.01)[2].Key())
+}
+
+func TestCountMinHeavyHittersMerge(t *testing.T) {
+	t.Parallel()
+
+	build := func() *sketch.CountMin[string] {
+		s, _ := sketch.NewCountMinBuilder(compare.StringHasher).TrackHeavyHitters(2).Build()
+
+		return s
+	}
+
+	a, b := build(), build()
+	a.AddCount("a", 10)
+	a.AddCount("b", 6)
+	b.AddCount("c", 8)
+	b.AddCount("b", 5)
+
+	assert.True(t, a.Merge(b))
+	assert.Equal(t, []entry.Entry[string, uint64]{
+		entry.New("b", uint64(11)),
+		entry.New("a", uint64(10)),
+	}, a.HeavyHitters(0))
+
+	untracked, _ := sketch.NewCountMin[string](sketch.DefaultWidth, sketch.DefaultDepth)
+	untracked.AddCount("a", 10)
+	assert.Empty(t, untracked.HeavyHitters(0))
+}
+
+func TestCountMinString(t *testing.T) {
+	t.Parallel()
+
+	s, _ := sketch.NewCountMin[int](100, 3)
+	s.AddAll(1, 2, 2)
+
+	resultLines := strings.Split(s.String(), "\n")
+	assert.Len(t, resultLines, 2)
+	assert.Equal(t, "CountMin", resultLines[0])
+	assert.Equal(t, "width=128, depth=3, total=3", resultLines[1])
+}
@@ -0,0 +1,192 @@
+package sketch
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+)
+
+const (
+	// MinPrecision and MaxPrecision bound the number of bits of each hash that choose a register.
+	MinPrecision = 4
+	MaxPrecision = 18
+
+	// DefaultPrecision gives 16384 registers, for a standard error of about 0.8%.
+	DefaultPrecision = 14
+)
+
+type HyperLogLogBuilder[T any] struct {
+	hasher    compare.Hasher[T]
+	precision int
+}
+
+func NewHyperLogLogBuilder[T any](hasher compare.Hasher[T]) *HyperLogLogBuilder[T] {
+	return &HyperLogLogBuilder[T]{
+		hasher:    hasher,
+		precision: DefaultPrecision,
+	}
+}
+
+// Precision sets the number of bits of each hash used to choose a register. The sketch has
+// 2^precision registers of one byte each, and its standard error is 1.04/sqrt(2^precision).
+func (b *HyperLogLogBuilder[T]) Precision(precision int) *HyperLogLogBuilder[T] {
+	b.precision = precision
+
+	return b
+}
+
+// Build returns an empty sketch. Returns false if the precision is outside
+// of [MinPrecision, MaxPrecision].
+func (b *HyperLogLogBuilder[T]) Build() (*HyperLogLog[T], bool) {
+	if b.precision < MinPrecision || b.precision > MaxPrecision {
+		return nil, false
+	}
+
+	return &HyperLogLog[T]{
+		hasher:    b.hasher,
+		precision: uint(b.precision),
+		registers: make([]uint8, 1<<b.precision),
+	}, true
+}
+
+// HyperLogLog estimates the number of distinct values added to it using a fixed amount of
+// memory, no matter how many values there are. Each value's hash picks a register, which
+// remembers the longest run of leading zeros seen in the rest of the hashes that picked it.
+// Long runs are rare, so they indicate that many distinct values were seen.
+//
+// The estimate has a standard error of 1.04/sqrt(2^precision), so about 0.8% with the
+// default precision. Adding a value more than once does not change the estimate.
+type HyperLogLog[T any] struct {
+	hasher    compare.Hasher[T]
+	precision uint
+	registers []uint8
+}
+
+func NewHyperLogLog[T comparable](precision int) (*HyperLogLog[T], bool) {
+	return NewHyperLogLogBuilder(compare.DefaultHasher[T]).Precision(precision).Build()
+}
+
+// Empty returns whether nothing has been added to the sketch.
+func (h *HyperLogLog[T]) Empty() bool {
+	for _, register := range h.registers {
+		if register != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (h *HyperLogLog[T]) Clear() {
+	for i := range h.registers {
+		h.registers[i] = 0
+	}
+}
+
+func (h *HyperLogLog[T]) String() string {
+	return fmt.Sprintf("HyperLogLog\nprecision=%d, estimate=%d", h.precision, h.Estimate())
+}
+
+// Precision returns the number of bits of each hash used to choose a register.
+func (h *HyperLogLog[T]) Precision() int {
+	return int(h.precision)
+}
+
+func (h *HyperLogLog[T]) Add(value T) {
+	// The register comes from the top bits of the hash, which some hashers such as FNV-1a barely
+	// change between similar values, so the hash is mixed first.
+	hash := compare.Mix64(h.hasher(value))
+	index := hash >> (64 - h.precision) //nolint:gomnd
+
+	// The bit below the remaining bits caps the rank when they are all zero.
+	rank := uint8(bits.LeadingZeros64(hash<<h.precision|1<<(h.precision-1)) + 1)
+	if rank > h.registers[index] {
+		h.registers[index] = rank
+	}
+}
+
+func (h *HyperLogLog[T]) AddAll(values ...T) {
+	for _, value := range values {
+		h.Add(value)
+	}
+}
+
+// Estimate returns the estimated number of distinct values that have been added.
+// It uses the improved estimator from Ertl, "New cardinality estimation algorithms for
+// HyperLogLog sketches" (2017), which is unbiased for any number of values. The usual estimator
+// switches to linear counting for small numbers of values, and is biased near the switch.
+func (h *HyperLogLog[T]) Estimate() int {
+	m := float64(len(h.registers))
+	maxRank := 64 - int(h.precision) + 1 //nolint:gomnd
+
+	// counts holds the number of registers with each rank.
+	counts := make([]int, maxRank+1)
+	for _, register := range h.registers {
+		counts[register]++
+	}
+
+	z := m * tau(1-float64(counts[maxRank])/m)
+	for rank := maxRank - 1; rank >= 1; rank-- {
+		z = (z + float64(counts[rank])) / 2 //nolint:gomnd
+	}
+
+	z += m * sigma(float64(counts[0])/m)
+
+	return int(math.Round(m * m / (2 * math.Ln2 * z))) //nolint:gomnd
+}
+
+// Merge updates the sketch to estimate the number of distinct values added to either sketch.
+// Both sketches must use the same hasher. Returns false if their precisions differ.
+func (h *HyperLogLog[T]) Merge(other *HyperLogLog[T]) bool {
+	if h.precision != other.precision {
+		return false
+	}
+
+	for i, register := range other.registers {
+		if register > h.registers[i] {
+			h.registers[i] = register
+		}
+	}
+
+	return true
+}
+
+// sigma is the sum of x^(2^k) * 2^(k-1) for k from 0, and accounts for the empty registers.
+func sigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+
+	y, z := 1.0, x
+	for {
+		x *= x
+		previous := z
+		z += x * y
+		y += y
+
+		if z == previous {
+			return z
+		}
+	}
+}
+
+// tau accounts for the registers whose rank was capped because all of their bits were zero.
+func tau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+
+	y, z := 1.0, 1-x
+	for {
+		x = math.Sqrt(x)
+		previous := z
+		y /= 2
+		z -= (1 - x) * (1 - x) * y
+
+		if z == previous {
+			return z / 3 //nolint:gomnd
+		}
+	}
+}
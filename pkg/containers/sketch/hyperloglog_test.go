@@ -0,0 +1,158 @@
+package sketch_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/compare"
+	"github.com/kaschnit/go-ds/pkg/containers/sketch"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHyperLogLogBuild(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		precision int
+		ok        bool
+	}{
+		{name: "minimum precision", precision: sketch.MinPrecision, ok: true},
+		{name: "default precision", precision: sketch.DefaultPrecision, ok: true},
+		{name: "maximum precision", precision: sketch.MaxPrecision, ok: true},
+		{name: "precision too low", precision: sketch.MinPrecision - 1, ok: false},
+		{name: "precision too high", precision: sketch.MaxPrecision + 1, ok: false},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			h, ok := sketch.NewHyperLogLog[int](testCase.precision)
+			assert.Equal(t, testCase.ok, ok)
+
+			if ok {
+				assert.Equal(t, testCase.precision, h.Precision())
+				assert.True(t, h.Empty())
+				assert.Equal(t, 0, h.Estimate())
+			}
+		})
+	}
+}
+
+func TestHyperLogLogEstimate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		precision int
+		count     int
+	}{
+		{name: "few values", precision: 14, count: 100},
+		{name: "small range", precision: 14, count: 10000},
+		{name: "large range", precision: 14, count: 200000},
+		{name: "low precision", precision: 8, count: 50000},
+		{name: "high precision", precision: 16, count: 100000},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			h, _ := sketch.NewHyperLogLog[int](testCase.precision)
+			for i := 0; i < testCase.count; i++ {
+				h.Add(i)
+				h.Add(i)
+			}
+
+			// Allow four standard errors.
+			standardError := 1.04 / math.Sqrt(float64(int(1)<<testCase.precision))
+			assert.InEpsilon(t, testCase.count, h.Estimate(), 4*standardError)
+		})
+	}
+}
+
+func TestHyperLogLogEstimateBias(t *testing.T) {
+	t.Parallel()
+
+	// Sweep the range in which estimators that switch from linear counting to the raw estimate
+	// are most biased, at 2.5 times the number of registers.
+	const (
+		precision = 14
+		runs      = 20
+	)
+
+	registers := 1 << precision
+	for _, multiple := range []float64{1, 1.5, 2, 2.25, 2.5, 2.75, 3, 4, 5} {
+		count := int(multiple * float64(registers))
+
+		sumError, sumSquaredError := 0.0, 0.0
+		for run := 0; run < runs; run++ {
+			h, _ := sketch.NewHyperLogLog[int](precision)
+			for i := 0; i < count; i++ {
+				h.Add(run<<32 | i)
+			}
+
+			relativeError := float64(h.Estimate()-count) / float64(count)
+			sumError += relativeError
+			sumSquaredError += relativeError * relativeError
+		}
+
+		// The standard error is about 0.8%, so the mean of the runs is well within 0.5%.
+		bias := sumError / runs
+		rootMeanSquaredError := math.Sqrt(sumSquaredError / runs)
+		assert.Less(t, math.Abs(bias), 0.005, "bias at %.2fm", multiple)
+		assert.Less(t, rootMeanSquaredError, 0.012, "error at %.2fm", multiple)
+	}
+}
+
+func TestHyperLogLogHasher(t *testing.T) {
+	t.Parallel()
+
+	h, ok := sketch.NewHyperLogLogBuilder(compare.StringHasher).Precision(12).Build()
+	assert.True(t, ok)
+
+	for i := 0; i < 5000; i++ {
+		h.Add(fmt.Sprintf("user-%d", i%1000))
+	}
+
+	assert.InEpsilon(t, 1000, h.Estimate(), 0.05)
+
+	h.Clear()
+	assert.True(t, h.Empty())
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	t.Parallel()
+
+	a, _ := sketch.NewHyperLogLog[int](14)
+	b, _ := sketch.NewHyperLogLog[int](14)
+
+	for i := 0; i < 30000; i++ {
+		a.Add(i)
+	}
+	for i := 20000; i < 50000; i++ {
+		b.Add(i)
+	}
+
+	assert.True(t, a.Merge(b))
+	assert.InEpsilon(t, 50000, a.Estimate(), 0.04)
+	assert.InEpsilon(t, 30000, b.Estimate(), 0.04)
+
+	other, _ := sketch.NewHyperLogLog[int](12)
+	assert.False(t, a.Merge(other))
+}
+
+func TestHyperLogLogString(t *testing.T) {
+	t.Parallel()
+
+	h, _ := sketch.NewHyperLogLog[int](10)
+	h.AddAll(1, 2, 3)
+
+	resultLines := strings.Split(h.String(), "\n")
+	assert.Len(t, resultLines, 2)
+	assert.Equal(t, "HyperLogLog", resultLines[0])
+	assert.Equal(t, "precision=10, estimate=3", resultLines[1])
+}
@@ -0,0 +1,432 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ErrInvalidEncoding is returned when unmarshaling data that was not produced by marshaling
+// the same kind of sketch.
+var ErrInvalidEncoding = errors.New("sketch: invalid encoding")
+
+const (
+	// MinK is the smallest accuracy parameter of a KLL sketch.
+	MinK = 8
+
+	// DefaultK keeps a few thousand values, for a rank error of about 1.7%.
+	DefaultK = 200
+
+	kllFormat byte = 1
+
+	// kllHeaderSize is the size of the format, k, the count, the minimum, the maximum
+	// and the number of levels.
+	kllHeaderSize = 1 + 4 + 8 + 8 + 8 + 4
+
+	// maxLevels bounds the number of levels, since the values of each level weigh twice as
+	// much as those of the level below it.
+	maxLevels = 64
+
+	// capacityRatio is how much smaller each level is than the level above it.
+	capacityRatio = 2.0 / 3.0
+
+	initialCoin uint64 = 0x9e3779b97f4a7c15
+)
+
+type KLLBuilder struct {
+	k int
+}
+
+func NewKLLBuilder() *KLLBuilder {
+	return &KLLBuilder{
+		k: DefaultK,
+	}
+}
+
+// K sets the accuracy parameter of the sketch. The sketch keeps about 3k values, and its rank
+// error is roughly proportional to 1/k.
+func (b *KLLBuilder) K(k int) *KLLBuilder {
+	b.k = k
+
+	return b
+}
+
+// Build returns an empty sketch. Returns false if k is less than MinK.
+func (b *KLLBuilder) Build() (*KLL, bool) {
+	if b.k < MinK || b.k > math.MaxUint32 {
+		return nil, false
+	}
+
+	return newKLL(b.k), true
+}
+
+// KLL is a quantile sketch, which estimates the quantiles and ranks of a stream of values
+// while keeping only a small, bounded sample of them. Values are kept in levels, where each
+// value of a level stands for twice as many values as one of the level below. When a level
+// fills up it is sorted and every other value is promoted to the next level, starting from the
+// first or second value at random.
+//
+// The error is in rank rather than in value: with the default k of 200, the true rank of the
+// value returned for q is within about 1.7% of q, and CDF is within about 1.7% of the true
+// fraction, with 99% probability. The error shrinks in proportion to 1/k. The minimum and
+// maximum are always exact.
+type KLL struct {
+	k      int
+	levels [][]float64
+
+	// size is the number of values kept in all levels, and count is the number of values
+	// they stand for.
+	size  int
+	count uint64
+
+	min float64
+	max float64
+
+	// coin is the state of the generator that chooses which values are promoted.
+	coin uint64
+}
+
+func NewKLL(k int) (*KLL, bool) {
+	return NewKLLBuilder().K(k).Build()
+}
+
+func newKLL(k int) *KLL {
+	return &KLL{
+		k:      k,
+		levels: make([][]float64, 1),
+		size:   0,
+		count:  0,
+		min:    math.NaN(),
+		max:    math.NaN(),
+		coin:   initialCoin,
+	}
+}
+
+// Empty returns whether nothing has been added to the sketch.
+func (s *KLL) Empty() bool {
+	return s.count == 0
+}
+
+func (s *KLL) Clear() {
+	*s = *newKLL(s.k)
+}
+
+func (s *KLL) String() string {
+	return fmt.Sprintf("KLL\nk=%d, count=%d, retained=%d", s.k, s.count, s.size)
+}
+
+// K returns the accuracy parameter of the sketch.
+func (s *KLL) K() int {
+	return s.k
+}
+
+// Count returns the number of values that have been added.
+func (s *KLL) Count() uint64 {
+	return s.count
+}
+
+// Min returns the smallest value that has been added. Returns false if the sketch is empty.
+func (s *KLL) Min() (float64, bool) {
+	return s.min, !s.Empty()
+}
+
+// Max returns the largest value that has been added. Returns false if the sketch is empty.
+func (s *KLL) Max() (float64, bool) {
+	return s.max, !s.Empty()
+}
+
+// Add adds the value to the sketch. NaN is ignored, since it has no rank.
+func (s *KLL) Add(value float64) {
+	if math.IsNaN(value) {
+		return
+	}
+
+	if s.Empty() || value < s.min {
+		s.min = value
+	}
+
+	if s.Empty() || value > s.max {
+		s.max = value
+	}
+
+	s.levels[0] = append(s.levels[0], value)
+	s.size++
+	s.count++
+
+	if s.size >= s.maxSize() {
+		s.compress()
+	}
+}
+
+func (s *KLL) AddAll(values ...float64) {
+	for _, value := range values {
+		s.Add(value)
+	}
+}
+
+// Quantile returns the estimated value that a fraction q of the added values are at most.
+// Quantile(0) is the minimum and Quantile(1) is the maximum. Returns false if the sketch is
+// empty or q is not in [0, 1].
+func (s *KLL) Quantile(q float64) (float64, bool) {
+	if s.Empty() || !(q >= 0 && q <= 1) {
+		return 0, false
+	}
+
+	switch q {
+	case 0:
+		return s.min, true
+	case 1:
+		return s.max, true
+	}
+
+	target := q * float64(s.count)
+	cumulative := uint64(0)
+
+	for _, item := range s.sorted() {
+		cumulative += item.weight
+		if float64(cumulative) >= target {
+			return item.value, true
+		}
+	}
+
+	return s.max, true
+}
+
+// CDF returns the estimated fraction of the added values that are at most x.
+// Returns false if the sketch is empty.
+func (s *KLL) CDF(x float64) (float64, bool) {
+	if s.Empty() || math.IsNaN(x) {
+		return 0, false
+	}
+
+	switch {
+	case x < s.min:
+		return 0, true
+	case x >= s.max:
+		return 1, true
+	}
+
+	rank := uint64(0)
+
+	for level, values := range s.levels {
+		for _, value := range values {
+			if value <= x {
+				rank += 1 << level
+			}
+		}
+	}
+
+	return float64(rank) / float64(s.count), true
+}
+
+// Merge adds the values summarized by the other sketch to this sketch, as if they had been
+// added to it directly. Returns false if the sketches have different values of k.
+func (s *KLL) Merge(other *KLL) bool {
+	if s.k != other.k {
+		return false
+	}
+
+	if other.Empty() {
+		return true
+	}
+
+	if s.Empty() || other.min < s.min {
+		s.min = other.min
+	}
+
+	if s.Empty() || other.max > s.max {
+		s.max = other.max
+	}
+
+	// Copy the other levels first, in case the other sketch is this sketch.
+	levels := make([][]float64, len(other.levels))
+	for level, values := range other.levels {
+		levels[level] = append([]float64(nil), values...)
+	}
+
+	for len(s.levels) < len(levels) {
+		s.levels = append(s.levels, nil)
+	}
+
+	for level, values := range levels {
+		s.levels[level] = append(s.levels[level], values...)
+	}
+
+	s.size += other.size
+	s.count += other.count
+
+	for s.size >= s.maxSize() {
+		s.compress()
+	}
+
+	return true
+}
+
+// MarshalBinary encodes the sketch, which can be restored with UnmarshalBinary,
+// including in another process.
+func (s *KLL) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, kllHeaderSize+4*len(s.levels)+8*s.size) //nolint:gomnd
+	data = append(data, kllFormat)
+	data = binary.LittleEndian.AppendUint32(data, uint32(s.k))
+	data = binary.LittleEndian.AppendUint64(data, s.count)
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(s.min))
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(s.max))
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(s.levels)))
+
+	for _, values := range s.levels {
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(values)))
+		for _, value := range values {
+			data = binary.LittleEndian.AppendUint64(data, math.Float64bits(value))
+		}
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary replaces the sketch with the encoded sketch, including its value of k.
+//
+//nolint:gomnd
+func (s *KLL) UnmarshalBinary(data []byte) error {
+	if len(data) < kllHeaderSize || data[0] != kllFormat {
+		return ErrInvalidEncoding
+	}
+
+	result := newKLL(int(binary.LittleEndian.Uint32(data[1:])))
+	result.count = binary.LittleEndian.Uint64(data[5:])
+	result.min = math.Float64frombits(binary.LittleEndian.Uint64(data[13:]))
+	result.max = math.Float64frombits(binary.LittleEndian.Uint64(data[21:]))
+	numLevels := int(binary.LittleEndian.Uint32(data[29:]))
+	data = data[kllHeaderSize:]
+
+	if result.k < MinK || numLevels < 1 || numLevels > maxLevels {
+		return ErrInvalidEncoding
+	}
+
+	result.levels = make([][]float64, numLevels)
+	weight := uint64(0)
+
+	for level := range result.levels {
+		if len(data) < 4 {
+			return ErrInvalidEncoding
+		}
+
+		length := int(binary.LittleEndian.Uint32(data))
+		data = data[4:]
+
+		if len(data)/8 < length {
+			return ErrInvalidEncoding
+		}
+
+		values := make([]float64, length)
+		for i := range values {
+			values[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+		}
+
+		data = data[8*length:]
+		result.levels[level] = values
+		result.size += length
+		weight += uint64(length) << level
+	}
+
+	// Promoting values keeps their total weight, so it always matches the count.
+	if len(data) != 0 || weight != result.count {
+		return ErrInvalidEncoding
+	}
+
+	*s = *result
+
+	return nil
+}
+
+// capacity returns how many values the level can hold before it is compacted. The top level
+// holds k values, and lower levels hold geometrically fewer.
+func (s *KLL) capacity(level int) int {
+	depth := len(s.levels) - level - 1
+	capacity := int(math.Ceil(float64(s.k) * math.Pow(capacityRatio, float64(depth))))
+
+	if capacity < 2 { //nolint:gomnd
+		return 2 //nolint:gomnd
+	}
+
+	return capacity
+}
+
+func (s *KLL) maxSize() int {
+	size := 0
+	for level := range s.levels {
+		size += s.capacity(level)
+	}
+
+	return size
+}
+
+// compress compacts the lowest full levels until the sketch is under its maximum size.
+func (s *KLL) compress() {
+	for level := 0; level < len(s.levels); level++ {
+		if len(s.levels[level]) < s.capacity(level) {
+			continue
+		}
+
+		if level+1 == len(s.levels) {
+			s.levels = append(s.levels, nil)
+		}
+
+		s.compact(level)
+
+		if s.size < s.maxSize() {
+			return
+		}
+	}
+}
+
+// compact sorts the level and promotes every other value to the next level. When the level
+// has an odd number of values, its smallest value stays behind.
+func (s *KLL) compact(level int) {
+	values := s.levels[level]
+	sort.Float64s(values)
+
+	start := len(values) % 2 //nolint:gomnd
+	pairs := (len(values) - start) / 2
+
+	for i := start + int(s.flip()); i < len(values); i += 2 {
+		s.levels[level+1] = append(s.levels[level+1], values[i])
+	}
+
+	s.levels[level] = values[:start]
+	s.size -= pairs
+}
+
+// flip returns 0 or 1 at random, using xorshift so that a sketch is reproducible.
+//
+//nolint:gomnd
+func (s *KLL) flip() uint64 {
+	s.coin ^= s.coin << 13
+	s.coin ^= s.coin >> 7
+	s.coin ^= s.coin << 17
+
+	return s.coin >> 63
+}
+
+type weightedValue struct {
+	value  float64
+	weight uint64
+}
+
+// sorted returns every kept value with the number of values it stands for, in order.
+func (s *KLL) sorted() []weightedValue {
+	items := make([]weightedValue, 0, s.size)
+
+	for level, values := range s.levels {
+		for _, value := range values {
+			items = append(items, weightedValue{value: value, weight: 1 << level})
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].value < items[j].value
+	})
+
+	return items
+}
@@ -0,0 +1,339 @@
+package sketch_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/sketch"
+	"github.com/kaschnit/go-ds/pkg/containers/slice"
+	"github.com/stretchr/testify/assert"
+)
+
+// rankErrorBound is the documented rank error for the default k, with some slack.
+const rankErrorBound = 0.02
+
+var quantiles = []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999}
+
+func generate(n int, seed int64, sample func(r *rand.Rand) float64) slice.Slice[float64] {
+	r := rand.New(rand.NewSource(seed)) //nolint:gosec
+
+	values := make(slice.Slice[float64], n)
+	for i := range values {
+		values[i] = sample(r)
+	}
+
+	return values
+}
+
+func sortedCopy(values slice.Slice[float64]) slice.Slice[float64] {
+	sorted := append(slice.Slice[float64](nil), values...)
+	sort.Float64s(sorted)
+
+	return sorted
+}
+
+// rankError returns how far q is from the range of fractions of the sorted values that the
+// value could have as its rank.
+func rankError(sorted slice.Slice[float64], value float64, q float64) float64 {
+	n := float64(len(sorted))
+	below := float64(sort.SearchFloat64s(sorted, value)) / n
+	atMost := float64(sort.Search(len(sorted), func(i int) bool { return sorted[i] > value })) / n
+
+	switch {
+	case q < below:
+		return below - q
+	case q > atMost:
+		return q - atMost
+	default:
+		return 0
+	}
+}
+
+func TestKLLBuild(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		k    int
+		ok   bool
+	}{
+		{name: "minimum k", k: sketch.MinK, ok: true},
+		{name: "default k", k: sketch.DefaultK, ok: true},
+		{name: "k too small", k: sketch.MinK - 1, ok: false},
+		{name: "negative k", k: -1, ok: false},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, ok := sketch.NewKLL(testCase.k)
+			assert.Equal(t, testCase.ok, ok)
+
+			if ok {
+				assert.Equal(t, testCase.k, s.K())
+				assert.True(t, s.Empty())
+
+				_, ok = s.Quantile(0.5)
+				assert.False(t, ok)
+
+				_, ok = s.CDF(0)
+				assert.False(t, ok)
+
+				_, ok = s.Min()
+				assert.False(t, ok)
+			}
+		})
+	}
+}
+
+func TestKLLQuantile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		values slice.Slice[float64]
+	}{
+		{
+			name:   "uniform",
+			values: generate(100000, 1, func(r *rand.Rand) float64 { return r.Float64() }),
+		},
+		{
+			name:   "normal",
+			values: generate(100000, 2, func(r *rand.Rand) float64 { return r.NormFloat64()*10 + 50 }),
+		},
+		{
+			name: "latencies",
+			values: generate(200000, 3, func(r *rand.Rand) float64 {
+				return math.Exp(r.NormFloat64()) * 20
+			}),
+		},
+		{
+			name:   "few distinct values",
+			values: generate(50000, 4, func(r *rand.Rand) float64 { return float64(r.Intn(10)) }),
+		},
+		{
+			name:   "ascending",
+			values: sortedCopy(generate(100000, 5, func(r *rand.Rand) float64 { return r.Float64() })),
+		},
+		{
+			name:   "fewer values than k",
+			values: generate(150, 6, func(r *rand.Rand) float64 { return r.Float64() }),
+		},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := sketch.NewKLL(sketch.DefaultK)
+			testCase.values.ForEach(func(_ int, value float64) {
+				s.Add(value)
+			})
+
+			sorted := sortedCopy(testCase.values)
+			assert.Equal(t, uint64(len(sorted)), s.Count())
+
+			minimum, _ := s.Quantile(0)
+			maximum, _ := s.Quantile(1)
+			assert.Equal(t, sorted[0], minimum)
+			assert.Equal(t, sorted[len(sorted)-1], maximum)
+
+			for _, q := range quantiles {
+				value, ok := s.Quantile(q)
+				assert.True(t, ok)
+				assert.LessOrEqual(t, rankError(sorted, value, q), rankErrorBound, "quantile %v", q)
+
+				// The fraction of values that are at most the exact quantile.
+				exact := sorted[int(math.Ceil(q*float64(len(sorted))))-1]
+				atMost := float64(sort.Search(len(sorted), func(i int) bool { return sorted[i] > exact }))
+				cdf, ok := s.CDF(exact)
+				assert.True(t, ok)
+				assert.InDelta(t, atMost/float64(len(sorted)), cdf, rankErrorBound, "cdf at quantile %v", q)
+			}
+		})
+	}
+}
+
+func TestKLLSmallK(t *testing.T) {
+	t.Parallel()
+
+	values := generate(100000, 7, func(r *rand.Rand) float64 { return r.Float64() })
+	sorted := sortedCopy(values)
+
+	small, _ := sketch.NewKLL(sketch.MinK)
+	large, _ := sketch.NewKLL(sketch.DefaultK)
+	small.AddAll(values...)
+	large.AddAll(values...)
+
+	smallError, largeError := 0.0, 0.0
+	for _, q := range quantiles {
+		value, _ := small.Quantile(q)
+		smallError += rankError(sorted, value, q)
+
+		value, _ = large.Quantile(q)
+		largeError += rankError(sorted, value, q)
+	}
+
+	assert.Less(t, largeError, smallError)
+}
+
+func TestKLLCDF(t *testing.T) {
+	t.Parallel()
+
+	s, _ := sketch.NewKLL(sketch.DefaultK)
+	s.AddAll(1, 2, 3, 4, math.NaN())
+
+	assert.Equal(t, uint64(4), s.Count())
+
+	tests := []struct {
+		x        float64
+		expected float64
+	}{
+		{x: 0, expected: 0},
+		{x: 1, expected: 0.25},
+		{x: 2.5, expected: 0.5},
+		{x: 4, expected: 1},
+		{x: 100, expected: 1},
+	}
+	for _, testCase := range tests {
+		cdf, ok := s.CDF(testCase.x)
+		assert.True(t, ok)
+		assert.Equal(t, testCase.expected, cdf)
+	}
+
+	_, ok := s.CDF(math.NaN())
+	assert.False(t, ok)
+
+	_, ok = s.Quantile(1.5)
+	assert.False(t, ok)
+
+	_, ok = s.Quantile(math.NaN())
+	assert.False(t, ok)
+
+	median, _ := s.Quantile(0.5)
+	assert.Equal(t, 2.0, median)
+}
+
+func TestKLLMerge(t *testing.T) {
+	t.Parallel()
+
+	values := generate(300000, 8, func(r *rand.Rand) float64 { return math.Exp(r.NormFloat64()) })
+	sorted := sortedCopy(values)
+
+	// Merge sketches of parts of the stream, as separate jobs would.
+	merged, _ := sketch.NewKLL(sketch.DefaultK)
+	for start := 0; start < len(values); start += 50000 {
+		part, _ := sketch.NewKLL(sketch.DefaultK)
+		part.AddAll(values[start : start+50000]...)
+		assert.True(t, merged.Merge(part))
+	}
+
+	assert.Equal(t, uint64(len(values)), merged.Count())
+
+	minimum, _ := merged.Min()
+	maximum, _ := merged.Max()
+	assert.Equal(t, sorted[0], minimum)
+	assert.Equal(t, sorted[len(sorted)-1], maximum)
+
+	for _, q := range quantiles {
+		value, _ := merged.Quantile(q)
+		assert.LessOrEqual(t, rankError(sorted, value, q), rankErrorBound, "quantile %v", q)
+	}
+
+	assert.True(t, merged.Merge(merged))
+	assert.Equal(t, uint64(2*len(values)), merged.Count())
+
+	median, _ := merged.Quantile(0.5)
+	assert.LessOrEqual(t, rankError(sorted, median, 0.5), rankErrorBound)
+
+	other, _ := sketch.NewKLL(100)
+	assert.False(t, merged.Merge(other))
+
+	merged.Clear()
+	assert.True(t, merged.Empty())
+}
+
+func TestKLLMarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	s, _ := sketch.NewKLL(64)
+	s.AddAll(generate(20000, 9, func(r *rand.Rand) float64 { return r.ExpFloat64() })...)
+
+	data, err := s.MarshalBinary()
+	assert.NoError(t, err)
+
+	restored, _ := sketch.NewKLL(sketch.DefaultK)
+	assert.NoError(t, restored.UnmarshalBinary(data))
+	assert.Equal(t, s.String(), restored.String())
+	assert.Equal(t, 64, restored.K())
+
+	for _, q := range quantiles {
+		expected, _ := s.Quantile(q)
+		actual, _ := restored.Quantile(q)
+		assert.Equal(t, expected, actual)
+	}
+
+	other, _ := sketch.NewKLL(64)
+	other.Add(-1)
+	assert.True(t, restored.Merge(other))
+
+	minimum, _ := restored.Min()
+	assert.Equal(t, -1.0, minimum)
+
+	empty, _ := sketch.NewKLL(sketch.DefaultK)
+	data, err = empty.MarshalBinary()
+	assert.NoError(t, err)
+	assert.NoError(t, restored.UnmarshalBinary(data))
+	assert.True(t, restored.Empty())
+}
+
+func TestKLLUnmarshalBinaryInvalid(t *testing.T) {
+	t.Parallel()
+
+	s, _ := sketch.NewKLL(sketch.DefaultK)
+	s.AddAll(1, 2, 3)
+
+	data, _ := s.MarshalBinary()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "truncated", data: data[:len(data)-1]},
+		{name: "trailing data", data: append(append([]byte(nil), data...), 0)},
+		{name: "wrong format", data: append([]byte{0}, data[1:]...)},
+		{name: "wrong count", data: func() []byte {
+			corrupt := append([]byte(nil), data...)
+			corrupt[5]++
+
+			return corrupt
+		}()},
+	}
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			restored, _ := sketch.NewKLL(sketch.DefaultK)
+			assert.ErrorIs(t, restored.UnmarshalBinary(testCase.data), sketch.ErrInvalidEncoding)
+			assert.True(t, restored.Empty())
+		})
+	}
+}
+
+func TestKLLString(t *testing.T) {
+	t.Parallel()
+
+	s, _ := sketch.NewKLL(sketch.DefaultK)
+	s.AddAll(3, 1, 2)
+
+	resultLines := strings.Split(s.String(), "\n")
+	assert.Len(t, resultLines, 2)
+	assert.Equal(t, "KLL", resultLines[0])
+	assert.Equal(t, "k=200, count=3, retained=3", resultLines[1])
+}
@@ -3,8 +3,39 @@ package arraystack
 import (
 	"fmt"
 	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/iterator"
 )
 
+type arrayStackIterator[T any] struct {
+	position int
+	stack    *ArrayStack[T]
+}
+
+func (a *arrayStackIterator[T]) Key() (int, bool) {
+	return a.position, a.position >= 0 && a.position < a.stack.Size()
+}
+
+func (a *arrayStackIterator[T]) Value() (T, bool) {
+	return a.stack.get(a.position)
+}
+
+func (a *arrayStackIterator[T]) Next() (iterator.ForwardIterator[int, T], bool) {
+	if !a.HasNext() {
+		return nil, false
+	}
+
+	return &arrayStackIterator[T]{
+		position: a.position + 1,
+		stack:    a.stack,
+	}, true
+}
+
+func (a *arrayStackIterator[T]) HasNext() bool {
+	return a.position+1 < a.stack.Size()
+}
+
 type ArrayStack[T any] struct {
 	values []T
 }
@@ -44,6 +75,54 @@ func (s *ArrayStack[T]) String() string {
 	return sb.String()
 }
 
+func (s *ArrayStack[T]) ForEach(op enumerable.Op[int, T]) {
+	for position := 0; position < s.Size(); position++ {
+		op(position, s.values[s.index(position)])
+	}
+}
+
+func (s *ArrayStack[T]) Any(predicate enumerable.Predicate[int, T]) bool {
+	for position := 0; position < s.Size(); position++ {
+		if predicate(position, s.values[s.index(position)]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *ArrayStack[T]) All(predicate enumerable.Predicate[int, T]) bool {
+	for position := 0; position < s.Size(); position++ {
+		if !predicate(position, s.values[s.index(position)]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *ArrayStack[T]) Find(predicate enumerable.Predicate[int, T]) (int, T, bool) {
+	for position := 0; position < s.Size(); position++ {
+		value := s.values[s.index(position)]
+		if predicate(position, value) {
+			return position, value, true
+		}
+	}
+
+	return 0, *new(T), false
+}
+
+func (s *ArrayStack[T]) Iterator() (iterator.ForwardIterator[int, T], bool) {
+	if s.Empty() {
+		return nil, false
+	}
+
+	return &arrayStackIterator[T]{
+		position: 0,
+		stack:    s,
+	}, true
+}
+
 func (s *ArrayStack[T]) Push(value T) {
 	s.values = append(s.values, value)
 }
@@ -70,3 +149,16 @@ func (s *ArrayStack[T]) Peek() (T, bool) {
 
 	return s.values[len(s.values)-1], true
 }
+
+func (s *ArrayStack[T]) get(position int) (T, bool) {
+	if position < 0 || position >= s.Size() {
+		return *new(T), false
+	}
+
+	return s.values[s.index(position)], true
+}
+
+// index converts a position counted from the top of the stack into an index of the values slice.
+func (s *ArrayStack[T]) index(position int) int {
+	return len(s.values) - 1 - position
+}
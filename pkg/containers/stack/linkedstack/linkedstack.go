@@ -3,8 +3,39 @@ package linkedstack
 import (
 	"fmt"
 	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/iterator"
 )
 
+type linkedStackIterator[T any] struct {
+	position int
+	node     *node[T]
+}
+
+func (a *linkedStackIterator[T]) Key() (int, bool) {
+	return a.position, a.node != nil
+}
+
+func (a *linkedStackIterator[T]) Value() (T, bool) {
+	return a.node.value, true
+}
+
+func (a *linkedStackIterator[T]) Next() (iterator.ForwardIterator[int, T], bool) {
+	if !a.HasNext() {
+		return nil, false
+	}
+
+	return &linkedStackIterator[T]{
+		position: a.position + 1,
+		node:     a.node.prev,
+	}, true
+}
+
+func (a *linkedStackIterator[T]) HasNext() bool {
+	return a.node.prev != nil
+}
+
 type node[T any] struct {
 	value T
 	prev  *node[T]
@@ -55,6 +86,53 @@ func (s *LinkedStack[T]) String() string {
 	return sb.String()
 }
 
+func (s *LinkedStack[T]) ForEach(op enumerable.Op[int, T]) {
+	for i, node := 0, s.head; node != nil; i, node = i+1, node.prev {
+		op(i, node.value)
+	}
+}
+
+func (s *LinkedStack[T]) Any(predicate enumerable.Predicate[int, T]) bool {
+	for i, node := 0, s.head; node != nil; i, node = i+1, node.prev {
+		if predicate(i, node.value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *LinkedStack[T]) All(predicate enumerable.Predicate[int, T]) bool {
+	for i, node := 0, s.head; node != nil; i, node = i+1, node.prev {
+		if !predicate(i, node.value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *LinkedStack[T]) Find(predicate enumerable.Predicate[int, T]) (int, T, bool) {
+	for i, node := 0, s.head; node != nil; i, node = i+1, node.prev {
+		if predicate(i, node.value) {
+			return i, node.value, true
+		}
+	}
+
+	return 0, *new(T), false
+}
+
+func (s *LinkedStack[T]) Iterator() (iterator.ForwardIterator[int, T], bool) {
+	if s.Empty() {
+		return nil, false
+	}
+
+	return &linkedStackIterator[T]{
+		position: 0,
+		node:     s.head,
+	}, true
+}
+
 func (s *LinkedStack[T]) Push(value T) {
 	s.head = &node[T]{
 		value: value,
@@ -1,9 +1,18 @@
 package stack
 
-import "github.com/kaschnit/go-ds/pkg/containers/container"
+import (
+	"github.com/kaschnit/go-ds/pkg/containers/container"
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	"github.com/kaschnit/go-ds/pkg/containers/iterable"
+)
 
+// Stack is a last-in-first-out container.
+// Enumeration and iteration visit the items from the top of the stack to the bottom,
+// keyed by their position in that order, without modifying the stack.
 type Stack[T any] interface {
 	container.Container
+	enumerable.Enumerable[int, T]
+	iterable.ForwardIterable[int, T]
 
 	Push(value T)
 	PushAll(values ...T)
@@ -335,3 +335,118 @@ func TestPopUntilEmpty(t *testing.T) {
 		})
 	}
 }
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	stacks := getStacksForTest(50, 100, 200, 500)
+	for i := range stacks {
+		s := stacks[i]
+		t.Run(fmt.Sprintf("%T", s), func(t *testing.T) {
+			t.Parallel()
+
+			actual := make([]int, 0, s.Size())
+			s.ForEach(func(key int, value int) {
+				assert.Equal(t, len(actual), key)
+				actual = append(actual, value)
+			})
+			assert.Equal(t, []int{500, 200, 100, 50}, actual)
+			assert.Equal(t, 4, s.Size())
+		})
+	}
+}
+
+func TestAnyAllFind(t *testing.T) {
+	t.Parallel()
+
+	stacks := getStacksForTest(50, 100, 200, 500)
+	for i := range stacks {
+		s := stacks[i]
+		t.Run(fmt.Sprintf("%T", s), func(t *testing.T) {
+			t.Parallel()
+
+			assert.True(t, s.Any(func(_ int, value int) bool { return value == 100 }))
+			assert.False(t, s.Any(func(_ int, value int) bool { return value == 101 }))
+			assert.True(t, s.All(func(_ int, value int) bool { return value >= 50 }))
+			assert.False(t, s.All(func(_ int, value int) bool { return value > 50 }))
+
+			key, value, ok := s.Find(func(_ int, value int) bool { return value < 300 })
+			assert.True(t, ok)
+			assert.Equal(t, 1, key)
+			assert.Equal(t, 200, value)
+
+			_, _, ok = s.Find(func(_ int, value int) bool { return value > 1000 })
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestIteration(t *testing.T) {
+	t.Parallel()
+
+	stacks := getStacksForTest(100, 200, 500)
+	for i := range stacks {
+		s := stacks[i]
+		t.Run(fmt.Sprintf("%T", s), func(t *testing.T) {
+			t.Parallel()
+
+			// Iterator to the top of the stack
+			itr, ok := s.Iterator()
+			assert.True(t, ok)
+			assert.True(t, itr.HasNext())
+
+			key, ok := itr.Key()
+			assert.True(t, ok)
+			assert.Equal(t, 0, key)
+
+			val, ok := itr.Value()
+			assert.True(t, ok)
+			assert.Equal(t, 500, val)
+
+			// Iterator to the middle of the stack
+			itr, ok = itr.Next()
+			assert.True(t, ok)
+			assert.True(t, itr.HasNext())
+
+			key, ok = itr.Key()
+			assert.True(t, ok)
+			assert.Equal(t, 1, key)
+
+			val, ok = itr.Value()
+			assert.True(t, ok)
+			assert.Equal(t, 200, val)
+
+			// Iterator to the bottom of the stack
+			itr, ok = itr.Next()
+			assert.True(t, ok)
+			assert.False(t, itr.HasNext())
+
+			key, ok = itr.Key()
+			assert.True(t, ok)
+			assert.Equal(t, 2, key)
+
+			val, ok = itr.Value()
+			assert.True(t, ok)
+			assert.Equal(t, 100, val)
+
+			// Invalid iterator
+			_, ok = itr.Next()
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestIteration_Empty(t *testing.T) {
+	t.Parallel()
+
+	stacks := getStacksForTest[int]()
+	for i := range stacks {
+		s := stacks[i]
+		t.Run(fmt.Sprintf("%T - Iterator()", s), func(t *testing.T) {
+			t.Parallel()
+
+			_, ok := s.Iterator()
+			assert.False(t, ok)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package trie
+
+import (
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+)
+
+// Set is a set of strings backed by a Trie, supporting the same prefix queries.
+// Values are enumerated in lexicographic order.
+type Set struct {
+	values *Trie[struct{}]
+}
+
+func NewSet(values ...string) *Set {
+	s := Set{
+		values: New[struct{}](),
+	}
+	s.AddAll(values...)
+
+	return &s
+}
+
+func (s *Set) Empty() bool {
+	return s.Size() == 0
+}
+
+func (s *Set) Size() int {
+	return s.values.Size()
+}
+
+func (s *Set) Clear() {
+	s.values.Clear()
+}
+
+func (s *Set) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("TrieSet\n")
+	sb.WriteString(strings.Join(s.values.Keys(), ","))
+
+	return sb.String()
+}
+
+func (s *Set) ForEach(op enumerable.Op[string, string]) {
+	s.values.ForEach(func(value string, _ struct{}) {
+		op(value, value)
+	})
+}
+
+func (s *Set) Any(predicate enumerable.Predicate[string, string]) bool {
+	return s.values.Any(func(value string, _ struct{}) bool {
+		return predicate(value, value)
+	})
+}
+
+func (s *Set) All(predicate enumerable.Predicate[string, string]) bool {
+	return s.values.All(func(value string, _ struct{}) bool {
+		return predicate(value, value)
+	})
+}
+
+func (s *Set) Find(predicate enumerable.Predicate[string, string]) (string, string, bool) {
+	value, _, ok := s.values.Find(func(value string, _ struct{}) bool {
+		return predicate(value, value)
+	})
+
+	return value, value, ok
+}
+
+func (s *Set) Add(value string) {
+	s.values.Put(value, struct{}{})
+}
+
+func (s *Set) AddAll(values ...string) {
+	for _, value := range values {
+		s.Add(value)
+	}
+}
+
+func (s *Set) Remove(value string) bool {
+	return s.values.RemoveKey(value)
+}
+
+func (s *Set) RemoveAll(values ...string) int {
+	return s.values.RemoveAllKeys(values...)
+}
+
+func (s *Set) Contains(value string) bool {
+	return s.values.ContainsKey(value)
+}
+
+func (s *Set) ContainsAll(values ...string) bool {
+	return s.values.ContainsAllKeys(values...)
+}
+
+func (s *Set) ContainsAny(values ...string) bool {
+	return s.values.ContainsAnyKey(values...)
+}
+
+// WithPrefix returns the values that start with the prefix in lexicographic order.
+func (s *Set) WithPrefix(prefix string) []string {
+	return s.values.KeysWithPrefix(prefix)
+}
+
+// CountPrefix returns the number of values that start with the prefix.
+func (s *Set) CountPrefix(prefix string) int {
+	return s.values.CountPrefix(prefix)
+}
+
+// LongestPrefixOf returns the longest value that is a prefix of str.
+// Returns false if no value is a prefix of str.
+func (s *Set) LongestPrefixOf(str string) (string, bool) {
+	prefix, _, ok := s.values.LongestPrefixOf(str)
+
+	return prefix, ok
+}
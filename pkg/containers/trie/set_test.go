@@ -0,0 +1,83 @@
+package trie_test
+
+import (
+	"testing"
+
+	"github.com/kaschnit/go-ds/pkg/containers/set"
+	"github.com/kaschnit/go-ds/pkg/containers/set/hashset"
+	"github.com/kaschnit/go-ds/pkg/containers/trie"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that trie.Set implements Set.
+var _ set.Set[string] = trie.NewSet()
+
+func TestSetString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "TrieSet\n", trie.NewSet().String())
+	assert.Equal(t, "TrieSet\na,ab,b", trie.NewSet("b", "ab", "a", "b").String())
+}
+
+func TestSetAddRemoveContains(t *testing.T) {
+	t.Parallel()
+
+	s := trie.NewSet("commit", "checkout")
+	assert.Equal(t, 2, s.Size())
+
+	s.AddAll("cherry-pick", "commit")
+	assert.Equal(t, 3, s.Size())
+	assert.True(t, s.Contains("commit"))
+	assert.False(t, s.Contains("c"))
+	assert.True(t, s.ContainsAll("commit", "checkout"))
+	assert.True(t, s.ContainsAny("c", "checkout"))
+	assert.False(t, s.ContainsAny("c", "ch"))
+
+	assert.True(t, s.Remove("commit"))
+	assert.False(t, s.Remove("commit"))
+	assert.Equal(t, 1, s.RemoveAll("checkout", "push"))
+	assert.Equal(t, 1, s.Size())
+
+	s.Clear()
+	assert.True(t, s.Empty())
+}
+
+func TestSetPrefixQueries(t *testing.T) {
+	t.Parallel()
+
+	s := trie.NewSet("status", "stash", "show", "switch")
+	assert.Equal(t, []string{"stash", "status"}, s.WithPrefix("st"))
+	assert.Equal(t, 2, s.CountPrefix("st"))
+	assert.Equal(t, 4, s.CountPrefix(""))
+
+	prefix, ok := s.LongestPrefixOf("showing")
+	assert.True(t, ok)
+	assert.Equal(t, "show", prefix)
+
+	_, ok = s.LongestPrefixOf("sh")
+	assert.False(t, ok)
+}
+
+func TestSetEnumeration(t *testing.T) {
+	t.Parallel()
+
+	s := trie.NewSet("b", "a", "c")
+
+	values := []string{}
+	s.ForEach(func(key string, value string) {
+		assert.Equal(t, key, value)
+		values = append(values, value)
+	})
+	assert.Equal(t, []string{"a", "b", "c"}, values)
+
+	assert.True(t, s.Any(func(key string, value string) bool { return value == "c" }))
+	assert.False(t, s.All(func(key string, value string) bool { return value < "c" }))
+
+	key, value, ok := s.Find(func(key string, value string) bool { return value > "a" })
+	assert.True(t, ok)
+	assert.Equal(t, "b", key)
+	assert.Equal(t, "b", value)
+
+	// Works with the set algebra of the set package.
+	assert.True(t, set.Equals[string](s, hashset.New("a", "b", "c")))
+}
@@ -0,0 +1,381 @@
+package trie
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kaschnit/go-ds/pkg/containers/enumerable"
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+)
+
+type node[V any] struct {
+	label    byte
+	children []*node[V]
+	value    V
+	hasValue bool
+
+	// count is the number of keys at or below the node.
+	count int
+}
+
+func newNode[V any](label byte) *node[V] {
+	return &node[V]{
+		label:    label,
+		children: nil,
+		value:    *new(V),
+		hasValue: false,
+		count:    0,
+	}
+}
+
+// child returns the child with the label, and where it is or would be inserted
+// in the sorted children.
+func (n *node[V]) child(label byte) (*node[V], int) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].label >= label
+	})
+
+	if i < len(n.children) && n.children[i].label == label {
+		return n.children[i], i
+	}
+
+	return nil, i
+}
+
+// forEach visits the keys at and below the node in lexicographic order, stopping early if
+// the op returns false. The key buffer holds the key of the node and is reused between calls.
+func (n *node[V]) forEach(key []byte, op func(key []byte, value V) bool) bool {
+	if n.hasValue && !op(key, n.value) {
+		return false
+	}
+
+	for _, child := range n.children {
+		if !child.forEach(append(key, child.label), op) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Trie is a map from strings to values that stores keys by their bytes in a tree, so keys that
+// share a prefix share the nodes for it. This makes prefix queries cost time proportional to
+// the length of the prefix plus the number of results, rather than the size of the map.
+// Keys are enumerated in lexicographic order.
+type Trie[V any] struct {
+	root *node[V]
+}
+
+func New[V any](entries ...entry.Entry[string, V]) *Trie[V] {
+	t := &Trie[V]{
+		root: newNode[V](0),
+	}
+	t.PutAll(entries...)
+
+	return t
+}
+
+func (t *Trie[V]) Empty() bool {
+	return t.Size() == 0
+}
+
+func (t *Trie[V]) Size() int {
+	return t.root.count
+}
+
+func (t *Trie[V]) Clear() {
+	t.root = newNode[V](0)
+}
+
+func (t *Trie[V]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("Trie\n")
+
+	strs := make([]string, 0, t.Size())
+	t.ForEach(func(key string, value V) {
+		strs = append(strs, entry.NewRef(key, value).String())
+	})
+
+	sb.WriteString(strings.Join(strs, ","))
+
+	return sb.String()
+}
+
+// ForEach visits the entries in lexicographic order of their keys.
+func (t *Trie[V]) ForEach(op enumerable.Op[string, V]) {
+	t.root.forEach(nil, func(key []byte, value V) bool {
+		op(string(key), value)
+
+		return true
+	})
+}
+
+func (t *Trie[V]) Any(predicate enumerable.Predicate[string, V]) bool {
+	_, _, found := t.Find(predicate)
+
+	return found
+}
+
+func (t *Trie[V]) All(predicate enumerable.Predicate[string, V]) bool {
+	return t.root.forEach(nil, func(key []byte, value V) bool {
+		return predicate(string(key), value)
+	})
+}
+
+// Find returns the first entry in lexicographic order that satisfies the predicate.
+func (t *Trie[V]) Find(predicate enumerable.Predicate[string, V]) (string, V, bool) {
+	foundKey, foundValue, found := "", *new(V), false
+
+	t.root.forEach(nil, func(key []byte, value V) bool {
+		if predicate(string(key), value) {
+			foundKey, foundValue, found = string(key), value, true
+
+			return false
+		}
+
+		return true
+	})
+
+	return foundKey, foundValue, found
+}
+
+func (t *Trie[V]) Keys() []string {
+	return t.KeysWithPrefix("")
+}
+
+func (t *Trie[V]) Values() []V {
+	values := make([]V, 0, t.Size())
+	t.ForEach(func(_ string, value V) {
+		values = append(values, value)
+	})
+
+	return values
+}
+
+func (t *Trie[V]) Entries() []entry.Entry[string, V] {
+	entries := make([]entry.Entry[string, V], 0, t.Size())
+	t.ForEach(func(key string, value V) {
+		entries = append(entries, entry.New(key, value))
+	})
+
+	return entries
+}
+
+func (t *Trie[V]) Get(key string) (V, bool) {
+	n := t.find(key)
+	if n == nil || !n.hasValue {
+		return *new(V), false
+	}
+
+	return n.value, true
+}
+
+func (t *Trie[V]) GetOrDefault(key string, defaultValue V) V {
+	if value, ok := t.Get(key); ok {
+		return value
+	}
+
+	return defaultValue
+}
+
+func (t *Trie[V]) Put(key string, value V) {
+	t.Swap(key, value)
+}
+
+func (t *Trie[V]) PutAll(entries ...entry.Entry[string, V]) {
+	for _, entry := range entries {
+		t.Put(entry.Key(), entry.Value())
+	}
+}
+
+func (t *Trie[V]) PutAllFrom(other mapp.ReadOnlyMap[string, V]) {
+	t.PutAll(other.Entries()...)
+}
+
+func (t *Trie[V]) Swap(key string, value V) (V, bool) {
+	path := make([]*node[V], 0, len(key)+1)
+	path = append(path, t.root)
+
+	n := t.root
+	for i := 0; i < len(key); i++ {
+		child, at := n.child(key[i])
+		if child == nil {
+			child = newNode[V](key[i])
+			n.children = append(n.children, nil)
+			copy(n.children[at+1:], n.children[at:])
+			n.children[at] = child
+		}
+
+		n = child
+		path = append(path, n)
+	}
+
+	old, existed := n.value, n.hasValue
+	n.value = value
+	n.hasValue = true
+
+	if !existed {
+		for _, p := range path {
+			p.count++
+		}
+	}
+
+	return old, existed
+}
+
+func (t *Trie[V]) Replace(key string, value V) bool {
+	n := t.find(key)
+	if n == nil || !n.hasValue {
+		return false
+	}
+
+	n.value = value
+
+	return true
+}
+
+func (t *Trie[V]) RemoveKey(key string) bool {
+	if !t.ContainsKey(key) {
+		return false
+	}
+
+	n := t.root
+	n.count--
+
+	for i := 0; i < len(key); i++ {
+		child, at := n.child(key[i])
+
+		// Nothing else is below the child, so the rest of the path can be dropped.
+		if child.count == 1 {
+			n.children = append(n.children[:at], n.children[at+1:]...)
+
+			return true
+		}
+
+		child.count--
+		n = child
+	}
+
+	n.value = *new(V)
+	n.hasValue = false
+
+	return true
+}
+
+func (t *Trie[V]) RemoveAllKeys(keys ...string) int {
+	removed := 0
+
+	for _, key := range keys {
+		if t.RemoveKey(key) {
+			removed++
+		}
+	}
+
+	return removed
+}
+
+func (t *Trie[V]) RemoveIf(predicate enumerable.Predicate[string, V]) int {
+	keys := []string{}
+	t.ForEach(func(key string, value V) {
+		if predicate(key, value) {
+			keys = append(keys, key)
+		}
+	})
+
+	return t.RemoveAllKeys(keys...)
+}
+
+func (t *Trie[V]) ContainsKey(key string) bool {
+	_, ok := t.Get(key)
+
+	return ok
+}
+
+func (t *Trie[V]) ContainsAllKeys(keys ...string) bool {
+	for _, key := range keys {
+		if !t.ContainsKey(key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *Trie[V]) ContainsAnyKey(keys ...string) bool {
+	for _, key := range keys {
+		if t.ContainsKey(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// KeysWithPrefix returns the keys that start with the prefix in lexicographic order.
+func (t *Trie[V]) KeysWithPrefix(prefix string) []string {
+	keys := []string{}
+	t.ForEachWithPrefix(prefix, func(key string, _ V) {
+		keys = append(keys, key)
+	})
+
+	return keys
+}
+
+// ForEachWithPrefix visits the entries whose keys start with the prefix
+// in lexicographic order of their keys.
+func (t *Trie[V]) ForEachWithPrefix(prefix string, op enumerable.Op[string, V]) {
+	n := t.find(prefix)
+	if n == nil {
+		return
+	}
+
+	n.forEach([]byte(prefix), func(key []byte, value V) bool {
+		op(string(key), value)
+
+		return true
+	})
+}
+
+// CountPrefix returns the number of keys that start with the prefix.
+func (t *Trie[V]) CountPrefix(prefix string) int {
+	n := t.find(prefix)
+	if n == nil {
+		return 0
+	}
+
+	return n.count
+}
+
+// LongestPrefixOf returns the longest key that is a prefix of s, along with its value.
+// Returns false if no key is a prefix of s.
+func (t *Trie[V]) LongestPrefixOf(s string) (string, V, bool) {
+	length, value, found := 0, *new(V), false
+
+	n := t.root
+	for i := 0; ; i++ {
+		if n.hasValue {
+			length, value, found = i, n.value, true
+		}
+
+		if i == len(s) {
+			break
+		}
+
+		if n, _ = n.child(s[i]); n == nil {
+			break
+		}
+	}
+
+	return s[:length], value, found
+}
+
+// find returns the node for the key, or nil if no key starts with it.
+func (t *Trie[V]) find(key string) *node[V] {
+	n := t.root
+	for i := 0; i < len(key) && n != nil; i++ {
+		n, _ = n.child(key[i])
+	}
+
+	return n
+}
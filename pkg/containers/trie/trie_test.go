@@ -0,0 +1,220 @@
+package trie_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	mapp "github.com/kaschnit/go-ds/pkg/containers/map"
+	"github.com/kaschnit/go-ds/pkg/containers/map/entry"
+	"github.com/kaschnit/go-ds/pkg/containers/map/hashmap"
+	"github.com/kaschnit/go-ds/pkg/containers/trie"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensure that Trie implements Map.
+var _ mapp.Map[string, int] = trie.New[int]()
+
+func TestTrieString(t *testing.T) {
+	t.Parallel()
+
+	tr := trie.New[int]()
+	assert.Equal(t, "Trie\n", tr.String())
+
+	tr.Put("b", 2)
+	tr.Put("a", 1)
+	assert.Equal(t, "Trie\nEntry{Key:a, Value:1},Entry{Key:b, Value:2}", tr.String())
+}
+
+func TestTrieLexicographicOrder(t *testing.T) {
+	t.Parallel()
+
+	keys := []string{"tea", "", "ten", "to", "a", "inn", "in", "i", "te", "Z", "\xff"}
+	tr := trie.New[int]()
+	for i, key := range keys {
+		tr.Put(key, i)
+	}
+
+	sorted := append([]string{}, keys...)
+	sort.Strings(sorted)
+	assert.Equal(t, sorted, tr.Keys())
+
+	visited := []string{}
+	tr.ForEach(func(key string, value int) {
+		visited = append(visited, key)
+		assert.Equal(t, keys[value], key)
+	})
+	assert.Equal(t, sorted, visited)
+	assert.Len(t, tr.Values(), len(keys))
+	assert.Equal(t, entry.New("", 1), tr.Entries()[0])
+}
+
+func TestTriePutGetRemove(t *testing.T) {
+	t.Parallel()
+
+	tr := trie.New(entry.New("in", 1), entry.New("inn", 2))
+	assert.Equal(t, 2, tr.Size())
+
+	// A prefix of a key is not a key itself.
+	assert.False(t, tr.ContainsKey("i"))
+	assert.False(t, tr.RemoveKey("i"))
+	assert.True(t, tr.ContainsAllKeys("in", "inn"))
+
+	old, existed := tr.Swap("in", 10)
+	assert.True(t, existed)
+	assert.Equal(t, 1, old)
+	assert.True(t, tr.Replace("inn", 20))
+	assert.False(t, tr.Replace("i", 0))
+
+	// Removing a key keeps the keys that continue past it.
+	assert.True(t, tr.RemoveKey("in"))
+	assert.False(t, tr.ContainsKey("in"))
+	assert.Equal(t, 20, tr.GetOrDefault("inn", 0))
+	assert.Equal(t, 1, tr.CountPrefix("i"))
+
+	// Removing the last key below a prefix removes the prefix too.
+	assert.True(t, tr.RemoveKey("inn"))
+	assert.True(t, tr.Empty())
+	assert.Equal(t, 0, tr.CountPrefix("i"))
+	assert.Equal(t, []string{}, tr.KeysWithPrefix("i"))
+
+	tr.Put("", 5)
+	assert.Equal(t, 5, tr.GetOrDefault("", 0))
+	assert.Equal(t, 1, tr.Size())
+	tr.Clear()
+	assert.True(t, tr.Empty())
+}
+
+func TestTrieKeysWithPrefix(t *testing.T) {
+	t.Parallel()
+
+	tr := trie.New(
+		entry.New("git", 0),
+		entry.New("git-commit", 0),
+		entry.New("git-checkout", 0),
+		entry.New("go", 0),
+		entry.New("gofmt", 0),
+	)
+
+	tests := []struct {
+		prefix        string
+		expectedKeys  []string
+		expectedCount int
+	}{
+		{prefix: "", expectedKeys: []string{"git", "git-checkout", "git-commit", "go", "gofmt"}, expectedCount: 5},
+		{prefix: "git-c", expectedKeys: []string{"git-checkout", "git-commit"}, expectedCount: 2},
+		{prefix: "go", expectedKeys: []string{"go", "gofmt"}, expectedCount: 2},
+		{prefix: "gofmt", expectedKeys: []string{"gofmt"}, expectedCount: 1},
+		{prefix: "gofmtx", expectedKeys: []string{}, expectedCount: 0},
+		{prefix: "h", expectedKeys: []string{}, expectedCount: 0},
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.prefix, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expectedKeys, tr.KeysWithPrefix(testCase.prefix))
+			assert.Equal(t, testCase.expectedCount, tr.CountPrefix(testCase.prefix))
+		})
+	}
+}
+
+func TestTrieLongestPrefixOf(t *testing.T) {
+	t.Parallel()
+
+	tr := trie.New(entry.New("/", 1), entry.New("/api", 2), entry.New("/api/v1", 3))
+
+	tests := []struct {
+		input         string
+		expectedKey   string
+		expectedValue int
+		expectedOk    bool
+	}{
+		{input: "/api/v1/users", expectedKey: "/api/v1", expectedValue: 3, expectedOk: true},
+		{input: "/api/v2", expectedKey: "/api", expectedValue: 2, expectedOk: true},
+		{input: "/api", expectedKey: "/api", expectedValue: 2, expectedOk: true},
+		{input: "/static", expectedKey: "/", expectedValue: 1, expectedOk: true},
+		{input: "api", expectedKey: "", expectedValue: 0, expectedOk: false},
+		{input: "", expectedKey: "", expectedValue: 0, expectedOk: false},
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+		t.Run(testCase.input, func(t *testing.T) {
+			t.Parallel()
+
+			key, value, ok := tr.LongestPrefixOf(testCase.input)
+			assert.Equal(t, testCase.expectedOk, ok)
+			assert.Equal(t, testCase.expectedKey, key)
+			assert.Equal(t, testCase.expectedValue, value)
+		})
+	}
+}
+
+func TestTrieEnumeration(t *testing.T) {
+	t.Parallel()
+
+	tr := trie.New(entry.New("b", 2), entry.New("a", 1), entry.New("c", 3))
+	assert.True(t, tr.Any(func(key string, value int) bool { return value == 2 }))
+	assert.False(t, tr.Any(func(key string, value int) bool { return value == 4 }))
+	assert.True(t, tr.All(func(key string, value int) bool { return value > 0 }))
+	assert.False(t, tr.All(func(key string, value int) bool { return value < 3 }))
+
+	// Find returns the first match in lexicographic order.
+	key, value, ok := tr.Find(func(key string, value int) bool { return value > 1 })
+	assert.True(t, ok)
+	assert.Equal(t, "b", key)
+	assert.Equal(t, 2, value)
+
+	assert.Equal(t, 2, tr.RemoveIf(func(key string, value int) bool { return key != "b" }))
+	assert.Equal(t, []string{"b"}, tr.Keys())
+
+	tr.PutAllFrom(hashmap.New(entry.New("d", 4)))
+	assert.Equal(t, []string{"b", "d"}, tr.Keys())
+}
+
+func TestTrieRandomOperations(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(11))
+	alphabet := "abc"
+	randomKey := func() string {
+		key := make([]byte, rng.Intn(5))
+		for i := range key {
+			key[i] = alphabet[rng.Intn(len(alphabet))]
+		}
+
+		return string(key)
+	}
+
+	tr := trie.New[int]()
+	expected := map[string]int{}
+
+	for i := 0; i < 5000; i++ {
+		key := randomKey()
+		if rng.Intn(3) == 0 {
+			_, existed := expected[key]
+			assert.Equal(t, existed, tr.RemoveKey(key))
+			delete(expected, key)
+		} else {
+			tr.Put(key, i)
+			expected[key] = i
+		}
+
+		prefix := randomKey()
+		count := 0
+		for k := range expected {
+			if len(k) >= len(prefix) && k[:len(prefix)] == prefix {
+				count++
+			}
+		}
+		assert.Equal(t, count, tr.CountPrefix(prefix))
+		assert.Len(t, tr.KeysWithPrefix(prefix), count)
+	}
+
+	assert.Equal(t, len(expected), tr.Size())
+	for key, value := range expected {
+		assert.Equal(t, value, tr.GetOrDefault(key, -1))
+	}
+}